@@ -47,7 +47,7 @@ clear-envtest-cache: ## Clear envtest ports cache
 
 .PHONY: test-unit
 test-unit: ginkgo generate fmt vet envtest ## Run tests.
-	KUBEBUILDER_ASSETS="$(shell $(ENVTEST) use $(ENVTEST_K8S_VERSION) -p path)" $(GINKGO) -p --nodes 4 --cover -r -randomize-all --randomize-suites --skip-package=tests ./...
+	KUBEBUILDER_ASSETS="$(shell $(ENVTEST) use $(ENVTEST_K8S_VERSION) -p path)" $(GINKGO) -p --nodes 4 --cover --race -r -randomize-all --randomize-suites --skip-package=tests ./...
 
 .PHONY: start-localstack
 start-localstack: docker-compose ## Run localstack with docker-compose
@@ -61,6 +61,10 @@ stop-localstack: docker-compose ## Run localstack with docker-compose
 test-integration: ginkgo ## Run integration tests
 	$(GINKGO) -p --nodes 4 -r -randomize-all --randomize-suites tests/integration/
 
+.PHONY: test-e2e
+test-e2e: ginkgo envtest ## Run end-to-end tests against the in-memory AWS fake
+	KUBEBUILDER_ASSETS="$(shell $(ENVTEST) use $(ENVTEST_K8S_VERSION) -p path)" $(GINKGO) -r -randomize-all --randomize-suites tests/fakeaws/ tests/e2e/
+
 .PHONY: run-acceptance-tests
 run-acceptance-tests: KUBECONFIG=$(HOME)/.kube/$(CLUSTER).yml
 run-acceptance-tests:
@@ -75,7 +79,7 @@ test-acceptance: ginkgo deploy-acceptance-cluster run-acceptance-tests## Run acc
 
 
 .PHONY: test-all
-test-all: lint lint-imports test-unit test-integration test-acceptance ## Run all tests and litner
+test-all: lint lint-imports test-unit test-e2e test-integration test-acceptance ## Run all tests and litner
 
 ##@ Deployment
 
@@ -0,0 +1,381 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// NetworkTopologyMode is the mode in which the network topology of a cluster is managed.
+// +kubebuilder:validation:Enum=None;UserManaged;GiantSwarmManaged
+type NetworkTopologyMode string
+
+const (
+	// NetworkTopologyModeNone means the operator doesn't manage any network topology for the cluster.
+	NetworkTopologyModeNone NetworkTopologyMode = "None"
+	// NetworkTopologyModeUserManaged means the transit gateway and prefix list are owned by the
+	// user and the operator only attaches the cluster VPC to them.
+	NetworkTopologyModeUserManaged NetworkTopologyMode = "UserManaged"
+	// NetworkTopologyModeGiantSwarmManaged means the operator creates and manages the transit
+	// gateway and prefix list.
+	NetworkTopologyModeGiantSwarmManaged NetworkTopologyMode = "GiantSwarmManaged"
+)
+
+// DeletionPolicy decides what happens to the AWS resources of a cluster when it is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete means the attachment and prefix list entries of the cluster are
+	// removed and the transit gateway, prefix lists and RAM shares created for it are deleted.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain means the attachment and prefix list entries of the cluster are
+	// removed, but the transit gateway, prefix lists and RAM shares are kept, e.g. to rebuild a
+	// management cluster without interrupting the connectivity of its workload clusters.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyOrphan means no AWS resource is modified when the cluster is deleted.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// AWSResourceReference references an AWS resource either by ID or by ARN.
+type AWSResourceReference struct {
+	// ID of the AWS resource, e.g. tgw-0123456789abcdef0.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// ARN of the AWS resource. The operator always writes the ARN back once the resource is known.
+	// +optional
+	ARN string `json:"arn,omitempty"`
+}
+
+// IsZero returns true if neither the ID nor the ARN is set.
+func (r *AWSResourceReference) IsZero() bool {
+	return r == nil || (r.ID == "" && r.ARN == "")
+}
+
+// NetworkTopologySpec defines the desired network topology of a Cluster.
+type NetworkTopologySpec struct {
+	// Mode in which the network topology is managed. Defaults to None.
+	// +kubebuilder:default=None
+	// +optional
+	Mode NetworkTopologyMode `json:"mode,omitempty"`
+
+	// DeletionPolicy for the AWS resources of the cluster. Defaults to the deletion policy the
+	// operator is configured with.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// TransitGateway the cluster VPC is attached to. Workload clusters default to the
+	// transit gateway of the management cluster.
+	// +optional
+	TransitGateway *AWSResourceReference `json:"transitGateway,omitempty"`
+
+	// PrefixList containing the CIDRs of all clusters attached to the transit gateway.
+	// +optional
+	PrefixList *AWSResourceReference `json:"prefixList,omitempty"`
+
+	// IPv6PrefixList containing the IPv6 CIDRs of all dual-stack clusters attached to the
+	// transit gateway.
+	// +optional
+	IPv6PrefixList *AWSResourceReference `json:"ipv6PrefixList,omitempty"`
+
+	// AttachmentSubnetSelector selects the subnets used for the transit gateway VPC attachment
+	// by tag. When empty, private subnets tagged with `subnet.giantswarm.io/tgw` are used, or the
+	// first private subnet of each availability zone if none are tagged.
+	// +optional
+	AttachmentSubnetSelector map[string]string `json:"attachmentSubnetSelector,omitempty"`
+
+	// RouteTableGroup is the isolation domain of the cluster, e.g. prod, dev or shared-services.
+	// The transit gateway VPC attachment is associated with a dedicated transit gateway route
+	// table per group which is created by the operator. When empty, clusters attached to transit
+	// gateways created by the operator use the "default" group, clusters attached to other
+	// transit gateways keep using the default route table of the transit gateway.
+	// Only supported in GiantSwarmManaged mode.
+	// +optional
+	RouteTableGroup string `json:"routeTableGroup,omitempty"`
+
+	// PropagateToRouteTableGroups lists additional route table groups the routes of the cluster
+	// VPC are propagated to. Routes are always propagated to the route table of the cluster's own
+	// group, other groups can only reach the cluster when listed here.
+	// +optional
+	PropagateToRouteTableGroups []string `json:"propagateToRouteTableGroups,omitempty"`
+
+	// Peerings to transit gateways of other management clusters or regions. Only supported for
+	// management clusters in GiantSwarmManaged mode.
+	// +optional
+	Peerings []TransitGatewayPeeringSpec `json:"peerings,omitempty"`
+}
+
+// TransitGatewayPeeringSpec describes a peering of the transit gateway with a peer transit gateway.
+type TransitGatewayPeeringSpec struct {
+	// TransitGatewayARN of the peer transit gateway. The account and region of the peer are
+	// taken from the ARN.
+	// +kubebuilder:validation:Pattern=`^arn:[^:]+:ec2:[^:]+:[0-9]{12}:transit-gateway/tgw-[0-9a-f]+$`
+	TransitGatewayARN string `json:"transitGatewayARN"`
+
+	// PrefixListARN of the prefix list containing the CIDRs of the peer. Static transit gateway
+	// routes through the peering are created for all of its entries. The prefix list is read in
+	// the region of the peer and therefore needs to be shared with the account of this cluster
+	// if the peer is owned by another account.
+	// +optional
+	PrefixListARN string `json:"prefixListARN,omitempty"`
+
+	// RouteTableGroup the peering attachment is associated with and the static routes to the peer
+	// are created in. Defaults to the route table group used for clusters without a group.
+	// +optional
+	RouteTableGroup string `json:"routeTableGroup,omitempty"`
+}
+
+// TransitGatewayAttachmentStatus describes the VPC attachment of the cluster.
+type TransitGatewayAttachmentStatus struct {
+	// ID of the transit gateway VPC attachment.
+	ID string `json:"id"`
+
+	// State of the transit gateway VPC attachment as reported by AWS.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// RouteTableID of the transit gateway route table the attachment is associated with.
+	// +optional
+	RouteTableID string `json:"routeTableID,omitempty"`
+
+	// AcceptanceRequest describes the SNS messages requesting the acceptance of the attachment
+	// in UserManaged mode.
+	// +optional
+	AcceptanceRequest *AttachmentAcceptanceRequestStatus `json:"acceptanceRequest,omitempty"`
+
+	// Recreations is the number of times a failed or deleted attachment was recreated since the
+	// attachment was last available.
+	// +optional
+	Recreations int32 `json:"recreations,omitempty"`
+}
+
+// AttachmentAcceptanceRequestStatus describes the SNS messages requesting the acceptance of a
+// transit gateway VPC attachment.
+type AttachmentAcceptanceRequestStatus struct {
+	// MessageID of the last SNS message.
+	// +optional
+	MessageID string `json:"messageID,omitempty"`
+
+	// LastNotificationTime is the time the last SNS message was published.
+	// +optional
+	LastNotificationTime *metav1.Time `json:"lastNotificationTime,omitempty"`
+
+	// Notifications is the number of SNS messages published for the attachment, including
+	// reminders.
+	// +optional
+	Notifications int32 `json:"notifications,omitempty"`
+
+	// Response of the owner of the transit gateway to the request, reported through the
+	// approval callback.
+	// +optional
+	Response *AttachmentAcceptanceResponse `json:"response,omitempty"`
+}
+
+// AttachmentAcceptanceDecision is the decision of the owner of the transit gateway about an
+// attachment.
+// +kubebuilder:validation:Enum=Accepted;Rejected
+type AttachmentAcceptanceDecision string
+
+const (
+	// AttachmentAccepted means the owner of the transit gateway accepted the attachment.
+	AttachmentAccepted AttachmentAcceptanceDecision = "Accepted"
+	// AttachmentRejected means the owner of the transit gateway rejected the attachment.
+	AttachmentRejected AttachmentAcceptanceDecision = "Rejected"
+)
+
+// AttachmentAcceptanceResponse is the response of the owner of the transit gateway to an
+// attachment acceptance request.
+type AttachmentAcceptanceResponse struct {
+	// Decision about the attachment.
+	Decision AttachmentAcceptanceDecision `json:"decision"`
+
+	// Reason given for the decision.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// RouteTableID of the transit gateway route table the accepted attachment is associated
+	// with.
+	// +optional
+	RouteTableID string `json:"routeTableID,omitempty"`
+
+	// Time the response was received.
+	Time metav1.Time `json:"time"`
+}
+
+// TransitGatewayPeeringStatus describes a peering attachment with a peer transit gateway.
+type TransitGatewayPeeringStatus struct {
+	// TransitGatewayARN of the peer transit gateway.
+	TransitGatewayARN string `json:"transitGatewayARN"`
+
+	// AttachmentID of the transit gateway peering attachment.
+	// +optional
+	AttachmentID string `json:"attachmentID,omitempty"`
+
+	// State of the transit gateway peering attachment as reported by AWS.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// RouteTableID of the transit gateway route table containing the routes to the peer.
+	// +optional
+	RouteTableID string `json:"routeTableID,omitempty"`
+
+	// Routes are the peer CIDRs routed through the peering attachment.
+	// +optional
+	Routes []string `json:"routes,omitempty"`
+}
+
+// TransitGatewayDeletionStage is a stage of the deletion of the transit gateway of a management
+// cluster.
+// +kubebuilder:validation:Enum=WaitingForAttachments;DeletingRouteTables;DeletingTransitGateway;DeletingPrefixLists
+type TransitGatewayDeletionStage string
+
+const (
+	// TransitGatewayDeletionWaitingForAttachments means the transit gateway still has attachments
+	// which aren't deleted yet, including attachments of other clusters.
+	TransitGatewayDeletionWaitingForAttachments TransitGatewayDeletionStage = "WaitingForAttachments"
+	// TransitGatewayDeletionDeletingRouteTables means the route tables created by the operator are
+	// being deleted.
+	TransitGatewayDeletionDeletingRouteTables TransitGatewayDeletionStage = "DeletingRouteTables"
+	// TransitGatewayDeletionDeletingTransitGateway means the transit gateway itself is being
+	// deleted.
+	TransitGatewayDeletionDeletingTransitGateway TransitGatewayDeletionStage = "DeletingTransitGateway"
+	// TransitGatewayDeletionDeletingPrefixLists means the transit gateway is deleted and the prefix
+	// lists are waiting to be emptied and no longer referenced before they are deleted.
+	TransitGatewayDeletionDeletingPrefixLists TransitGatewayDeletionStage = "DeletingPrefixLists"
+)
+
+// TransitGatewayDeletionStatus describes the staged deletion of the transit gateway of a
+// management cluster.
+type TransitGatewayDeletionStatus struct {
+	// Stage of the deletion.
+	Stage TransitGatewayDeletionStage `json:"stage"`
+
+	// Blocking lists the attachments, route tables or prefix list references the current stage
+	// is waiting for.
+	// +optional
+	Blocking []string `json:"blocking,omitempty"`
+
+	// Attempts is the number of times the deletion was blocked in the current stage.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// LastAttemptTime is the time the deletion was last attempted.
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+}
+
+// PrefixListEntryStatus describes the entry of the cluster in the prefix list.
+type PrefixListEntryStatus struct {
+	// PrefixListID of the prefix list containing the entry.
+	PrefixListID string `json:"prefixListID"`
+
+	// CIDR of the entry.
+	CIDR string `json:"cidr"`
+
+	// SecondaryCIDRs of the VPC with an entry in the prefix list.
+	// +optional
+	SecondaryCIDRs []string `json:"secondaryCIDRs,omitempty"`
+
+	// Description of the entry.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// NetworkTopologyStatus defines the observed network topology of a Cluster.
+type NetworkTopologyStatus struct {
+	// TransitGatewayAttachment of the cluster VPC.
+	// +optional
+	TransitGatewayAttachment *TransitGatewayAttachmentStatus `json:"transitGatewayAttachment,omitempty"`
+
+	// PrefixListEntry of the cluster VPC CIDR.
+	// +optional
+	PrefixListEntry *PrefixListEntryStatus `json:"prefixListEntry,omitempty"`
+
+	// IPv6PrefixListEntry of the cluster in the IPv6 prefix list of the management cluster.
+	// +optional
+	IPv6PrefixListEntry *PrefixListEntryStatus `json:"ipv6PrefixListEntry,omitempty"`
+
+	// Peerings of the transit gateway with peer transit gateways.
+	// +optional
+	Peerings []TransitGatewayPeeringStatus `json:"peerings,omitempty"`
+
+	// ResourceShareARNs of the RAM shares used to share the transit gateway and prefix list
+	// with the account of the cluster.
+	// +optional
+	ResourceShareARNs []string `json:"resourceShareARNs,omitempty"`
+
+	// TransitGatewayDeletion describes the staged deletion of the transit gateway of a
+	// management cluster while it is being deleted.
+	// +optional
+	TransitGatewayDeletion *TransitGatewayDeletionStatus `json:"transitGatewayDeletion,omitempty"`
+
+	// Conditions defines current service state of the NetworkTopology.
+	// +optional
+	Conditions capi.Conditions `json:"conditions,omitempty"`
+}
+
+// NetworkTopology is the Schema for the networktopologies API. A NetworkTopology has the
+// same name and namespace as the Cluster it configures.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=networktopologies,scope=Namespaced,shortName=nettop
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Transit Gateway",type="string",JSONPath=".spec.transitGateway.id"
+// +kubebuilder:printcolumn:name="Route Table Group",type="string",JSONPath=".spec.routeTableGroup",priority=1
+// +kubebuilder:printcolumn:name="Attachment",type="string",JSONPath=".status.transitGatewayAttachment.state"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='NetworkTopologyReady')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type NetworkTopology struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkTopologySpec   `json:"spec,omitempty"`
+	Status NetworkTopologyStatus `json:"status,omitempty"`
+}
+
+// GetDeletionPolicy returns the deletion policy of the NetworkTopology or the
+// default if it isn't set.
+func (n *NetworkTopology) GetDeletionPolicy(defaultPolicy DeletionPolicy) DeletionPolicy {
+	if n == nil || n.Spec.DeletionPolicy == "" {
+		return defaultPolicy
+	}
+	return n.Spec.DeletionPolicy
+}
+
+// GetConditions returns the list of conditions for a NetworkTopology.
+func (n *NetworkTopology) GetConditions() capi.Conditions {
+	return n.Status.Conditions
+}
+
+// SetConditions sets the conditions on a NetworkTopology.
+func (n *NetworkTopology) SetConditions(conditions capi.Conditions) {
+	n.Status.Conditions = conditions
+}
+
+// NetworkTopologyList contains a list of NetworkTopology.
+// +kubebuilder:object:root=true
+type NetworkTopologyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkTopology `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkTopology{}, &NetworkTopologyList{})
+}
@@ -0,0 +1,341 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSResourceReference) DeepCopyInto(out *AWSResourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSResourceReference.
+func (in *AWSResourceReference) DeepCopy() *AWSResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttachmentAcceptanceRequestStatus) DeepCopyInto(out *AttachmentAcceptanceRequestStatus) {
+	*out = *in
+	if in.LastNotificationTime != nil {
+		in, out := &in.LastNotificationTime, &out.LastNotificationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(AttachmentAcceptanceResponse)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachmentAcceptanceRequestStatus.
+func (in *AttachmentAcceptanceRequestStatus) DeepCopy() *AttachmentAcceptanceRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachmentAcceptanceRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttachmentAcceptanceResponse) DeepCopyInto(out *AttachmentAcceptanceResponse) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachmentAcceptanceResponse.
+func (in *AttachmentAcceptanceResponse) DeepCopy() *AttachmentAcceptanceResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachmentAcceptanceResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTopology) DeepCopyInto(out *NetworkTopology) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTopology.
+func (in *NetworkTopology) DeepCopy() *NetworkTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkTopology) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTopologyList) DeepCopyInto(out *NetworkTopologyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkTopology, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTopologyList.
+func (in *NetworkTopologyList) DeepCopy() *NetworkTopologyList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTopologyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkTopologyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTopologySpec) DeepCopyInto(out *NetworkTopologySpec) {
+	*out = *in
+	if in.TransitGateway != nil {
+		in, out := &in.TransitGateway, &out.TransitGateway
+		*out = new(AWSResourceReference)
+		**out = **in
+	}
+	if in.PrefixList != nil {
+		in, out := &in.PrefixList, &out.PrefixList
+		*out = new(AWSResourceReference)
+		**out = **in
+	}
+	if in.IPv6PrefixList != nil {
+		in, out := &in.IPv6PrefixList, &out.IPv6PrefixList
+		*out = new(AWSResourceReference)
+		**out = **in
+	}
+	if in.AttachmentSubnetSelector != nil {
+		in, out := &in.AttachmentSubnetSelector, &out.AttachmentSubnetSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PropagateToRouteTableGroups != nil {
+		in, out := &in.PropagateToRouteTableGroups, &out.PropagateToRouteTableGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Peerings != nil {
+		in, out := &in.Peerings, &out.Peerings
+		*out = make([]TransitGatewayPeeringSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTopologySpec.
+func (in *NetworkTopologySpec) DeepCopy() *NetworkTopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTopologyStatus) DeepCopyInto(out *NetworkTopologyStatus) {
+	*out = *in
+	if in.TransitGatewayAttachment != nil {
+		in, out := &in.TransitGatewayAttachment, &out.TransitGatewayAttachment
+		*out = new(TransitGatewayAttachmentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrefixListEntry != nil {
+		in, out := &in.PrefixListEntry, &out.PrefixListEntry
+		*out = new(PrefixListEntryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPv6PrefixListEntry != nil {
+		in, out := &in.IPv6PrefixListEntry, &out.IPv6PrefixListEntry
+		*out = new(PrefixListEntryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Peerings != nil {
+		in, out := &in.Peerings, &out.Peerings
+		*out = make([]TransitGatewayPeeringStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceShareARNs != nil {
+		in, out := &in.ResourceShareARNs, &out.ResourceShareARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TransitGatewayDeletion != nil {
+		in, out := &in.TransitGatewayDeletion, &out.TransitGatewayDeletion
+		*out = new(TransitGatewayDeletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(v1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTopologyStatus.
+func (in *NetworkTopologyStatus) DeepCopy() *NetworkTopologyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTopologyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixListEntryStatus) DeepCopyInto(out *PrefixListEntryStatus) {
+	*out = *in
+	if in.SecondaryCIDRs != nil {
+		in, out := &in.SecondaryCIDRs, &out.SecondaryCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixListEntryStatus.
+func (in *PrefixListEntryStatus) DeepCopy() *PrefixListEntryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixListEntryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayAttachmentStatus) DeepCopyInto(out *TransitGatewayAttachmentStatus) {
+	*out = *in
+	if in.AcceptanceRequest != nil {
+		in, out := &in.AcceptanceRequest, &out.AcceptanceRequest
+		*out = new(AttachmentAcceptanceRequestStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayAttachmentStatus.
+func (in *TransitGatewayAttachmentStatus) DeepCopy() *TransitGatewayAttachmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayAttachmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayDeletionStatus) DeepCopyInto(out *TransitGatewayDeletionStatus) {
+	*out = *in
+	if in.Blocking != nil {
+		in, out := &in.Blocking, &out.Blocking
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayDeletionStatus.
+func (in *TransitGatewayDeletionStatus) DeepCopy() *TransitGatewayDeletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayDeletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayPeeringSpec) DeepCopyInto(out *TransitGatewayPeeringSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayPeeringSpec.
+func (in *TransitGatewayPeeringSpec) DeepCopy() *TransitGatewayPeeringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayPeeringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayPeeringStatus) DeepCopyInto(out *TransitGatewayPeeringStatus) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayPeeringStatus.
+func (in *TransitGatewayPeeringStatus) DeepCopy() *TransitGatewayPeeringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayPeeringStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -5,11 +5,11 @@ import (
 	"context"
 	"sync"
 
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/controllers"
 	"k8s.io/apimachinery/pkg/types"
 	v1beta1a "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
 	"sigs.k8s.io/cluster-api/api/v1beta1"
-
-	"github.com/giantswarm/aws-network-topology-operator/controllers"
 )
 
 type FakeClusterClient struct {
@@ -38,6 +38,18 @@ type FakeClusterClient struct {
 	containsFinalizerReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	CreateNetworkTopologyStub        func(context.Context, *v1alpha1.NetworkTopology) error
+	createNetworkTopologyMutex       sync.RWMutex
+	createNetworkTopologyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.NetworkTopology
+	}
+	createNetworkTopologyReturns struct {
+		result1 error
+	}
+	createNetworkTopologyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetStub        func(context.Context, types.NamespacedName) (*v1beta1.Cluster, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
@@ -66,6 +78,33 @@ type FakeClusterClient struct {
 		result1 *v1beta1a.AWSClusterRoleIdentity
 		result2 error
 	}
+	GetNetworkTopologyStub        func(context.Context, types.NamespacedName) (*v1alpha1.NetworkTopology, error)
+	getNetworkTopologyMutex       sync.RWMutex
+	getNetworkTopologyArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}
+	getNetworkTopologyReturns struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}
+	getNetworkTopologyReturnsOnCall map[int]struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}
+	PatchNetworkTopologyStub        func(context.Context, *v1alpha1.NetworkTopology, *v1alpha1.NetworkTopology) error
+	patchNetworkTopologyMutex       sync.RWMutex
+	patchNetworkTopologyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.NetworkTopology
+		arg3 *v1alpha1.NetworkTopology
+	}
+	patchNetworkTopologyReturns struct {
+		result1 error
+	}
+	patchNetworkTopologyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RemoveFinalizerStub        func(context.Context, *v1beta1.Cluster, string) error
 	removeFinalizerMutex       sync.RWMutex
 	removeFinalizerArgsForCall []struct {
@@ -220,6 +259,68 @@ func (fake *FakeClusterClient) ContainsFinalizerReturnsOnCall(i int, result1 boo
 	}{result1}
 }
 
+func (fake *FakeClusterClient) CreateNetworkTopology(arg1 context.Context, arg2 *v1alpha1.NetworkTopology) error {
+	fake.createNetworkTopologyMutex.Lock()
+	ret, specificReturn := fake.createNetworkTopologyReturnsOnCall[len(fake.createNetworkTopologyArgsForCall)]
+	fake.createNetworkTopologyArgsForCall = append(fake.createNetworkTopologyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.NetworkTopology
+	}{arg1, arg2})
+	stub := fake.CreateNetworkTopologyStub
+	fakeReturns := fake.createNetworkTopologyReturns
+	fake.recordInvocation("CreateNetworkTopology", []interface{}{arg1, arg2})
+	fake.createNetworkTopologyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterClient) CreateNetworkTopologyCallCount() int {
+	fake.createNetworkTopologyMutex.RLock()
+	defer fake.createNetworkTopologyMutex.RUnlock()
+	return len(fake.createNetworkTopologyArgsForCall)
+}
+
+func (fake *FakeClusterClient) CreateNetworkTopologyCalls(stub func(context.Context, *v1alpha1.NetworkTopology) error) {
+	fake.createNetworkTopologyMutex.Lock()
+	defer fake.createNetworkTopologyMutex.Unlock()
+	fake.CreateNetworkTopologyStub = stub
+}
+
+func (fake *FakeClusterClient) CreateNetworkTopologyArgsForCall(i int) (context.Context, *v1alpha1.NetworkTopology) {
+	fake.createNetworkTopologyMutex.RLock()
+	defer fake.createNetworkTopologyMutex.RUnlock()
+	argsForCall := fake.createNetworkTopologyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterClient) CreateNetworkTopologyReturns(result1 error) {
+	fake.createNetworkTopologyMutex.Lock()
+	defer fake.createNetworkTopologyMutex.Unlock()
+	fake.CreateNetworkTopologyStub = nil
+	fake.createNetworkTopologyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterClient) CreateNetworkTopologyReturnsOnCall(i int, result1 error) {
+	fake.createNetworkTopologyMutex.Lock()
+	defer fake.createNetworkTopologyMutex.Unlock()
+	fake.CreateNetworkTopologyStub = nil
+	if fake.createNetworkTopologyReturnsOnCall == nil {
+		fake.createNetworkTopologyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createNetworkTopologyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClusterClient) Get(arg1 context.Context, arg2 types.NamespacedName) (*v1beta1.Cluster, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
@@ -350,6 +451,134 @@ func (fake *FakeClusterClient) GetAWSClusterRoleIdentityReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *FakeClusterClient) GetNetworkTopology(arg1 context.Context, arg2 types.NamespacedName) (*v1alpha1.NetworkTopology, error) {
+	fake.getNetworkTopologyMutex.Lock()
+	ret, specificReturn := fake.getNetworkTopologyReturnsOnCall[len(fake.getNetworkTopologyArgsForCall)]
+	fake.getNetworkTopologyArgsForCall = append(fake.getNetworkTopologyArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}{arg1, arg2})
+	stub := fake.GetNetworkTopologyStub
+	fakeReturns := fake.getNetworkTopologyReturns
+	fake.recordInvocation("GetNetworkTopology", []interface{}{arg1, arg2})
+	fake.getNetworkTopologyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyCallCount() int {
+	fake.getNetworkTopologyMutex.RLock()
+	defer fake.getNetworkTopologyMutex.RUnlock()
+	return len(fake.getNetworkTopologyArgsForCall)
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyCalls(stub func(context.Context, types.NamespacedName) (*v1alpha1.NetworkTopology, error)) {
+	fake.getNetworkTopologyMutex.Lock()
+	defer fake.getNetworkTopologyMutex.Unlock()
+	fake.GetNetworkTopologyStub = stub
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyArgsForCall(i int) (context.Context, types.NamespacedName) {
+	fake.getNetworkTopologyMutex.RLock()
+	defer fake.getNetworkTopologyMutex.RUnlock()
+	argsForCall := fake.getNetworkTopologyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyReturns(result1 *v1alpha1.NetworkTopology, result2 error) {
+	fake.getNetworkTopologyMutex.Lock()
+	defer fake.getNetworkTopologyMutex.Unlock()
+	fake.GetNetworkTopologyStub = nil
+	fake.getNetworkTopologyReturns = struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyReturnsOnCall(i int, result1 *v1alpha1.NetworkTopology, result2 error) {
+	fake.getNetworkTopologyMutex.Lock()
+	defer fake.getNetworkTopologyMutex.Unlock()
+	fake.GetNetworkTopologyStub = nil
+	if fake.getNetworkTopologyReturnsOnCall == nil {
+		fake.getNetworkTopologyReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1.NetworkTopology
+			result2 error
+		})
+	}
+	fake.getNetworkTopologyReturnsOnCall[i] = struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) PatchNetworkTopology(arg1 context.Context, arg2 *v1alpha1.NetworkTopology, arg3 *v1alpha1.NetworkTopology) error {
+	fake.patchNetworkTopologyMutex.Lock()
+	ret, specificReturn := fake.patchNetworkTopologyReturnsOnCall[len(fake.patchNetworkTopologyArgsForCall)]
+	fake.patchNetworkTopologyArgsForCall = append(fake.patchNetworkTopologyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.NetworkTopology
+		arg3 *v1alpha1.NetworkTopology
+	}{arg1, arg2, arg3})
+	stub := fake.PatchNetworkTopologyStub
+	fakeReturns := fake.patchNetworkTopologyReturns
+	fake.recordInvocation("PatchNetworkTopology", []interface{}{arg1, arg2, arg3})
+	fake.patchNetworkTopologyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterClient) PatchNetworkTopologyCallCount() int {
+	fake.patchNetworkTopologyMutex.RLock()
+	defer fake.patchNetworkTopologyMutex.RUnlock()
+	return len(fake.patchNetworkTopologyArgsForCall)
+}
+
+func (fake *FakeClusterClient) PatchNetworkTopologyCalls(stub func(context.Context, *v1alpha1.NetworkTopology, *v1alpha1.NetworkTopology) error) {
+	fake.patchNetworkTopologyMutex.Lock()
+	defer fake.patchNetworkTopologyMutex.Unlock()
+	fake.PatchNetworkTopologyStub = stub
+}
+
+func (fake *FakeClusterClient) PatchNetworkTopologyArgsForCall(i int) (context.Context, *v1alpha1.NetworkTopology, *v1alpha1.NetworkTopology) {
+	fake.patchNetworkTopologyMutex.RLock()
+	defer fake.patchNetworkTopologyMutex.RUnlock()
+	argsForCall := fake.patchNetworkTopologyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClusterClient) PatchNetworkTopologyReturns(result1 error) {
+	fake.patchNetworkTopologyMutex.Lock()
+	defer fake.patchNetworkTopologyMutex.Unlock()
+	fake.PatchNetworkTopologyStub = nil
+	fake.patchNetworkTopologyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClusterClient) PatchNetworkTopologyReturnsOnCall(i int, result1 error) {
+	fake.patchNetworkTopologyMutex.Lock()
+	defer fake.patchNetworkTopologyMutex.Unlock()
+	fake.PatchNetworkTopologyStub = nil
+	if fake.patchNetworkTopologyReturnsOnCall == nil {
+		fake.patchNetworkTopologyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.patchNetworkTopologyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClusterClient) RemoveFinalizer(arg1 context.Context, arg2 *v1beta1.Cluster, arg3 string) error {
 	fake.removeFinalizerMutex.Lock()
 	ret, specificReturn := fake.removeFinalizerReturnsOnCall[len(fake.removeFinalizerArgsForCall)]
@@ -482,10 +711,16 @@ func (fake *FakeClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.addFinalizerMutex.RUnlock()
 	fake.containsFinalizerMutex.RLock()
 	defer fake.containsFinalizerMutex.RUnlock()
+	fake.createNetworkTopologyMutex.RLock()
+	defer fake.createNetworkTopologyMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	fake.getAWSClusterRoleIdentityMutex.RLock()
 	defer fake.getAWSClusterRoleIdentityMutex.RUnlock()
+	fake.getNetworkTopologyMutex.RLock()
+	defer fake.getNetworkTopologyMutex.RUnlock()
+	fake.patchNetworkTopologyMutex.RLock()
+	defer fake.patchNetworkTopologyMutex.RUnlock()
 	fake.removeFinalizerMutex.RLock()
 	defer fake.removeFinalizerMutex.RUnlock()
 	fake.updateStatusMutex.RLock()
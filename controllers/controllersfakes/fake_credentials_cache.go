@@ -0,0 +1,76 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package controllersfakes
+
+import (
+	"sync"
+
+	"github.com/giantswarm/aws-network-topology-operator/controllers"
+)
+
+type FakeCredentialsCache struct {
+	InvalidateRoleIdentityStub        func(string)
+	invalidateRoleIdentityMutex       sync.RWMutex
+	invalidateRoleIdentityArgsForCall []struct {
+		arg1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCredentialsCache) InvalidateRoleIdentity(arg1 string) {
+	fake.invalidateRoleIdentityMutex.Lock()
+	fake.invalidateRoleIdentityArgsForCall = append(fake.invalidateRoleIdentityArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.InvalidateRoleIdentityStub
+	fake.recordInvocation("InvalidateRoleIdentity", []interface{}{arg1})
+	fake.invalidateRoleIdentityMutex.Unlock()
+	if stub != nil {
+		fake.InvalidateRoleIdentityStub(arg1)
+	}
+}
+
+func (fake *FakeCredentialsCache) InvalidateRoleIdentityCallCount() int {
+	fake.invalidateRoleIdentityMutex.RLock()
+	defer fake.invalidateRoleIdentityMutex.RUnlock()
+	return len(fake.invalidateRoleIdentityArgsForCall)
+}
+
+func (fake *FakeCredentialsCache) InvalidateRoleIdentityCalls(stub func(string)) {
+	fake.invalidateRoleIdentityMutex.Lock()
+	defer fake.invalidateRoleIdentityMutex.Unlock()
+	fake.InvalidateRoleIdentityStub = stub
+}
+
+func (fake *FakeCredentialsCache) InvalidateRoleIdentityArgsForCall(i int) string {
+	fake.invalidateRoleIdentityMutex.RLock()
+	defer fake.invalidateRoleIdentityMutex.RUnlock()
+	argsForCall := fake.invalidateRoleIdentityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCredentialsCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.invalidateRoleIdentityMutex.RLock()
+	defer fake.invalidateRoleIdentityMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCredentialsCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ controllers.CredentialsCache = new(FakeCredentialsCache)
@@ -10,17 +10,19 @@ import (
 )
 
 type FakeRAMClient struct {
-	ApplyResourceShareStub        func(context.Context, aws.ResourceShare) error
+	ApplyResourceShareStub        func(context.Context, aws.ResourceShare) (string, error)
 	applyResourceShareMutex       sync.RWMutex
 	applyResourceShareArgsForCall []struct {
 		arg1 context.Context
 		arg2 aws.ResourceShare
 	}
 	applyResourceShareReturns struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	applyResourceShareReturnsOnCall map[int]struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	DeleteResourceShareStub        func(context.Context, string) error
 	deleteResourceShareMutex       sync.RWMutex
@@ -38,7 +40,7 @@ type FakeRAMClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRAMClient) ApplyResourceShare(arg1 context.Context, arg2 aws.ResourceShare) error {
+func (fake *FakeRAMClient) ApplyResourceShare(arg1 context.Context, arg2 aws.ResourceShare) (string, error) {
 	fake.applyResourceShareMutex.Lock()
 	ret, specificReturn := fake.applyResourceShareReturnsOnCall[len(fake.applyResourceShareArgsForCall)]
 	fake.applyResourceShareArgsForCall = append(fake.applyResourceShareArgsForCall, struct {
@@ -53,9 +55,9 @@ func (fake *FakeRAMClient) ApplyResourceShare(arg1 context.Context, arg2 aws.Res
 		return stub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeRAMClient) ApplyResourceShareCallCount() int {
@@ -64,7 +66,7 @@ func (fake *FakeRAMClient) ApplyResourceShareCallCount() int {
 	return len(fake.applyResourceShareArgsForCall)
 }
 
-func (fake *FakeRAMClient) ApplyResourceShareCalls(stub func(context.Context, aws.ResourceShare) error) {
+func (fake *FakeRAMClient) ApplyResourceShareCalls(stub func(context.Context, aws.ResourceShare) (string, error)) {
 	fake.applyResourceShareMutex.Lock()
 	defer fake.applyResourceShareMutex.Unlock()
 	fake.ApplyResourceShareStub = stub
@@ -77,27 +79,30 @@ func (fake *FakeRAMClient) ApplyResourceShareArgsForCall(i int) (context.Context
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeRAMClient) ApplyResourceShareReturns(result1 error) {
+func (fake *FakeRAMClient) ApplyResourceShareReturns(result1 string, result2 error) {
 	fake.applyResourceShareMutex.Lock()
 	defer fake.applyResourceShareMutex.Unlock()
 	fake.ApplyResourceShareStub = nil
 	fake.applyResourceShareReturns = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeRAMClient) ApplyResourceShareReturnsOnCall(i int, result1 error) {
+func (fake *FakeRAMClient) ApplyResourceShareReturnsOnCall(i int, result1 string, result2 error) {
 	fake.applyResourceShareMutex.Lock()
 	defer fake.applyResourceShareMutex.Unlock()
 	fake.ApplyResourceShareStub = nil
 	if fake.applyResourceShareReturnsOnCall == nil {
 		fake.applyResourceShareReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 string
+			result2 error
 		})
 	}
 	fake.applyResourceShareReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeRAMClient) DeleteResourceShare(arg1 context.Context, arg2 string) error {
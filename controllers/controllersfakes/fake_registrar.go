@@ -5,17 +5,18 @@ import (
 	"context"
 	"sync"
 
-	"sigs.k8s.io/cluster-api/api/v1beta1"
-
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
 	"github.com/giantswarm/aws-network-topology-operator/controllers"
+	"sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 type FakeRegistrar struct {
-	RegisterStub        func(context.Context, *v1beta1.Cluster) error
+	RegisterStub        func(context.Context, *v1beta1.Cluster, *v1alpha1.NetworkTopology) error
 	registerMutex       sync.RWMutex
 	registerArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1beta1.Cluster
+		arg3 *v1alpha1.NetworkTopology
 	}
 	registerReturns struct {
 		result1 error
@@ -23,11 +24,12 @@ type FakeRegistrar struct {
 	registerReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UnregisterStub        func(context.Context, *v1beta1.Cluster) error
+	UnregisterStub        func(context.Context, *v1beta1.Cluster, *v1alpha1.NetworkTopology) error
 	unregisterMutex       sync.RWMutex
 	unregisterArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1beta1.Cluster
+		arg3 *v1alpha1.NetworkTopology
 	}
 	unregisterReturns struct {
 		result1 error
@@ -39,19 +41,20 @@ type FakeRegistrar struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRegistrar) Register(arg1 context.Context, arg2 *v1beta1.Cluster) error {
+func (fake *FakeRegistrar) Register(arg1 context.Context, arg2 *v1beta1.Cluster, arg3 *v1alpha1.NetworkTopology) error {
 	fake.registerMutex.Lock()
 	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
 	fake.registerArgsForCall = append(fake.registerArgsForCall, struct {
 		arg1 context.Context
 		arg2 *v1beta1.Cluster
-	}{arg1, arg2})
+		arg3 *v1alpha1.NetworkTopology
+	}{arg1, arg2, arg3})
 	stub := fake.RegisterStub
 	fakeReturns := fake.registerReturns
-	fake.recordInvocation("Register", []interface{}{arg1, arg2})
+	fake.recordInvocation("Register", []interface{}{arg1, arg2, arg3})
 	fake.registerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -65,17 +68,17 @@ func (fake *FakeRegistrar) RegisterCallCount() int {
 	return len(fake.registerArgsForCall)
 }
 
-func (fake *FakeRegistrar) RegisterCalls(stub func(context.Context, *v1beta1.Cluster) error) {
+func (fake *FakeRegistrar) RegisterCalls(stub func(context.Context, *v1beta1.Cluster, *v1alpha1.NetworkTopology) error) {
 	fake.registerMutex.Lock()
 	defer fake.registerMutex.Unlock()
 	fake.RegisterStub = stub
 }
 
-func (fake *FakeRegistrar) RegisterArgsForCall(i int) (context.Context, *v1beta1.Cluster) {
+func (fake *FakeRegistrar) RegisterArgsForCall(i int) (context.Context, *v1beta1.Cluster, *v1alpha1.NetworkTopology) {
 	fake.registerMutex.RLock()
 	defer fake.registerMutex.RUnlock()
 	argsForCall := fake.registerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeRegistrar) RegisterReturns(result1 error) {
@@ -101,19 +104,20 @@ func (fake *FakeRegistrar) RegisterReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeRegistrar) Unregister(arg1 context.Context, arg2 *v1beta1.Cluster) error {
+func (fake *FakeRegistrar) Unregister(arg1 context.Context, arg2 *v1beta1.Cluster, arg3 *v1alpha1.NetworkTopology) error {
 	fake.unregisterMutex.Lock()
 	ret, specificReturn := fake.unregisterReturnsOnCall[len(fake.unregisterArgsForCall)]
 	fake.unregisterArgsForCall = append(fake.unregisterArgsForCall, struct {
 		arg1 context.Context
 		arg2 *v1beta1.Cluster
-	}{arg1, arg2})
+		arg3 *v1alpha1.NetworkTopology
+	}{arg1, arg2, arg3})
 	stub := fake.UnregisterStub
 	fakeReturns := fake.unregisterReturns
-	fake.recordInvocation("Unregister", []interface{}{arg1, arg2})
+	fake.recordInvocation("Unregister", []interface{}{arg1, arg2, arg3})
 	fake.unregisterMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -127,17 +131,17 @@ func (fake *FakeRegistrar) UnregisterCallCount() int {
 	return len(fake.unregisterArgsForCall)
 }
 
-func (fake *FakeRegistrar) UnregisterCalls(stub func(context.Context, *v1beta1.Cluster) error) {
+func (fake *FakeRegistrar) UnregisterCalls(stub func(context.Context, *v1beta1.Cluster, *v1alpha1.NetworkTopology) error) {
 	fake.unregisterMutex.Lock()
 	defer fake.unregisterMutex.Unlock()
 	fake.UnregisterStub = stub
 }
 
-func (fake *FakeRegistrar) UnregisterArgsForCall(i int) (context.Context, *v1beta1.Cluster) {
+func (fake *FakeRegistrar) UnregisterArgsForCall(i int) (context.Context, *v1beta1.Cluster, *v1alpha1.NetworkTopology) {
 	fake.unregisterMutex.RLock()
 	defer fake.unregisterMutex.RUnlock()
 	argsForCall := fake.unregisterArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeRegistrar) UnregisterReturns(result1 error) {
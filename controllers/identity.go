@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+//counterfeiter:generate . CredentialsCache
+type CredentialsCache interface {
+	InvalidateRoleIdentity(name string)
+}
+
+// IdentityReconciler evicts the cached AWS credentials derived from an
+// AWSClusterRoleIdentity whenever it changes, so e.g. a rotated role ARN is
+// used by the next reconciliation of the clusters referencing it.
+type IdentityReconciler struct {
+	credentialsCache CredentialsCache
+}
+
+func NewIdentityReconciler(credentialsCache CredentialsCache) *IdentityReconciler {
+	return &IdentityReconciler{
+		credentialsCache: credentialsCache,
+	}
+}
+
+func (r *IdentityReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("identity-reconciler").
+		For(&capa.AWSClusterRoleIdentity{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+func (r *IdentityReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Evicting cached AWS credentials of AWSClusterRoleIdentity")
+	r.credentialsCache.InvalidateRoleIdentity(req.Name)
+
+	return ctrl.Result{}, nil
+}
@@ -3,20 +3,33 @@ package controllers
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"time"
 
-	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/giantswarm/microerror"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	capiconditions "sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/metrics"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/tracing"
 	nettopAnnotations "github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
 )
 
@@ -33,33 +46,71 @@ type ClusterClient interface {
 	ContainsFinalizer(*capi.Cluster, string) bool
 	UpdateStatus(ctx context.Context, cluster *capi.Cluster) error
 	GetAWSClusterRoleIdentity(context.Context, types.NamespacedName) (*capa.AWSClusterRoleIdentity, error)
+	GetNetworkTopology(context.Context, types.NamespacedName) (*v1alpha1.NetworkTopology, error)
+	CreateNetworkTopology(context.Context, *v1alpha1.NetworkTopology) error
+	PatchNetworkTopology(ctx context.Context, topology *v1alpha1.NetworkTopology, base *v1alpha1.NetworkTopology) error
 }
 
 //counterfeiter:generate . Registrar
 type Registrar interface {
-	Register(context.Context, *capi.Cluster) error
-	Unregister(context.Context, *capi.Cluster) error
+	Register(context.Context, *capi.Cluster, *v1alpha1.NetworkTopology) error
+	Unregister(context.Context, *capi.Cluster, *v1alpha1.NetworkTopology) error
 }
 
 type NetworkTopologyReconciler struct {
 	client     ClusterClient
 	registrars []Registrar
+	recorder   record.EventRecorder
 }
 
-func NewNetworkTopologyReconciler(client ClusterClient, registrars []Registrar) *NetworkTopologyReconciler {
+func NewNetworkTopologyReconciler(client ClusterClient, registrars []Registrar, recorder record.EventRecorder) *NetworkTopologyReconciler {
 	return &NetworkTopologyReconciler{
 		client:     client,
 		registrars: registrars,
+		recorder:   recorder,
 	}
 }
 
 func (r *NetworkTopologyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capi.Cluster{}).
+		Owns(&v1alpha1.NetworkTopology{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, acceptanceResponseChanged()))).
 		Complete(r)
 }
 
-func (r *NetworkTopologyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+// acceptanceResponseChanged reconciles clusters when the approval callback
+// reported a response to the acceptance request of their attachment.
+func acceptanceResponseChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldTopology, ok := e.ObjectOld.(*v1alpha1.NetworkTopology)
+			if !ok {
+				return false
+			}
+			newTopology, ok := e.ObjectNew.(*v1alpha1.NetworkTopology)
+			if !ok {
+				return false
+			}
+			return !reflect.DeepEqual(acceptanceResponse(oldTopology), acceptanceResponse(newTopology))
+		},
+	}
+}
+
+func acceptanceResponse(topology *v1alpha1.NetworkTopology) *v1alpha1.AttachmentAcceptanceResponse {
+	attachment := topology.Status.TransitGatewayAttachment
+	if attachment == nil || attachment.AcceptanceRequest == nil {
+		return nil
+	}
+	return attachment.AcceptanceRequest.Response
+}
+
+func (r *NetworkTopologyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "NetworkTopologyReconciler.Reconcile", trace.WithAttributes(
+		tracing.ClusterName.String(req.Name),
+		tracing.ClusterNamespace.String(req.Namespace),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 
 	logger.Info("Reconciling")
@@ -87,27 +138,104 @@ func (r *NetworkTopologyReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	if !cluster.DeletionTimestamp.IsZero() {
 		logger.Info("Reconciling delete")
-		return r.reconcileDelete(ctx, cluster)
+		topology, err := r.getNetworkTopology(ctx, cluster)
+		if err != nil {
+			return ctrl.Result{}, microerror.Mask(err)
+		}
+		return r.reconcileDelete(ctx, cluster, topology)
+	}
+
+	topology, err := r.getOrCreateNetworkTopology(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, microerror.Mask(err)
 	}
 
-	return r.reconcileNormal(ctx, cluster)
+	return r.reconcileNormal(ctx, cluster, topology)
 }
 
-func (r *NetworkTopologyReconciler) reconcileNormal(ctx context.Context, cluster *capi.Cluster) (ctrl.Result, error) {
-	err := r.client.AddFinalizer(ctx, cluster, FinalizerNetTop)
+// getNetworkTopology returns the NetworkTopology of the cluster. If it doesn't
+// exist (yet) it is built from the cluster annotations without being persisted.
+func (r *NetworkTopologyReconciler) getNetworkTopology(ctx context.Context, cluster *capi.Cluster) (*v1alpha1.NetworkTopology, error) {
+	topology, err := r.client.GetNetworkTopology(ctx, k8sclient.GetNamespacedName(cluster))
+	if k8sErrors.IsNotFound(err) {
+		topology = &v1alpha1.NetworkTopology{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name,
+				Namespace: cluster.Namespace,
+			},
+		}
+		nettopAnnotations.ImportNetworkTopology(cluster, topology)
+		return topology, nil
+	}
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return topology, nil
+}
+
+// getOrCreateNetworkTopology returns the NetworkTopology of the cluster and
+// creates it from the cluster annotations if it doesn't exist yet. Annotations
+// are only imported for fields not yet set on an existing NetworkTopology.
+func (r *NetworkTopologyReconciler) getOrCreateNetworkTopology(ctx context.Context, cluster *capi.Cluster) (*v1alpha1.NetworkTopology, error) {
+	logger := log.FromContext(ctx)
+
+	topology, err := r.getNetworkTopology(ctx, cluster)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if topology.CreationTimestamp.IsZero() {
+		logger.Info("Creating NetworkTopology from cluster annotations")
+		topology.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(cluster, capi.GroupVersion.WithKind("Cluster")),
+		}
+		err = r.client.CreateNetworkTopology(ctx, topology)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		return topology, nil
+	}
+
+	baseTopology := topology.DeepCopy()
+	if nettopAnnotations.ImportNetworkTopology(cluster, topology) {
+		logger.Info("Importing cluster annotations into NetworkTopology")
+		err = r.client.PatchNetworkTopology(ctx, topology, baseTopology)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return topology, nil
+}
+
+func (r *NetworkTopologyReconciler) reconcileNormal(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) (result ctrl.Result, err error) {
+	err = r.client.AddFinalizer(ctx, cluster, FinalizerNetTop)
 	if err != nil {
 		return ctrl.Result{}, microerror.Mask(err)
 	}
 
+	baseTopology := topology.DeepCopy()
+	previousCondition := capiconditions.Get(cluster, networkTopologyCondition).DeepCopy()
 	defer func() {
+		if err != nil {
+			r.recorder.Eventf(cluster, corev1.EventTypeWarning, "ReconcileFailed", "Failed to reconcile network topology: %v", err)
+		}
+		r.recordConditionTransition(cluster, previousCondition)
+
 		_ = r.client.UpdateStatus(ctx, cluster)
+
+		capiconditions.Set(topology, capiconditions.Get(cluster, networkTopologyCondition))
+		_ = r.client.PatchNetworkTopology(ctx, topology, baseTopology)
+
+		recordMetrics(cluster, topology, err)
 	}()
 
 	for _, reg := range r.registrars {
-		err = reg.Register(ctx, cluster)
+		err = r.register(ctx, reg, cluster, topology)
 		if err != nil {
 			if errors.Is(err, &registrar.ModeNotSupportedError{}) {
-				capiconditions.MarkFalse(cluster, networkTopologyCondition, "ModeNotSupported", capi.ConditionSeverityInfo, "The provided mode '%s' is not supported", nettopAnnotations.GetAnnotation(cluster, gsannotation.NetworkTopologyModeAnnotation))
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "ModeNotSupported", capi.ConditionSeverityInfo, "The provided mode '%s' is not supported", topology.Spec.Mode)
 				return ctrl.Result{Requeue: false}, nil
 			} else if errors.Is(err, &registrar.TransitGatewayNotAvailableError{}) {
 				capiconditions.MarkFalse(cluster, networkTopologyCondition, "TransitGatewayNotAvailable", capi.ConditionSeverityWarning, "The transit gateway is not yet available for attachment")
@@ -115,8 +243,51 @@ func (r *NetworkTopologyReconciler) reconcileNormal(ctx context.Context, cluster
 			} else if errors.Is(err, &registrar.VPCNotReadyError{}) {
 				capiconditions.MarkFalse(cluster, networkTopologyCondition, "VPCNotReady", capi.ConditionSeverityInfo, "The cluster's VPC is not yet ready")
 				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 1}, nil
+			} else if errors.Is(err, &registrar.RouteTableNotReadyError{}) {
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "RouteTableNotReady", capi.ConditionSeverityInfo, "The transit gateway route table of the cluster is not yet ready")
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 1}, nil
+			} else if errors.Is(err, &registrar.TransitGatewayPeeringNotReadyError{}) {
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "TransitGatewayPeeringNotReady", capi.ConditionSeverityInfo, "The transit gateway peerings are not yet ready")
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 1}, nil
+			} else if errors.Is(err, &registrar.NoAttachmentSubnetsError{}) {
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "NoAttachmentSubnets", capi.ConditionSeverityError, "%v", err)
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 10}, nil
+			} else if errors.Is(err, &registrar.PrefixListNotReadyError{}) {
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "PrefixListNotReady", capi.ConditionSeverityInfo, "The prefix list is being modified")
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 1}, nil
+			} else if errors.Is(err, &registrar.PrefixListFullError{}) {
+				prefixListFullErr := err.(*registrar.PrefixListFullError)
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "PrefixListFull", capi.ConditionSeverityError, "The prefix list %s reached the maximum of %d entries", prefixListFullErr.PrefixListID, prefixListFullErr.MaxEntries)
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 10}, nil
+			} else if errors.Is(err, &registrar.SNSUnavailableError{}) {
+				snsErr := err.(*registrar.SNSUnavailableError)
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "SNSUnavailable", capi.ConditionSeverityWarning, "The SNS message requesting the acceptance of the transit gateway attachment %s couldn't be published: %v", snsErr.AttachmentID, snsErr.Err)
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 1}, nil
+			} else if errors.Is(err, &registrar.AttachmentRejectedError{}) {
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "AttachmentRejected", capi.ConditionSeverityError, "%v", err)
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 10}, nil
+			} else if errors.Is(err, &registrar.AttachmentFailedError{}) {
+				failedErr := err.(*registrar.AttachmentFailedError)
+				reason := "AttachmentFailed"
+				if failedErr.State == string(ec2types.TransitGatewayAttachmentStateDeleted) || failedErr.State == string(ec2types.TransitGatewayAttachmentStateDeleting) {
+					reason = "AttachmentDeleted"
+				}
+				if failedErr.Exhausted {
+					capiconditions.MarkFalse(cluster, networkTopologyCondition, reason, capi.ConditionSeverityError, "%v", err)
+					return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 10}, nil
+				}
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, reason, capi.ConditionSeverityWarning, "%v", err)
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 1}, nil
+			} else if errors.Is(err, &registrar.AttachmentPendingError{}) {
+				pendingErr := err.(*registrar.AttachmentPendingError)
+				if pendingErr.State == string(ec2types.TransitGatewayAttachmentStatePendingAcceptance) {
+					capiconditions.MarkFalse(cluster, networkTopologyCondition, "AttachmentPendingAcceptance", capi.ConditionSeverityInfo, "The transit gateway attachment %s is waiting to be accepted", pendingErr.AttachmentID)
+					return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 10}, nil
+				}
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "AttachmentPending", capi.ConditionSeverityInfo, "%v", err)
+				return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 1}, nil
 			} else if errors.Is(err, &registrar.IDNotProvidedError{}) {
-				capiconditions.MarkFalse(cluster, networkTopologyCondition, "RequiredIDMissing", capi.ConditionSeverityError, "The %s ID is missing from the annotations", err.(*registrar.IDNotProvidedError).ID)
+				capiconditions.MarkFalse(cluster, networkTopologyCondition, "RequiredIDMissing", capi.ConditionSeverityError, "The %s ID is missing from the NetworkTopology", err.(*registrar.IDNotProvidedError).ID)
 				return ctrl.Result{Requeue: false}, nil
 			}
 
@@ -128,16 +299,21 @@ func (r *NetworkTopologyReconciler) reconcileNormal(ctx context.Context, cluster
 	return ctrl.Result{Requeue: true, RequeueAfter: time.Minute * 10}, nil
 }
 
-func (r *NetworkTopologyReconciler) reconcileDelete(ctx context.Context, cluster *capi.Cluster) (ctrl.Result, error) {
+func (r *NetworkTopologyReconciler) reconcileDelete(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) (ctrl.Result, error) {
 	if !r.client.ContainsFinalizer(cluster, FinalizerNetTop) {
 		return ctrl.Result{}, nil
 	}
 
 	for i := range r.registrars {
-		registrar := r.registrars[len(r.registrars)-1-i]
+		reg := r.registrars[len(r.registrars)-1-i]
 
-		err := registrar.Unregister(ctx, cluster)
-		if err != nil {
+		err := r.unregister(ctx, reg, cluster, topology)
+		if errors.Is(err, &registrar.TransitGatewayDeletionBlockedError{}) {
+			blockedErr := err.(*registrar.TransitGatewayDeletionBlockedError)
+			r.markDeletionBlocked(ctx, cluster, topology, blockedErr)
+			return ctrl.Result{Requeue: true, RequeueAfter: blockedErr.RetryAfter}, nil
+		} else if err != nil {
+			r.recorder.Eventf(cluster, corev1.EventTypeWarning, "DeleteFailed", "Failed to delete network topology: %v", err)
 			return ctrl.Result{}, microerror.Mask(err)
 		}
 	}
@@ -147,5 +323,101 @@ func (r *NetworkTopologyReconciler) reconcileDelete(ctx context.Context, cluster
 		return ctrl.Result{}, microerror.Mask(err)
 	}
 
+	metrics.SetAttachmentState(cluster.Namespace, cluster.Name, "")
+
 	return ctrl.Result{}, nil
 }
+
+// markDeletionBlocked reports the resources blocking the deletion of the
+// transit gateway in the NetworkTopologyReady condition and persists the
+// deletion stage of the NetworkTopology.
+func (r *NetworkTopologyReconciler) markDeletionBlocked(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology, blockedErr *registrar.TransitGatewayDeletionBlockedError) {
+	logger := log.FromContext(ctx)
+
+	previousCondition := capiconditions.Get(cluster, networkTopologyCondition).DeepCopy()
+	if len(blockedErr.Blocking) == 0 {
+		capiconditions.MarkFalse(cluster, networkTopologyCondition, "TransitGatewayDeletionBlocked", capi.ConditionSeverityInfo, "The deletion of the transit gateway %s is waiting in stage %s", blockedErr.TransitGatewayID, blockedErr.Stage)
+	} else {
+		capiconditions.MarkFalse(cluster, networkTopologyCondition, "TransitGatewayDeletionBlocked", capi.ConditionSeverityInfo, "The deletion of the transit gateway %s is waiting in stage %s for %s", blockedErr.TransitGatewayID, blockedErr.Stage, strings.Join(blockedErr.Blocking, ", "))
+	}
+	r.recordConditionTransition(cluster, previousCondition)
+	_ = r.client.UpdateStatus(ctx, cluster)
+
+	if topology.CreationTimestamp.IsZero() {
+		return
+	}
+	current, err := r.client.GetNetworkTopology(ctx, k8sclient.GetNamespacedName(cluster))
+	if err != nil {
+		logger.Error(err, "Failed to get NetworkTopology to record the deletion stage")
+		return
+	}
+	baseTopology := current.DeepCopy()
+	current.Status.TransitGatewayDeletion = topology.Status.TransitGatewayDeletion
+	capiconditions.Set(current, capiconditions.Get(cluster, networkTopologyCondition))
+	if err := r.client.PatchNetworkTopology(ctx, current, baseTopology); err != nil {
+		logger.Error(err, "Failed to record the deletion stage on the NetworkTopology")
+	}
+}
+
+// register calls Register of the registrar in a child span.
+func (r *NetworkTopologyReconciler) register(ctx context.Context, reg Registrar, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, registrarName(reg)+".Register")
+	defer func() { tracing.End(span, err) }()
+
+	return reg.Register(ctx, cluster, topology)
+}
+
+// unregister calls Unregister of the registrar in a child span.
+func (r *NetworkTopologyReconciler) unregister(ctx context.Context, reg Registrar, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, registrarName(reg)+".Unregister")
+	defer func() { tracing.End(span, err) }()
+
+	return reg.Unregister(ctx, cluster, topology)
+}
+
+func registrarName(reg Registrar) string {
+	t := reflect.TypeOf(reg)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// recordConditionTransition records an event when the status or reason of the
+// NetworkTopologyReady condition changed during the reconciliation.
+func (r *NetworkTopologyReconciler) recordConditionTransition(cluster *capi.Cluster, previous *capi.Condition) {
+	current := capiconditions.Get(cluster, networkTopologyCondition)
+	if current == nil {
+		return
+	}
+	if previous != nil && previous.Status == current.Status && previous.Reason == current.Reason {
+		return
+	}
+
+	switch {
+	case current.Status == corev1.ConditionTrue:
+		r.recorder.Event(cluster, corev1.EventTypeNormal, string(networkTopologyCondition), "The network topology is ready")
+	case current.Severity == capi.ConditionSeverityInfo:
+		r.recorder.Event(cluster, corev1.EventTypeNormal, current.Reason, current.Message)
+	default:
+		r.recorder.Event(cluster, corev1.EventTypeWarning, current.Reason, current.Message)
+	}
+}
+
+// recordMetrics records the outcome of the reconciliation and the attachment state of the cluster.
+func recordMetrics(cluster *capi.Cluster, topology *v1alpha1.NetworkTopology, err error) {
+	switch {
+	case err != nil:
+		metrics.RecordReconcile(metrics.ReasonError)
+	case capiconditions.IsTrue(cluster, networkTopologyCondition):
+		metrics.RecordReconcile(metrics.ReasonReady)
+	default:
+		metrics.RecordReconcile(capiconditions.GetReason(cluster, networkTopologyCondition))
+	}
+
+	attachmentState := ""
+	if topology.Status.TransitGatewayAttachment != nil {
+		attachmentState = topology.Status.TransitGatewayAttachment.State
+	}
+	metrics.SetAttachmentState(cluster.Namespace, cluster.Name, attachmentState)
+}
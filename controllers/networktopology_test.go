@@ -2,30 +2,41 @@ package controllers_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	awstypes "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/smithy-go"
 	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	capiconditions "sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
 	"github.com/giantswarm/aws-network-topology-operator/controllers"
 	"github.com/giantswarm/aws-network-topology-operator/controllers/controllersfakes"
 	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/aws/awsfakes"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
 	"github.com/giantswarm/aws-network-topology-operator/tests"
 )
 
@@ -41,8 +52,10 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 		reconciler                             *controllers.NetworkTopologyReconciler
 		clusterClient                          ClusterClient
 		fakeRegistrar                          *controllersfakes.FakeRegistrar
+		recorder                               *record.FakeRecorder
 		transitGatewayClient                   *awsfakes.FakeTransitGatewayClient
 		transitGatewayClientForWorkloadCluster *awsfakes.FakeTransitGatewayClient
+		resourceShareClient                    *awsfakes.FakeResourceShareClient
 
 		transitGatewayID  = "abc-123"
 		transitGatewayARN = fmt.Sprintf("arn:aws:iam::123456789012:transit-gateways/%s", transitGatewayID)
@@ -50,6 +63,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 		prefixListARN     = fmt.Sprintf("arn:aws:iam::123456789012:prefix-lists/%s", prefixListID)
 		mcVPCId           = "vpc-123"
 		wcVPCId           = "vpc-987"
+		clusterCIDR       = "10.3.0.0/16"
 
 		cluster    *capi.Cluster
 		awsCluster *capa.AWSCluster
@@ -72,7 +86,8 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 			Spec: capa.AWSClusterSpec{
 				NetworkSpec: capa.NetworkSpec{
 					VPC: capa.VPCSpec{
-						ID: vpcID,
+						ID:        vpcID,
+						CidrBlock: clusterCIDR,
 					},
 					Subnets: capa.Subnets{
 						{
@@ -111,6 +126,34 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 		return cluster, awsCluster
 	}
 
+	setDeletionPolicy := func(name types.NamespacedName, deletionPolicy v1alpha1.DeletionPolicy) {
+		topology := &v1alpha1.NetworkTopology{}
+		Expect(k8sClient.Get(ctx, name, topology)).To(Succeed())
+		patchedTopology := topology.DeepCopy()
+		patchedTopology.Spec.DeletionPolicy = deletionPolicy
+		Expect(k8sClient.Patch(ctx, patchedTopology, client.MergeFrom(topology))).To(Succeed())
+	}
+
+	// newTransitGatewayReconciler returns a reconciler with only the
+	// TransitGateway registrar, recreating attachments and deleting resource
+	// shares unless the options say otherwise.
+	newTransitGatewayReconciler := func(transitGatewayClient awsclient.TransitGatewayClient, getTransitGatewayClientForWorkloadCluster func(types.NamespacedName) awsclient.TransitGatewayClient, options registrar.TransitGatewayOptions) *controllers.NetworkTopologyReconciler {
+		if options.AttachmentRecreationLimit == 0 {
+			options.AttachmentRecreationLimit = registrar.DefaultAttachmentRecreationLimit
+		}
+		if options.ResourceShareClient == nil {
+			options.ResourceShareClient = resourceShareClient
+		}
+
+		return controllers.NewNetworkTopologyReconciler(
+			clusterClient,
+			[]controllers.Registrar{
+				registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster, options, recorder),
+			},
+			recorder,
+		)
+	}
+
 	BeforeEach(func() {
 		logger := zap.New(zap.WriteTo(GinkgoWriter))
 		ctx = log.IntoContext(context.Background(), logger)
@@ -122,11 +165,14 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 		clusterClient = k8sclient.NewCluster(k8sClient, mc)
 
 		fakeRegistrar = new(controllersfakes.FakeRegistrar)
+		resourceShareClient = new(awsfakes.FakeResourceShareClient)
+		recorder = record.NewFakeRecorder(100)
 		reconciler = controllers.NewNetworkTopologyReconciler(
 			clusterClient,
 			[]controllers.Registrar{
 				fakeRegistrar,
 			},
+			recorder,
 		)
 
 		{
@@ -139,7 +185,8 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 				Spec: capa.AWSClusterSpec{
 					NetworkSpec: capa.NetworkSpec{
 						VPC: capa.VPCSpec{
-							ID: wcVPCId,
+							ID:        wcVPCId,
+							CidrBlock: "10.2.0.0/16",
 						},
 						Subnets: capa.Subnets{
 							{
@@ -186,7 +233,8 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 				Spec: capa.AWSClusterSpec{
 					NetworkSpec: capa.NetworkSpec{
 						VPC: capa.VPCSpec{
-							ID: mcVPCId,
+							ID:        mcVPCId,
+							CidrBlock: "10.0.0.0/16",
 						},
 						Subnets: capa.Subnets{
 							{
@@ -247,18 +295,71 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 
 	It("uses the registrars to register the records", func() {
 		Expect(fakeRegistrar.RegisterCallCount()).To(Equal(1))
-		_, actualCluster := fakeRegistrar.RegisterArgsForCall(0)
+		_, actualCluster, actualTopology := fakeRegistrar.RegisterArgsForCall(0)
 		Expect(actualCluster.ObjectMeta.UID).To(Equal(cluster.ObjectMeta.UID))
+		Expect(actualTopology.Name).To(Equal(cluster.Name))
 	})
 
-	When("the cluster doesn't have the topology mode annotation", func() {
+	It("creates a NetworkTopology owned by the cluster", func() {
+		actualTopology := &v1alpha1.NetworkTopology{}
+		err := k8sClient.Get(ctx, request.NamespacedName, actualTopology)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(actualTopology.OwnerReferences).To(HaveLen(1))
+		Expect(actualTopology.OwnerReferences[0].UID).To(Equal(cluster.ObjectMeta.UID))
+	})
+
+	When("the cluster has network topology annotations", func() {
 		BeforeEach(func() {
-			reconciler = controllers.NewNetworkTopologyReconciler(
-				clusterClient,
-				[]controllers.Registrar{
-					registrar.NewTransitGateway(new(awsfakes.FakeTransitGatewayClient), clusterClient, nil),
+			patchedCluster := cluster.DeepCopy()
+			patchedCluster.Annotations = map[string]string{
+				gsannotation.NetworkTopologyModeAnnotation:             gsannotation.NetworkTopologyModeUserManaged,
+				gsannotation.NetworkTopologyTransitGatewayIDAnnotation: transitGatewayARN,
+				gsannotation.NetworkTopologyPrefixListIDAnnotation:     prefixListID,
+			}
+			Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(cluster))).To(Succeed())
+		})
+
+		It("imports the annotations into the NetworkTopology", func() {
+			actualTopology := &v1alpha1.NetworkTopology{}
+			err := k8sClient.Get(ctx, request.NamespacedName, actualTopology)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actualTopology.Spec.Mode).To(Equal(v1alpha1.NetworkTopologyModeUserManaged))
+			Expect(actualTopology.Spec.TransitGateway.ARN).To(Equal(transitGatewayARN))
+			Expect(actualTopology.Spec.PrefixList.ID).To(Equal(prefixListID))
+		})
+	})
+
+	When("the NetworkTopology already exists", func() {
+		BeforeEach(func() {
+			topology := &v1alpha1.NetworkTopology{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cluster.Name,
+					Namespace: cluster.Namespace,
 				},
-			)
+				Spec: v1alpha1.NetworkTopologySpec{
+					Mode: v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+				},
+			}
+			Expect(k8sClient.Create(ctx, topology)).To(Succeed())
+
+			patchedCluster := cluster.DeepCopy()
+			patchedCluster.Annotations = map[string]string{
+				gsannotation.NetworkTopologyModeAnnotation: gsannotation.NetworkTopologyModeNone,
+			}
+			Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(cluster))).To(Succeed())
+		})
+
+		It("does not override the mode with the annotation", func() {
+			_, _, actualTopology := fakeRegistrar.RegisterArgsForCall(0)
+			Expect(actualTopology.Spec.Mode).To(Equal(v1alpha1.NetworkTopologyModeGiantSwarmManaged))
+		})
+	})
+
+	When("the cluster doesn't have the topology mode annotation", func() {
+		BeforeEach(func() {
+			reconciler = newTransitGatewayReconciler(new(awsfakes.FakeTransitGatewayClient), nil, registrar.TransitGatewayOptions{})
 
 			patchedCluster := cluster.DeepCopy()
 			patchedCluster.Finalizers = []string{controllers.FinalizerNetTop}
@@ -273,12 +374,17 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 		})
 
 		It("should default to 'None'", func() {
+			actualTopology := &v1alpha1.NetworkTopology{}
+			err := k8sClient.Get(ctx, request.NamespacedName, actualTopology)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualTopology.Spec.Mode).To(Equal(v1alpha1.NetworkTopologyModeNone))
+
 			actualCluster := &capi.Cluster{}
-			err := k8sClient.Get(ctx, request.NamespacedName, actualCluster)
+			err = k8sClient.Get(ctx, request.NamespacedName, actualCluster)
 			Expect(err).NotTo(HaveOccurred())
 
 			actualAnnotation := actualCluster.Annotations[gsannotation.NetworkTopologyModeAnnotation]
-			Expect(actualAnnotation).To(Equal(gsannotation.NetworkTopologyModeNone))
+			Expect(actualAnnotation).To(BeEmpty())
 		})
 
 		It("should not set the gateway ID", func() {
@@ -299,12 +405,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 
 	When("the cluster topology mode annotation is set to 'None'", func() {
 		BeforeEach(func() {
-			reconciler = controllers.NewNetworkTopologyReconciler(
-				clusterClient,
-				[]controllers.Registrar{
-					registrar.NewTransitGateway(new(awsfakes.FakeTransitGatewayClient), clusterClient, nil),
-				},
-			)
+			reconciler = newTransitGatewayReconciler(new(awsfakes.FakeTransitGatewayClient), nil, registrar.TransitGatewayOptions{})
 
 			patchedCluster := cluster.DeepCopy()
 			patchedCluster.Finalizers = []string{controllers.FinalizerNetTop}
@@ -365,12 +466,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 				panic("Should not be called in this test case")
 			}
 
-			reconciler = controllers.NewNetworkTopologyReconciler(
-				clusterClient,
-				[]controllers.Registrar{
-					registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-				},
-			)
+			reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 			patchedCluster := cluster.DeepCopy()
 			patchedCluster.Finalizers = []string{controllers.FinalizerNetTop}
@@ -404,6 +500,36 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 			Expect(reconcileErr).NotTo(HaveOccurred())
 		})
 
+		When("the prefix list ID is missing and tracing is enabled", func() {
+			var spanRecorder *tracetest.SpanRecorder
+
+			BeforeEach(func() {
+				spanRecorder = tracetest.NewSpanRecorder()
+				previousProvider := otel.GetTracerProvider()
+				otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+				DeferCleanup(otel.SetTracerProvider, previousProvider)
+
+				actualCluster := &capi.Cluster{}
+				Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+				patchedCluster := actualCluster.DeepCopy()
+				delete(patchedCluster.Annotations, gsannotation.NetworkTopologyPrefixListIDAnnotation)
+				Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(actualCluster))).To(Succeed())
+			})
+
+			It("marks the required ID as missing and records the error on the span", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+
+				actualCluster := &capi.Cluster{}
+				Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+				Expect(capiconditions.GetReason(actualCluster, "NetworkTopologyReady")).To(Equal("RequiredIDMissing"))
+
+				Expect(spanRecorder.Ended()).To(ContainElement(And(
+					HaveField("Name()", "TransitGateway.Register"),
+					HaveField("Status().Description", "PrefixList ID not provided"),
+				)))
+			})
+		})
+
 		When("the cluster is a Management Cluster", func() {
 			BeforeEach(func() {
 				mcCluster, wcAWSCluster := newCluster(
@@ -470,12 +596,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 					return transitGatewayClientForWorkloadCluster
 				}
 
-				reconciler = controllers.NewNetworkTopologyReconciler(
-					clusterClient,
-					[]controllers.Registrar{
-						registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-					},
-				)
+				reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 				request = ctrl.Request{
 					NamespacedName: types.NamespacedName{
@@ -494,6 +615,12 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						},
 					}, nil)
 				})
+				It("should disable the default route table association and propagation", func() {
+					_, payload, _ := transitGatewayClient.CreateTransitGatewayArgsForCall(0)
+					Expect(payload.Options.DefaultRouteTableAssociation).To(Equal(awstypes.DefaultRouteTableAssociationValueDisable))
+					Expect(payload.Options.DefaultRouteTablePropagation).To(Equal(awstypes.DefaultRouteTablePropagationValueDisable))
+				})
+
 				It("should create a transit gateway attachment", func() {
 					// Management vs. workload cluster AWS account
 					Expect(transitGatewayClient.CreateTransitGatewayVpcAttachmentCallCount()).To(Equal(0))
@@ -619,12 +746,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 					return transitGatewayClientForWorkloadCluster
 				}
 
-				reconciler = controllers.NewNetworkTopologyReconciler(
-					clusterClient,
-					[]controllers.Registrar{
-						registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-					},
-				)
+				reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 				request = ctrl.Request{
 					NamespacedName: types.NamespacedName{
@@ -674,16 +796,113 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 				})
 			})
 
+			When("the transit gateway attachment was rejected", func() {
+				BeforeEach(func() {
+					transitGatewayClientForWorkloadCluster.DescribeTransitGatewayVpcAttachmentsReturns(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+						TransitGatewayVpcAttachments: []awstypes.TransitGatewayVpcAttachment{
+							{
+								TransitGatewayAttachmentId: aws.String("tgw-attach-123"),
+								TransitGatewayId:           &transitGatewayID,
+								State:                      awstypes.TransitGatewayAttachmentStateRejected,
+								VpcOwnerId:                 aws.String(wcVPCId),
+							},
+						},
+					}, nil)
+				})
+
+				It("sets the AttachmentRejected condition reason without sending the SNS message", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(transitGatewayClient.PublishSNSMessageCallCount()).To(Equal(0))
+
+					actualCluster := &capi.Cluster{}
+					Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+					condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+					Expect(condition).NotTo(BeNil())
+					Expect(condition.Reason).To(Equal("AttachmentRejected"))
+				})
+			})
+
+			When("the transit gateway attachment failed", func() {
+				BeforeEach(func() {
+					transitGatewayClientForWorkloadCluster.DescribeTransitGatewayVpcAttachmentsReturns(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+						TransitGatewayVpcAttachments: []awstypes.TransitGatewayVpcAttachment{
+							{
+								TransitGatewayAttachmentId: aws.String("tgw-attach-failed"),
+								TransitGatewayId:           &transitGatewayID,
+								State:                      awstypes.TransitGatewayAttachmentStateFailed,
+								VpcOwnerId:                 aws.String(wcVPCId),
+							},
+						},
+					}, nil)
+				})
+
+				It("deletes the attachment before recreating it", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(transitGatewayClientForWorkloadCluster.DeleteTransitGatewayVpcAttachmentCallCount()).To(Equal(1))
+					_, deleteInput, _ := transitGatewayClientForWorkloadCluster.DeleteTransitGatewayVpcAttachmentArgsForCall(0)
+					Expect(*deleteInput.TransitGatewayAttachmentId).To(Equal("tgw-attach-failed"))
+					Expect(transitGatewayClientForWorkloadCluster.CreateTransitGatewayVpcAttachmentCallCount()).To(Equal(0))
+
+					actualTopology := &v1alpha1.NetworkTopology{}
+					Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+					Expect(actualTopology.Status.TransitGatewayAttachment.ID).To(Equal("tgw-attach-failed"))
+					Expect(actualTopology.Status.TransitGatewayAttachment.State).To(Equal(string(awstypes.TransitGatewayAttachmentStateDeleting)))
+					Expect(actualTopology.Status.TransitGatewayAttachment.Recreations).To(Equal(int32(1)))
+
+					events := []string{}
+					for len(recorder.Events) > 0 {
+						events = append(events, <-recorder.Events)
+					}
+					Expect(events).To(ContainElement(HavePrefix("Warning TransitGatewayAttachmentRecreated")))
+				})
+
+				It("doesn't report the network topology as ready while the attachment is deleted", func() {
+					Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+					actualCluster := &capi.Cluster{}
+					Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+					condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+					Expect(condition).NotTo(BeNil())
+					Expect(condition.Status).To(Equal(v1.ConditionFalse))
+					Expect(condition.Reason).To(Equal("AttachmentDeleted"))
+					Expect(condition.Severity).To(Equal(capi.ConditionSeverityWarning))
+				})
+
+				When("the recreation limit is reached", func() {
+					JustBeforeEach(func() {
+						for i := 0; i < registrar.DefaultAttachmentRecreationLimit; i++ {
+							result, reconcileErr = reconciler.Reconcile(ctx, request)
+						}
+					})
+
+					It("stops recreating the attachment and sets the AttachmentFailed condition reason", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClientForWorkloadCluster.DeleteTransitGatewayVpcAttachmentCallCount()).To(Equal(registrar.DefaultAttachmentRecreationLimit))
+						Expect(result.RequeueAfter).To(Equal(10 * time.Minute))
+
+						actualCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+						condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.Reason).To(Equal("AttachmentFailed"))
+						Expect(condition.Severity).To(Equal(capi.ConditionSeverityError))
+					})
+				})
+			})
+
 			When("the transit gateway attachment already exists and it's pending approval", func() {
 				BeforeEach(func() {
 					transitGatewayClientForWorkloadCluster.DescribeTransitGatewayVpcAttachmentsReturns(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
 						TransitGatewayVpcAttachments: []awstypes.TransitGatewayVpcAttachment{
 							{
-								State:      awstypes.TransitGatewayAttachmentStatePendingAcceptance,
-								VpcOwnerId: aws.String(wcVPCId),
+								TransitGatewayAttachmentId: aws.String("tgw-attach-123"),
+								TransitGatewayId:           &transitGatewayID,
+								State:                      awstypes.TransitGatewayAttachmentStatePendingAcceptance,
+								VpcOwnerId:                 aws.String(wcVPCId),
 							},
 						},
 					}, nil)
+					transitGatewayClient.PublishSNSMessageReturns(&sns.PublishOutput{MessageId: aws.String("message-1")}, nil)
 				})
 				It("should not create the transit gateway attachment again", func() {
 					Expect(transitGatewayClient.CreateTransitGatewayVpcAttachmentCallCount()).To(Equal(0))
@@ -693,6 +912,123 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 				It("should send the SNS message", func() {
 					Expect(transitGatewayClient.PublishSNSMessageCallCount()).To(Equal(1))
 				})
+
+				It("should send a versioned attachment request", func() {
+					_, input, _ := transitGatewayClient.PublishSNSMessageArgsForCall(0)
+
+					attachmentRequest := registrar.AttachmentRequest{}
+					Expect(json.Unmarshal([]byte(*input.Message), &attachmentRequest)).To(Succeed())
+					Expect(attachmentRequest.SchemaVersion).To(Equal(registrar.AttachmentRequestSchemaVersion))
+					Expect(attachmentRequest.Cluster.Name).To(Equal(request.Name))
+					Expect(attachmentRequest.Cluster.Namespace).To(Equal(request.Namespace))
+					Expect(attachmentRequest.ManagementCluster).To(Equal(clusterClient.GetManagementClusterNamespacedName().Name))
+					Expect(attachmentRequest.AccountID).To(Equal(wcVPCId))
+					Expect(attachmentRequest.TransitGatewayID).To(Equal(transitGatewayID))
+					Expect(attachmentRequest.AttachmentID).To(Equal("tgw-attach-123"))
+					Expect(attachmentRequest.Contact).To(Equal(registrar.DefaultContactAddress))
+					Expect(attachmentRequest.Callback.Action).To(Equal(registrar.AcceptAttachmentAction))
+
+					Expect(*input.MessageAttributes["Schema_Version"].StringValue).To(Equal(registrar.AttachmentRequestSchemaVersion))
+					Expect(*input.MessageAttributes["Postfach"].StringValue).To(Equal(registrar.DefaultContactAddress))
+					Expect(*input.MessageAttributes["Attachment_ID"].StringValue).To(Equal("tgw-attach-123"))
+					Expect(*input.MessageAttributes["Name"].StringValue).To(Equal(request.Name))
+				})
+
+				It("should track the acceptance request in the network topology", func() {
+					actualTopology := &v1alpha1.NetworkTopology{}
+					Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+
+					acceptanceRequest := actualTopology.Status.TransitGatewayAttachment.AcceptanceRequest
+					Expect(acceptanceRequest).NotTo(BeNil())
+					Expect(acceptanceRequest.MessageID).To(Equal("message-1"))
+					Expect(acceptanceRequest.Notifications).To(Equal(int32(1)))
+					Expect(acceptanceRequest.LastNotificationTime).NotTo(BeNil())
+				})
+
+				It("sets the AttachmentPendingAcceptance condition reason", func() {
+					actualCluster := &capi.Cluster{}
+					Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+					condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+					Expect(condition).NotTo(BeNil())
+					Expect(condition.Reason).To(Equal("AttachmentPendingAcceptance"))
+				})
+
+				It("should not send the SNS message again", func() {
+					_, err := reconciler.Reconcile(ctx, request)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(transitGatewayClient.PublishSNSMessageCallCount()).To(Equal(1))
+				})
+
+				When("the owner of the transit gateway rejected the attachment through the callback", func() {
+					JustBeforeEach(func() {
+						topology := &v1alpha1.NetworkTopology{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, topology)).To(Succeed())
+						base := topology.DeepCopy()
+						topology.Status.TransitGatewayAttachment.AcceptanceRequest.Response = &v1alpha1.AttachmentAcceptanceResponse{
+							Decision: v1alpha1.AttachmentRejected,
+							Reason:   "CIDR overlaps",
+							Time:     metav1.Now(),
+						}
+						Expect(k8sClient.Status().Patch(ctx, topology, client.MergeFrom(base))).To(Succeed())
+
+						result, reconcileErr = reconciler.Reconcile(ctx, request)
+					})
+
+					It("sets the AttachmentRejected condition reason", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.PublishSNSMessageCallCount()).To(Equal(1))
+
+						actualCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+						condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.Reason).To(Equal("AttachmentRejected"))
+						Expect(condition.Message).To(ContainSubstring("CIDR overlaps"))
+					})
+				})
+
+				When("the reminder interval elapsed", func() {
+					BeforeEach(func() {
+						reconciler = newTransitGatewayReconciler(transitGatewayClient, func(types.NamespacedName) awsclient.TransitGatewayClient {
+							return transitGatewayClientForWorkloadCluster
+						}, registrar.TransitGatewayOptions{
+							Notification: registrar.NotificationOptions{ReminderInterval: time.Nanosecond},
+						})
+					})
+
+					It("should send a reminder", func() {
+						_, err := reconciler.Reconcile(ctx, request)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.PublishSNSMessageCallCount()).To(Equal(2))
+
+						_, first, _ := transitGatewayClient.PublishSNSMessageArgsForCall(0)
+						_, reminder, _ := transitGatewayClient.PublishSNSMessageArgsForCall(1)
+						Expect(*first.MessageDeduplicationId).To(Equal("tgw-attach-123-1"))
+						Expect(*reminder.MessageDeduplicationId).To(Equal("tgw-attach-123-2"))
+
+						actualTopology := &v1alpha1.NetworkTopology{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+						Expect(actualTopology.Status.TransitGatewayAttachment.AcceptanceRequest.Notifications).To(Equal(int32(2)))
+					})
+				})
+
+				When("the SNS message can't be published", func() {
+					BeforeEach(func() {
+						transitGatewayClient.PublishSNSMessageReturns(nil, fmt.Errorf("failed to assume role"))
+					})
+
+					It("sets the SNSUnavailable condition reason and retries", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+						actualCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+						condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.Reason).To(Equal("SNSUnavailable"))
+						Expect(condition.Message).To(ContainSubstring("failed to assume role"))
+					})
+				})
 			})
 		})
 
@@ -737,6 +1073,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 								TransitGatewayId:           &transitGatewayID,
 								VpcId:                      &wcAWSCluster.Spec.NetworkSpec.VPC.ID,
 								State:                      awstypes.TransitGatewayAttachmentStateAvailable,
+								SubnetIds:                  []string{"sub-1"},
 							},
 						},
 					},
@@ -758,12 +1095,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 					return transitGatewayClientForWorkloadCluster
 				}
 
-				reconciler = controllers.NewNetworkTopologyReconciler(
-					clusterClient,
-					[]controllers.Registrar{
-						registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-					},
-				)
+				reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 				request = ctrl.Request{
 					NamespacedName: types.NamespacedName{
@@ -818,12 +1150,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 				panic("Should not be called in this test case")
 			}
 
-			reconciler = controllers.NewNetworkTopologyReconciler(
-				clusterClient,
-				[]controllers.Registrar{
-					registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-				},
-			)
+			reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 			patchedCluster := cluster.DeepCopy()
 			patchedCluster.Finalizers = []string{controllers.FinalizerNetTop}
@@ -846,13 +1173,24 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 			Expect(actualMode).To(Equal(gsannotation.NetworkTopologyModeGiantSwarmManaged))
 		})
 
-		It("should set the gateway ID annotation value", func() {
+		It("should not write the gateway ID back to the cluster annotations", func() {
 			actualCluster := &capi.Cluster{}
 			err := k8sClient.Get(ctx, request.NamespacedName, actualCluster)
 			Expect(err).NotTo(HaveOccurred())
 
-			actualID := actualCluster.Annotations[gsannotation.NetworkTopologyTransitGatewayIDAnnotation]
-			Expect(actualID).To(Equal(transitGatewayARN))
+			Expect(actualCluster.Annotations).NotTo(HaveKey(gsannotation.NetworkTopologyTransitGatewayIDAnnotation))
+		})
+
+		It("should set the transit gateway on the NetworkTopology", func() {
+			actualTopology := &v1alpha1.NetworkTopology{}
+			err := k8sClient.Get(ctx, request.NamespacedName, actualTopology)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actualTopology.Spec.Mode).To(Equal(v1alpha1.NetworkTopologyModeGiantSwarmManaged))
+			Expect(actualTopology.Spec.TransitGateway).To(Equal(&v1alpha1.AWSResourceReference{
+				ID:  transitGatewayID,
+				ARN: transitGatewayARN,
+			}))
 		})
 
 		It("does requeue the event", func() {
@@ -872,11 +1210,16 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 				Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(cluster))).To(Succeed())
 			})
 
-			It("sets the annotation to the arn", func() {
+			It("sets the arn on the NetworkTopology and leaves the annotation untouched", func() {
+				actualTopology := &v1alpha1.NetworkTopology{}
+				err := k8sClient.Get(ctx, request.NamespacedName, actualTopology)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualTopology.Spec.TransitGateway.ARN).To(Equal(transitGatewayARN))
+
 				actualCluster := &capi.Cluster{}
-				err := k8sClient.Get(ctx, request.NamespacedName, actualCluster)
+				err = k8sClient.Get(ctx, request.NamespacedName, actualCluster)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(actualCluster.Annotations[gsannotation.NetworkTopologyTransitGatewayIDAnnotation]).To(Equal(transitGatewayARN))
+				Expect(actualCluster.Annotations[gsannotation.NetworkTopologyTransitGatewayIDAnnotation]).To(Equal(transitGatewayID))
 			})
 		})
 
@@ -952,6 +1295,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 								TransitGatewayAttachmentId: &transitGatewayID,
 								TransitGatewayId:           &transitGatewayID,
 								VpcId:                      &mcAWSCluster.Spec.NetworkSpec.VPC.ID,
+								State:                      awstypes.TransitGatewayAttachmentStatePending,
 							},
 						},
 						nil,
@@ -961,12 +1305,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1013,9 +1352,34 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 					Expect(*payload.VpcId).To(Equal(mcVPCId))
 				})
 
+				It("should record events for the created resources", func() {
+					events := []string{}
+					for len(recorder.Events) > 0 {
+						events = append(events, <-recorder.Events)
+					}
+
+					Expect(events).To(ContainElements(
+						HavePrefix("Normal TransitGatewayCreated"),
+						HavePrefix("Normal TransitGatewayAttachmentCreated"),
+						HavePrefix("Normal PrefixListEntriesAdded"),
+						HavePrefix("Normal AttachmentPending"),
+					))
+					Expect(events).NotTo(ContainElement(HavePrefix("Normal NetworkTopologyReady")))
+				})
+
 				It("should not create routes on subnet route tables", func() {
 					Expect(transitGatewayClientForWorkloadCluster.CreateRouteCallCount()).To(Equal(0))
 				})
+
+				It("should record the attachment and prefix list entry on the NetworkTopology", func() {
+					actualTopology := &v1alpha1.NetworkTopology{}
+					err := k8sClient.Get(ctx, request.NamespacedName, actualTopology)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(actualTopology.Status.TransitGatewayAttachment.ID).To(Equal(transitGatewayID))
+					Expect(actualTopology.Status.PrefixListEntry.PrefixListID).To(Equal(prefixListID))
+					Expect(actualTopology.Spec.PrefixList.ARN).To(Equal(prefixListARN))
+				})
 			})
 
 			When("the cluster has an existing transit gateway but no attachment", func() {
@@ -1091,12 +1455,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1147,11 +1506,11 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(cluster))).To(Succeed())
 					})
 
-					It("sets the annotation to the arn", func() {
-						actualCluster := &capi.Cluster{}
-						err := k8sClient.Get(ctx, request.NamespacedName, actualCluster)
+					It("sets the arn on the NetworkTopology", func() {
+						actualTopology := &v1alpha1.NetworkTopology{}
+						err := k8sClient.Get(ctx, request.NamespacedName, actualTopology)
 						Expect(err).NotTo(HaveOccurred())
-						Expect(actualCluster.Annotations[gsannotation.NetworkTopologyPrefixListIDAnnotation]).To(Equal(prefixListARN))
+						Expect(actualTopology.Spec.PrefixList.ARN).To(Equal(prefixListARN))
 					})
 				})
 			})
@@ -1229,12 +1588,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1328,12 +1682,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1357,6 +1706,177 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 					Expect(transitGatewayClient.DeleteTransitGatewayVpcAttachmentCallCount()).To(Equal(0))
 					Expect(transitGatewayClientForWorkloadCluster.DeleteTransitGatewayVpcAttachmentCallCount()).To(Equal(1))
 				})
+
+				It("deletes the prefix list and its resource shares", func() {
+					Expect(resourceShareClient.DeleteResourceSharesOfCallCount()).To(Equal(1))
+					_, resourceARN := resourceShareClient.DeleteResourceSharesOfArgsForCall(0)
+					Expect(resourceARN).To(Equal(prefixListARN))
+
+					Expect(transitGatewayClient.DeleteManagedPrefixListCallCount()).To(Equal(1))
+					_, input, _ := transitGatewayClient.DeleteManagedPrefixListArgsForCall(0)
+					Expect(*input.PrefixListId).To(Equal(prefixListID))
+				})
+
+				When("the prefix list still has entries of other clusters", func() {
+					BeforeEach(func() {
+						transitGatewayClient.GetManagedPrefixListEntriesReturns(
+							&ec2.GetManagedPrefixListEntriesOutput{
+								Entries: []awstypes.PrefixListEntry{
+									{
+										Cidr:        aws.String("10.1.0.0/16"),
+										Description: aws.String("CIDR block for cluster other-cluster"),
+									},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("deletes the transit gateway but waits before deleting the prefix list", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.DeleteTransitGatewayCallCount()).To(Equal(1))
+						Expect(transitGatewayClient.DeleteManagedPrefixListCallCount()).To(Equal(0))
+						Expect(resourceShareClient.DeleteResourceSharesOfCallCount()).To(Equal(0))
+
+						actualTopology := &v1alpha1.NetworkTopology{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+						Expect(actualTopology.Status.TransitGatewayDeletion).NotTo(BeNil())
+						Expect(actualTopology.Status.TransitGatewayDeletion.Stage).To(Equal(v1alpha1.TransitGatewayDeletionDeletingPrefixLists))
+						Expect(actualTopology.Status.TransitGatewayDeletion.Blocking).To(ConsistOf(prefixListID + " (entry 10.1.0.0/16)"))
+					})
+
+					It("deletes the prefix list once it is empty without deleting the transit gateway again", func() {
+						transitGatewayClient.GetManagedPrefixListEntriesReturns(&ec2.GetManagedPrefixListEntriesOutput{}, nil)
+
+						_, reconcileErr = reconciler.Reconcile(ctx, request)
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.DeleteTransitGatewayCallCount()).To(Equal(1))
+						Expect(transitGatewayClient.DeleteManagedPrefixListCallCount()).To(Equal(1))
+					})
+				})
+
+				When("the prefix list is referenced by a route table", func() {
+					BeforeEach(func() {
+						transitGatewayClient.GetManagedPrefixListAssociationsReturns(
+							&ec2.GetManagedPrefixListAssociationsOutput{
+								PrefixListAssociations: []awstypes.PrefixListAssociation{
+									{
+										ResourceId:    aws.String("rtb-0123456789abcdef0"),
+										ResourceOwner: aws.String("123456789012"),
+									},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("waits before deleting the prefix list", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.DeleteManagedPrefixListCallCount()).To(Equal(0))
+
+						actualCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+						condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.Reason).To(Equal("TransitGatewayDeletionBlocked"))
+						Expect(condition.Message).To(ContainSubstring(prefixListID + " (rtb-0123456789abcdef0)"))
+					})
+				})
+
+				When("the deletion policy is Retain", func() {
+					BeforeEach(func() {
+						setDeletionPolicy(request.NamespacedName, v1alpha1.DeletionPolicyRetain)
+					})
+
+					It("detaches the transit gateway attachment but keeps the transit gateway and prefix list", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClientForWorkloadCluster.DeleteTransitGatewayVpcAttachmentCallCount()).To(Equal(1))
+						Expect(transitGatewayClient.DeleteTransitGatewayCallCount()).To(Equal(0))
+						Expect(transitGatewayClient.DeleteManagedPrefixListCallCount()).To(Equal(0))
+
+						events := []string{}
+						for len(recorder.Events) > 0 {
+							events = append(events, <-recorder.Events)
+						}
+						Expect(events).To(ContainElement(HavePrefix("Normal ResourcesRetained Kept transit gateway " + transitGatewayID)))
+					})
+				})
+
+				When("the deletion policy is Orphan", func() {
+					BeforeEach(func() {
+						setDeletionPolicy(request.NamespacedName, v1alpha1.DeletionPolicyOrphan)
+					})
+
+					It("leaves the AWS resources untouched", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClientForWorkloadCluster.DeleteTransitGatewayVpcAttachmentCallCount()).To(Equal(0))
+						Expect(transitGatewayClient.DeleteTransitGatewayCallCount()).To(Equal(0))
+						Expect(transitGatewayClient.DeleteManagedPrefixListCallCount()).To(Equal(0))
+					})
+				})
+
+				When("the transit gateway still has attachments", func() {
+					BeforeEach(func() {
+						transitGatewayClient.DescribeTransitGatewayAttachmentsReturns(
+							&ec2.DescribeTransitGatewayAttachmentsOutput{
+								TransitGatewayAttachments: []awstypes.TransitGatewayAttachment{
+									{
+										TransitGatewayAttachmentId: aws.String("tgw-attach-wc"),
+										TransitGatewayId:           &transitGatewayID,
+										ResourceType:               awstypes.TransitGatewayAttachmentResourceTypeVpc,
+										ResourceId:                 aws.String(wcVPCId),
+										State:                      awstypes.TransitGatewayAttachmentStateDeleting,
+									},
+									{
+										TransitGatewayAttachmentId: aws.String("tgw-attach-deleted"),
+										TransitGatewayId:           &transitGatewayID,
+										ResourceType:               awstypes.TransitGatewayAttachmentResourceTypeVpc,
+										ResourceId:                 aws.String("vpc-deleted"),
+										State:                      awstypes.TransitGatewayAttachmentStateDeleted,
+									},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("waits for the attachments before deleting the transit gateway", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.DeleteTransitGatewayCallCount()).To(Equal(0))
+						Expect(transitGatewayClient.DeleteTransitGatewayRouteTableCallCount()).To(Equal(0))
+						Expect(result.RequeueAfter).To(Equal(15 * time.Second))
+
+						actualCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+						Expect(actualCluster.Finalizers).To(ContainElement(controllers.FinalizerNetTop))
+
+						condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+						Expect(condition).NotTo(BeNil())
+						Expect(condition.Reason).To(Equal("TransitGatewayDeletionBlocked"))
+						Expect(condition.Message).To(ContainSubstring("tgw-attach-wc (vpc " + wcVPCId + ", deleting)"))
+						Expect(condition.Message).NotTo(ContainSubstring("tgw-attach-deleted"))
+					})
+
+					It("backs off while the attachments exist", func() {
+						result, reconcileErr = reconciler.Reconcile(ctx, request)
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+
+						actualTopology := &v1alpha1.NetworkTopology{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+						Expect(actualTopology.Status.TransitGatewayDeletion).NotTo(BeNil())
+						Expect(actualTopology.Status.TransitGatewayDeletion.Stage).To(Equal(v1alpha1.TransitGatewayDeletionWaitingForAttachments))
+						Expect(actualTopology.Status.TransitGatewayDeletion.Attempts).To(Equal(int32(2)))
+					})
+
+					It("deletes the transit gateway once the attachments are deleted", func() {
+						transitGatewayClient.DescribeTransitGatewayAttachmentsReturns(&ec2.DescribeTransitGatewayAttachmentsOutput{}, nil)
+
+						_, reconcileErr = reconciler.Reconcile(ctx, request)
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.DeleteTransitGatewayCallCount()).To(Equal(1))
+					})
+				})
 			})
 		})
 
@@ -1442,12 +1962,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1566,12 +2081,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1606,6 +2116,246 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 					}))
 					Expect(*payload.VpcId).To(Equal(wcVPCId))
 				})
+
+				When("the prefix list entries are paginated", func() {
+					BeforeEach(func() {
+						transitGatewayClient.GetManagedPrefixListEntriesReturnsOnCall(0,
+							&ec2.GetManagedPrefixListEntriesOutput{
+								Entries: []awstypes.PrefixListEntry{
+									{Cidr: aws.String("10.1.0.0/16"), Description: aws.String("CIDR block for cluster other")},
+								},
+								NextToken: aws.String("next"),
+							},
+							nil,
+						)
+						transitGatewayClient.GetManagedPrefixListEntriesReturnsOnCall(1,
+							&ec2.GetManagedPrefixListEntriesOutput{
+								Entries: []awstypes.PrefixListEntry{
+									{Cidr: aws.String(clusterCIDR), Description: aws.String(fmt.Sprintf("CIDR block for cluster %s/%s in %s", request.Namespace, request.Name, wcVPCId))},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("follows the next token", func() {
+						Expect(transitGatewayClient.GetManagedPrefixListEntriesCallCount()).To(Equal(2))
+						_, payload, _ := transitGatewayClient.GetManagedPrefixListEntriesArgsForCall(1)
+						Expect(*payload.NextToken).To(Equal("next"))
+					})
+
+					It("does not add the existing entry again", func() {
+						Expect(transitGatewayClient.ModifyManagedPrefixListCallCount()).To(Equal(0))
+					})
+				})
+
+				When("the VPC has secondary CIDR blocks", func() {
+					BeforeEach(func() {
+						transitGatewayClientForWorkloadCluster.DescribeVpcsReturns(
+							&ec2.DescribeVpcsOutput{
+								Vpcs: []awstypes.Vpc{
+									{
+										VpcId: aws.String(wcVPCId),
+										CidrBlockAssociationSet: []awstypes.VpcCidrBlockAssociation{
+											{
+												CidrBlock:      aws.String(clusterCIDR),
+												CidrBlockState: &awstypes.VpcCidrBlockState{State: awstypes.VpcCidrBlockStateCodeAssociated},
+											},
+											{
+												CidrBlock:      aws.String("100.64.0.0/16"),
+												CidrBlockState: &awstypes.VpcCidrBlockState{State: awstypes.VpcCidrBlockStateCodeAssociated},
+											},
+											{
+												CidrBlock:      aws.String("100.65.0.0/16"),
+												CidrBlockState: &awstypes.VpcCidrBlockState{State: awstypes.VpcCidrBlockStateCodeDisassociated},
+											},
+										},
+									},
+								},
+							},
+							nil,
+						)
+						transitGatewayClient.GetManagedPrefixListEntriesReturns(
+							&ec2.GetManagedPrefixListEntriesOutput{
+								Entries: []awstypes.PrefixListEntry{
+									{Cidr: aws.String("100.66.0.0/16"), Description: aws.String(fmt.Sprintf("CIDR block for cluster %s/%s in %s", request.Namespace, request.Name, wcVPCId))},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("adds an entry for every associated CIDR block", func() {
+						_, input, _ := transitGatewayClientForWorkloadCluster.DescribeVpcsArgsForCall(0)
+						Expect(input.VpcIds).To(ConsistOf(wcVPCId))
+
+						Expect(transitGatewayClient.ModifyManagedPrefixListCallCount()).To(Equal(1))
+						_, payload, _ := transitGatewayClient.ModifyManagedPrefixListArgsForCall(0)
+						Expect(payload.AddEntries).To(ConsistOf(
+							awstypes.AddPrefixListEntry{Cidr: aws.String(clusterCIDR), Description: aws.String(fmt.Sprintf("CIDR block for cluster %s/%s in %s", request.Namespace, request.Name, wcVPCId))},
+							awstypes.AddPrefixListEntry{Cidr: aws.String("100.64.0.0/16"), Description: aws.String(fmt.Sprintf("CIDR block for cluster %s/%s in %s", request.Namespace, request.Name, wcVPCId))},
+						))
+					})
+
+					It("removes entries of CIDR blocks no longer associated with the VPC", func() {
+						_, payload, _ := transitGatewayClient.ModifyManagedPrefixListArgsForCall(0)
+						Expect(payload.RemoveEntries).To(ConsistOf(awstypes.RemovePrefixListEntry{Cidr: aws.String("100.66.0.0/16")}))
+					})
+
+					It("sets the secondary CIDR blocks in the status", func() {
+						actualTopology := &v1alpha1.NetworkTopology{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+						Expect(actualTopology.Status.PrefixListEntry).NotTo(BeNil())
+						Expect(actualTopology.Status.PrefixListEntry.SecondaryCIDRs).To(ConsistOf("100.64.0.0/16"))
+					})
+				})
+
+				When("the VPC is dual-stack", func() {
+					BeforeEach(func() {
+						transitGatewayClientForWorkloadCluster.DescribeVpcsReturns(
+							&ec2.DescribeVpcsOutput{
+								Vpcs: []awstypes.Vpc{
+									{
+										VpcId: aws.String(wcVPCId),
+										Ipv6CidrBlockAssociationSet: []awstypes.VpcIpv6CidrBlockAssociation{
+											{
+												Ipv6CidrBlock:      aws.String("2600:1f18:abcd:1200::/56"),
+												Ipv6CidrBlockState: &awstypes.VpcCidrBlockState{State: awstypes.VpcCidrBlockStateCodeAssociated},
+											},
+										},
+									},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("enables IPv6 support on the attachment", func() {
+						_, payload, _ := transitGatewayClientForWorkloadCluster.CreateTransitGatewayVpcAttachmentArgsForCall(0)
+						Expect(payload.Options).NotTo(BeNil())
+						Expect(payload.Options.Ipv6Support).To(Equal(awstypes.Ipv6SupportValueEnable))
+					})
+
+					It("adds the IPv6 CIDR block to the IPv6 prefix list", func() {
+						_, describePayload, _ := transitGatewayClient.DescribeManagedPrefixListsArgsForCall(transitGatewayClient.DescribeManagedPrefixListsCallCount() - 1)
+						Expect(describePayload.Filters[0].Values).To(ConsistOf(ContainSubstring("tgw-ipv6-prefixlist")))
+
+						Expect(transitGatewayClient.ModifyManagedPrefixListCallCount()).To(Equal(2))
+						_, payload, _ := transitGatewayClient.ModifyManagedPrefixListArgsForCall(1)
+						Expect(payload.AddEntries).To(ConsistOf(
+							awstypes.AddPrefixListEntry{Cidr: aws.String("2600:1f18:abcd:1200::/56"), Description: aws.String(fmt.Sprintf("CIDR block for cluster %s/%s in %s", request.Namespace, request.Name, wcVPCId))},
+						))
+					})
+
+					It("writes the IPv6 prefix list back to the NetworkTopology", func() {
+						actualTopology := &v1alpha1.NetworkTopology{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+						Expect(actualTopology.Spec.IPv6PrefixList).NotTo(BeNil())
+						Expect(actualTopology.Spec.IPv6PrefixList.ARN).To(Equal(prefixListARN))
+						Expect(actualTopology.Status.IPv6PrefixListEntry).NotTo(BeNil())
+						Expect(actualTopology.Status.IPv6PrefixListEntry.CIDR).To(Equal("2600:1f18:abcd:1200::/56"))
+
+						actualCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+						Expect(actualCluster.Annotations).NotTo(HaveKey(annotations.NetworkTopologyIPv6PrefixListAnnotation))
+					})
+				})
+
+				When("the prefix list was modified concurrently", func() {
+					BeforeEach(func() {
+						transitGatewayClient.ModifyManagedPrefixListReturnsOnCall(0, nil, &smithy.GenericAPIError{Code: "PrefixListVersionMismatch"})
+					})
+
+					It("reads the prefix list again and retries", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(transitGatewayClient.ModifyManagedPrefixListCallCount()).To(Equal(2))
+
+						_, input, _ := transitGatewayClient.DescribeManagedPrefixListsArgsForCall(transitGatewayClient.DescribeManagedPrefixListsCallCount() - 1)
+						Expect(input.PrefixListIds).To(ConsistOf(prefixListID))
+					})
+				})
+
+				When("the prefix list is full", func() {
+					BeforeEach(func() {
+						transitGatewayClient.DescribeManagedPrefixListsReturns(
+							&ec2.DescribeManagedPrefixListsOutput{
+								PrefixLists: []awstypes.ManagedPrefixList{
+									{
+										PrefixListId:  &prefixListID,
+										PrefixListArn: &prefixListARN,
+										Version:       aws.Int64(1),
+										MaxEntries:    aws.Int32(1),
+									},
+								},
+							},
+							nil,
+						)
+						transitGatewayClient.GetManagedPrefixListEntriesReturns(
+							&ec2.GetManagedPrefixListEntriesOutput{
+								Entries: []awstypes.PrefixListEntry{
+									{Cidr: aws.String("10.1.0.0/16"), Description: aws.String("CIDR block for cluster other")},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("grows the prefix list", func() {
+						Expect(transitGatewayClient.ModifyManagedPrefixListCallCount()).To(Equal(1))
+						_, payload, _ := transitGatewayClient.ModifyManagedPrefixListArgsForCall(0)
+						Expect(*payload.MaxEntries).To(Equal(int32(1 + registrar.PREFIX_LIST_MAX_ENTRIES_INCREMENT)))
+						Expect(payload.AddEntries).To(BeEmpty())
+					})
+
+					It("requeues the event", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(result.RequeueAfter).To(Equal(time.Minute))
+					})
+
+					When("the prefix list reached the ceiling", func() {
+						BeforeEach(func() {
+							transitGatewayClient.DescribeManagedPrefixListsReturns(
+								&ec2.DescribeManagedPrefixListsOutput{
+									PrefixLists: []awstypes.ManagedPrefixList{
+										{
+											PrefixListId:  &prefixListID,
+											PrefixListArn: &prefixListARN,
+											Version:       aws.Int64(1),
+											MaxEntries:    aws.Int32(registrar.PREFIX_LIST_MAX_ENTRIES_CEILING),
+										},
+									},
+								},
+								nil,
+							)
+
+							entries := []awstypes.PrefixListEntry{}
+							for i := 0; i < registrar.PREFIX_LIST_MAX_ENTRIES_CEILING; i++ {
+								entries = append(entries, awstypes.PrefixListEntry{
+									Cidr:        aws.String(fmt.Sprintf("172.%d.%d.0/24", 16+i/256, i%256)),
+									Description: aws.String(fmt.Sprintf("CIDR block for cluster other-%d", i)),
+								})
+							}
+							transitGatewayClient.GetManagedPrefixListEntriesReturns(
+								&ec2.GetManagedPrefixListEntriesOutput{
+									Entries: entries,
+								},
+								nil,
+							)
+						})
+
+						It("does not modify the prefix list", func() {
+							Expect(transitGatewayClient.ModifyManagedPrefixListCallCount()).To(Equal(0))
+						})
+
+						It("sets the PrefixListFull condition reason", func() {
+							actualCluster := &capi.Cluster{}
+							Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+							condition := capiconditions.Get(actualCluster, "NetworkTopologyReady")
+							Expect(condition).NotTo(BeNil())
+							Expect(condition.Reason).To(Equal("PrefixListFull"))
+						})
+					})
+				})
 			})
 
 			When("the cluster has an existing transit gateway and existing attachment", func() {
@@ -1690,12 +2440,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1714,6 +2459,205 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 					Expect(transitGatewayClient.CreateTransitGatewayVpcAttachmentCallCount()).To(Equal(0))
 					Expect(transitGatewayClientForWorkloadCluster.CreateTransitGatewayVpcAttachmentCallCount()).To(Equal(0))
 				})
+
+				It("should not manage transit gateway route tables", func() {
+					Expect(transitGatewayClient.DescribeTransitGatewayRouteTablesCallCount()).To(Equal(0))
+					Expect(transitGatewayClient.AssociateTransitGatewayRouteTableCallCount()).To(Equal(0))
+				})
+
+				When("the subnets of the attachment changed", func() {
+					BeforeEach(func() {
+						transitGatewayClientForWorkloadCluster.DescribeTransitGatewayVpcAttachmentsReturns(
+							&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+								TransitGatewayVpcAttachments: []awstypes.TransitGatewayVpcAttachment{
+									{
+										TransitGatewayId:           &transitGatewayID,
+										TransitGatewayAttachmentId: &transitGatewayID,
+										VpcId:                      aws.String(wcVPCId),
+										State:                      awstypes.TransitGatewayAttachmentStateAvailable,
+										SubnetIds:                  []string{"sub-old"},
+									},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("modifies the subnets of the attachment", func() {
+						Expect(transitGatewayClientForWorkloadCluster.ModifyTransitGatewayVpcAttachmentCallCount()).To(Equal(1))
+						_, payload, _ := transitGatewayClientForWorkloadCluster.ModifyTransitGatewayVpcAttachmentArgsForCall(0)
+						Expect(*payload.TransitGatewayAttachmentId).To(Equal(transitGatewayID))
+						Expect(payload.AddSubnetIds).To(ConsistOf("sub-1"))
+						Expect(payload.RemoveSubnetIds).To(ConsistOf("sub-old"))
+						Expect(payload.Options).To(BeNil())
+					})
+
+					It("records the drift as an event", func() {
+						events := []string{}
+						for len(recorder.Events) > 0 {
+							events = append(events, <-recorder.Events)
+						}
+						Expect(events).To(ContainElement(HavePrefix("Normal AttachmentSubnetsDrift")))
+					})
+
+					It("continues the registration", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+
+						actualCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualCluster)).To(Succeed())
+						Expect(capiconditions.GetReason(actualCluster, "NetworkTopologyReady")).NotTo(Equal("AttachmentSubnetsDrift"))
+					})
+				})
+
+				When("the cluster has a route table group", func() {
+					routeTableID := "tgw-rtb-prod"
+					sharedServicesRouteTableID := "tgw-rtb-shared-services"
+					defaultRouteTableID := "tgw-rtb-default"
+
+					BeforeEach(func() {
+						wcCluster := &capi.Cluster{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, wcCluster)).To(Succeed())
+						patchedCluster := wcCluster.DeepCopy()
+						patchedCluster.Annotations[annotations.NetworkTopologyRouteTableGroupAnnotation] = "prod"
+						Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(wcCluster))).To(Succeed())
+
+						topology := &v1alpha1.NetworkTopology{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      request.Name,
+								Namespace: request.Namespace,
+							},
+							Spec: v1alpha1.NetworkTopologySpec{
+								PropagateToRouteTableGroups: []string{"shared-services"},
+							},
+						}
+						Expect(k8sClient.Create(ctx, topology)).To(Succeed())
+
+						transitGatewayClientForWorkloadCluster.DescribeTransitGatewayVpcAttachmentsReturns(
+							&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+								TransitGatewayVpcAttachments: []awstypes.TransitGatewayVpcAttachment{
+									{
+										TransitGatewayId:           &transitGatewayID,
+										TransitGatewayAttachmentId: &transitGatewayID,
+										VpcId:                      aws.String(wcVPCId),
+										State:                      awstypes.TransitGatewayAttachmentStateAvailable,
+										SubnetIds:                  []string{"sub-1"},
+									},
+								},
+							},
+							nil,
+						)
+
+						transitGatewayClient.DescribeTransitGatewayRouteTablesReturnsOnCall(0,
+							&ec2.DescribeTransitGatewayRouteTablesOutput{},
+							nil,
+						)
+						transitGatewayClient.DescribeTransitGatewayRouteTablesReturnsOnCall(1,
+							&ec2.DescribeTransitGatewayRouteTablesOutput{
+								TransitGatewayRouteTables: []awstypes.TransitGatewayRouteTable{
+									{
+										TransitGatewayRouteTableId: &sharedServicesRouteTableID,
+										State:                      awstypes.TransitGatewayRouteTableStateAvailable,
+									},
+								},
+							},
+							nil,
+						)
+						transitGatewayClient.CreateTransitGatewayRouteTableReturns(
+							&ec2.CreateTransitGatewayRouteTableOutput{
+								TransitGatewayRouteTable: &awstypes.TransitGatewayRouteTable{
+									TransitGatewayRouteTableId: &routeTableID,
+									State:                      awstypes.TransitGatewayRouteTableStateAvailable,
+								},
+							},
+							nil,
+						)
+						transitGatewayClient.DescribeTransitGatewayAttachmentsReturns(
+							&ec2.DescribeTransitGatewayAttachmentsOutput{
+								TransitGatewayAttachments: []awstypes.TransitGatewayAttachment{
+									{
+										TransitGatewayAttachmentId: &transitGatewayID,
+									},
+								},
+							},
+							nil,
+						)
+						transitGatewayClient.GetTransitGatewayAttachmentPropagationsReturns(
+							&ec2.GetTransitGatewayAttachmentPropagationsOutput{
+								TransitGatewayAttachmentPropagations: []awstypes.TransitGatewayAttachmentPropagation{
+									{
+										TransitGatewayRouteTableId: &defaultRouteTableID,
+										State:                      awstypes.TransitGatewayPropagationStateEnabled,
+									},
+								},
+							},
+							nil,
+						)
+					})
+
+					It("creates the route table of the group", func() {
+						Expect(transitGatewayClient.CreateTransitGatewayRouteTableCallCount()).To(Equal(1))
+						_, payload, _ := transitGatewayClient.CreateTransitGatewayRouteTableArgsForCall(0)
+						Expect(*payload.TransitGatewayId).To(Equal(transitGatewayID))
+						Expect(payload.TagSpecifications[0].Tags).To(ContainElement(awstypes.Tag{
+							Key:   aws.String(registrar.RouteTableGroupTag),
+							Value: aws.String("prod"),
+						}))
+					})
+
+					It("associates the attachment with the route table of the group", func() {
+						Expect(transitGatewayClient.AssociateTransitGatewayRouteTableCallCount()).To(Equal(1))
+						_, payload, _ := transitGatewayClient.AssociateTransitGatewayRouteTableArgsForCall(0)
+						Expect(*payload.TransitGatewayAttachmentId).To(Equal(transitGatewayID))
+						Expect(*payload.TransitGatewayRouteTableId).To(Equal(routeTableID))
+
+						actualTopology := &v1alpha1.NetworkTopology{}
+						Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+						Expect(actualTopology.Status.TransitGatewayAttachment.RouteTableID).To(Equal(routeTableID))
+					})
+
+					It("only propagates to the configured route table groups", func() {
+						Expect(transitGatewayClient.EnableTransitGatewayRouteTablePropagationCallCount()).To(Equal(2))
+						_, payload, _ := transitGatewayClient.EnableTransitGatewayRouteTablePropagationArgsForCall(0)
+						Expect(*payload.TransitGatewayRouteTableId).To(Equal(routeTableID))
+						_, payload, _ = transitGatewayClient.EnableTransitGatewayRouteTablePropagationArgsForCall(1)
+						Expect(*payload.TransitGatewayRouteTableId).To(Equal(sharedServicesRouteTableID))
+
+						Expect(transitGatewayClient.DisableTransitGatewayRouteTablePropagationCallCount()).To(Equal(1))
+						_, disablePayload, _ := transitGatewayClient.DisableTransitGatewayRouteTablePropagationArgsForCall(0)
+						Expect(*disablePayload.TransitGatewayRouteTableId).To(Equal(defaultRouteTableID))
+					})
+
+					When("the attachment is associated with another route table", func() {
+						BeforeEach(func() {
+							transitGatewayClient.DescribeTransitGatewayAttachmentsReturns(
+								&ec2.DescribeTransitGatewayAttachmentsOutput{
+									TransitGatewayAttachments: []awstypes.TransitGatewayAttachment{
+										{
+											TransitGatewayAttachmentId: &transitGatewayID,
+											Association: &awstypes.TransitGatewayAttachmentAssociation{
+												TransitGatewayRouteTableId: &defaultRouteTableID,
+												State:                      awstypes.TransitGatewayAssociationStateAssociated,
+											},
+										},
+									},
+								},
+								nil,
+							)
+						})
+
+						It("disassociates the attachment first", func() {
+							Expect(transitGatewayClient.DisassociateTransitGatewayRouteTableCallCount()).To(Equal(1))
+							_, payload, _ := transitGatewayClient.DisassociateTransitGatewayRouteTableArgsForCall(0)
+							Expect(*payload.TransitGatewayRouteTableId).To(Equal(defaultRouteTableID))
+							Expect(transitGatewayClient.AssociateTransitGatewayRouteTableCallCount()).To(Equal(0))
+						})
+
+						It("requeues the event", func() {
+							Expect(reconcileErr).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(Equal(time.Minute))
+						})
+					})
+				})
 			})
 
 			When("the management cluster annotation is set to 'None'", func() {
@@ -1747,12 +2691,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1808,12 +2747,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
@@ -1907,12 +2841,7 @@ var _ = Describe("NewNetworkTopologyReconciler", func() {
 						return transitGatewayClientForWorkloadCluster
 					}
 
-					reconciler = controllers.NewNetworkTopologyReconciler(
-						clusterClient,
-						[]controllers.Registrar{
-							registrar.NewTransitGateway(transitGatewayClient, clusterClient, getTransitGatewayClientForWorkloadCluster),
-						},
-					)
+					reconciler = newTransitGatewayReconciler(transitGatewayClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{})
 
 					request = ctrl.Request{
 						NamespacedName: types.NamespacedName{
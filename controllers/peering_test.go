@@ -0,0 +1,349 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	awstypes "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/controllers"
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws/awsfakes"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"github.com/giantswarm/aws-network-topology-operator/tests"
+)
+
+var _ = Describe("TransitGatewayPeering", func() {
+	var (
+		ctx context.Context
+
+		name                  string
+		transitGatewayID      = "tgw-0a"
+		transitGatewayARN     = "arn:aws:ec2:eu-west-1:123456789012:transit-gateway/tgw-0a"
+		peerTransitGatewayID  = "tgw-0b"
+		peerTransitGatewayARN = "arn:aws:ec2:us-east-1:123456789012:transit-gateway/tgw-0b"
+		peerPrefixListARN     = "arn:aws:ec2:us-east-1:123456789012:prefix-list/pl-0b"
+		peeringAttachmentID   = "tgw-attach-peering"
+		routeTableID          = "tgw-rtb-default"
+
+		cluster              *capi.Cluster
+		topology             *v1alpha1.NetworkTopology
+		transitGatewayClient *awsfakes.FakeTransitGatewayClient
+		reconciler           *controllers.NetworkTopologyReconciler
+		request              ctrl.Request
+
+		result       ctrl.Result
+		reconcileErr error
+	)
+
+	peeringAttachment := func(requesterID, accepterID string, state awstypes.TransitGatewayAttachmentState) *ec2.DescribeTransitGatewayPeeringAttachmentsOutput {
+		return &ec2.DescribeTransitGatewayPeeringAttachmentsOutput{
+			TransitGatewayPeeringAttachments: []awstypes.TransitGatewayPeeringAttachment{
+				{
+					TransitGatewayAttachmentId: &peeringAttachmentID,
+					RequesterTgwInfo:           &awstypes.PeeringTgwInfo{TransitGatewayId: aws.String(requesterID)},
+					AccepterTgwInfo:            &awstypes.PeeringTgwInfo{TransitGatewayId: aws.String(accepterID)},
+					State:                      state,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		name = tests.GenerateGUID("test")
+		awsCluster := &capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+		Expect(k8sClient.Create(ctx, awsCluster)).To(Succeed())
+
+		cluster = &capi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: capi.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{
+					Kind:      "AWSCluster",
+					Namespace: namespace,
+					Name:      name,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+
+		topology = &v1alpha1.NetworkTopology{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.NetworkTopologySpec{
+				Mode: v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+				TransitGateway: &v1alpha1.AWSResourceReference{
+					ARN: transitGatewayARN,
+				},
+				Peerings: []v1alpha1.TransitGatewayPeeringSpec{
+					{
+						TransitGatewayARN: peerTransitGatewayARN,
+						PrefixListARN:     peerPrefixListARN,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, topology)).To(Succeed())
+
+		transitGatewayClient = new(awsfakes.FakeTransitGatewayClient)
+		transitGatewayClient.DescribeTransitGatewaysReturns(&ec2.DescribeTransitGatewaysOutput{
+			TransitGateways: []awstypes.TransitGateway{
+				{
+					TransitGatewayId:  &transitGatewayID,
+					TransitGatewayArn: &transitGatewayARN,
+					State:             awstypes.TransitGatewayStateAvailable,
+					Options: &awstypes.TransitGatewayOptions{
+						DefaultRouteTableAssociation:   awstypes.DefaultRouteTableAssociationValueEnable,
+						AssociationDefaultRouteTableId: &routeTableID,
+					},
+				},
+			},
+		}, nil)
+		transitGatewayClient.DescribeTransitGatewayPeeringAttachmentsReturns(&ec2.DescribeTransitGatewayPeeringAttachmentsOutput{}, nil)
+		transitGatewayClient.CreateTransitGatewayPeeringAttachmentReturns(&ec2.CreateTransitGatewayPeeringAttachmentOutput{
+			TransitGatewayPeeringAttachment: &peeringAttachment(transitGatewayID, peerTransitGatewayID, awstypes.TransitGatewayAttachmentStateInitiatingRequest).TransitGatewayPeeringAttachments[0],
+		}, nil)
+
+		clusterClient := k8sclient.NewCluster(k8sClient, types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		})
+		reconciler = controllers.NewNetworkTopologyReconciler(
+			clusterClient,
+			[]controllers.Registrar{
+				registrar.NewTransitGatewayPeering(transitGatewayClient, clusterClient, v1alpha1.DeletionPolicyDelete),
+			},
+			record.NewFakeRecorder(100),
+		)
+
+		request = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		result, reconcileErr = reconciler.Reconcile(ctx, request)
+	})
+
+	It("requests the peering", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+		Expect(transitGatewayClient.CreateTransitGatewayPeeringAttachmentCallCount()).To(Equal(1))
+		_, payload, _ := transitGatewayClient.CreateTransitGatewayPeeringAttachmentArgsForCall(0)
+		Expect(*payload.TransitGatewayId).To(Equal(transitGatewayID))
+		Expect(*payload.PeerTransitGatewayId).To(Equal(peerTransitGatewayID))
+		Expect(*payload.PeerAccountId).To(Equal("123456789012"))
+		Expect(*payload.PeerRegion).To(Equal("us-east-1"))
+
+		actualTopology := &v1alpha1.NetworkTopology{}
+		Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+		Expect(actualTopology.Status.Peerings).To(HaveLen(1))
+		Expect(actualTopology.Status.Peerings[0].AttachmentID).To(Equal(peeringAttachmentID))
+	})
+
+	When("the peer transit gateway has the lower ID", func() {
+		BeforeEach(func() {
+			patchedTopology := topology.DeepCopy()
+			patchedTopology.Spec.Peerings[0].TransitGatewayARN = "arn:aws:ec2:us-east-1:123456789012:transit-gateway/tgw-00"
+			Expect(k8sClient.Patch(ctx, patchedTopology, client.MergeFrom(topology))).To(Succeed())
+		})
+
+		It("waits for the peer to request the peering", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+			Expect(transitGatewayClient.CreateTransitGatewayPeeringAttachmentCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the peering is pending acceptance by a peer in the same account", func() {
+		BeforeEach(func() {
+			transitGatewayClient.DescribeTransitGatewayPeeringAttachmentsReturns(
+				peeringAttachment(transitGatewayID, peerTransitGatewayID, awstypes.TransitGatewayAttachmentStatePendingAcceptance), nil,
+			)
+		})
+
+		It("accepts the peering in the region of the peer", func() {
+			Expect(transitGatewayClient.CreateTransitGatewayPeeringAttachmentCallCount()).To(Equal(0))
+			Expect(transitGatewayClient.AcceptTransitGatewayPeeringAttachmentCallCount()).To(Equal(1))
+
+			_, payload, optFns := transitGatewayClient.AcceptTransitGatewayPeeringAttachmentArgsForCall(0)
+			Expect(*payload.TransitGatewayAttachmentId).To(Equal(peeringAttachmentID))
+
+			options := ec2.Options{Region: "eu-west-1"}
+			for _, optFn := range optFns {
+				optFn(&options)
+			}
+			Expect(options.Region).To(Equal("us-east-1"))
+		})
+	})
+
+	When("the peering is available", func() {
+		BeforeEach(func() {
+			transitGatewayClient.DescribeTransitGatewayPeeringAttachmentsReturns(
+				peeringAttachment(transitGatewayID, peerTransitGatewayID, awstypes.TransitGatewayAttachmentStateAvailable), nil,
+			)
+			transitGatewayClient.DescribeTransitGatewayAttachmentsReturns(&ec2.DescribeTransitGatewayAttachmentsOutput{
+				TransitGatewayAttachments: []awstypes.TransitGatewayAttachment{
+					{
+						TransitGatewayAttachmentId: &peeringAttachmentID,
+						Association: &awstypes.TransitGatewayAttachmentAssociation{
+							TransitGatewayRouteTableId: &routeTableID,
+							State:                      awstypes.TransitGatewayAssociationStateAssociated,
+						},
+					},
+				},
+			}, nil)
+			transitGatewayClient.GetManagedPrefixListEntriesReturns(&ec2.GetManagedPrefixListEntriesOutput{
+				Entries: []awstypes.PrefixListEntry{
+					{Cidr: aws.String("10.10.0.0/16")},
+					{Cidr: aws.String("10.11.0.0/16")},
+				},
+			}, nil)
+			transitGatewayClient.SearchTransitGatewayRoutesReturns(&ec2.SearchTransitGatewayRoutesOutput{
+				Routes: []awstypes.TransitGatewayRoute{
+					{DestinationCidrBlock: aws.String("10.10.0.0/16")},
+					{DestinationCidrBlock: aws.String("10.12.0.0/16")},
+				},
+			}, nil)
+		})
+
+		It("reads the peer prefix list in the region of the peer", func() {
+			Expect(transitGatewayClient.GetManagedPrefixListEntriesCallCount()).To(Equal(1))
+			_, payload, optFns := transitGatewayClient.GetManagedPrefixListEntriesArgsForCall(0)
+			Expect(*payload.PrefixListId).To(Equal("pl-0b"))
+
+			options := ec2.Options{}
+			for _, optFn := range optFns {
+				optFn(&options)
+			}
+			Expect(options.Region).To(Equal("us-east-1"))
+		})
+
+		It("creates static routes for the peer CIDRs", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+
+			Expect(transitGatewayClient.CreateTransitGatewayRouteCallCount()).To(Equal(1))
+			_, createPayload, _ := transitGatewayClient.CreateTransitGatewayRouteArgsForCall(0)
+			Expect(*createPayload.DestinationCidrBlock).To(Equal("10.11.0.0/16"))
+			Expect(*createPayload.TransitGatewayRouteTableId).To(Equal(routeTableID))
+			Expect(*createPayload.TransitGatewayAttachmentId).To(Equal(peeringAttachmentID))
+
+			Expect(transitGatewayClient.DeleteTransitGatewayRouteCallCount()).To(Equal(1))
+			_, deletePayload, _ := transitGatewayClient.DeleteTransitGatewayRouteArgsForCall(0)
+			Expect(*deletePayload.DestinationCidrBlock).To(Equal("10.12.0.0/16"))
+		})
+
+		When("the peer prefix list isn't shared with the account", func() {
+			BeforeEach(func() {
+				transitGatewayClient.GetManagedPrefixListEntriesReturns(nil, &smithy.GenericAPIError{
+					Code: awsclient.ErrPrefixListNotFound,
+				})
+			})
+
+			It("returns an error saying the prefix list needs to be shared", func() {
+				Expect(reconcileErr).To(MatchError(&registrar.PeerPrefixListNotSharedError{}))
+				Expect(reconcileErr.Error()).To(ContainSubstring("needs to be shared with the account of the management cluster"))
+				Expect(transitGatewayClient.SearchTransitGatewayRoutesCallCount()).To(Equal(0))
+			})
+		})
+
+		When("there are more static routes than a single search returns", func() {
+			BeforeEach(func() {
+				transitGatewayClient.SearchTransitGatewayRoutesReturns(&ec2.SearchTransitGatewayRoutesOutput{
+					Routes: []awstypes.TransitGatewayRoute{
+						{DestinationCidrBlock: aws.String("10.12.0.0/16")},
+					},
+					AdditionalRoutesAvailable: aws.Bool(true),
+				}, nil)
+			})
+
+			It("doesn't create or delete any routes", func() {
+				Expect(reconcileErr).To(MatchError(&registrar.TooManyTransitGatewayRoutesError{}))
+				Expect(transitGatewayClient.CreateTransitGatewayRouteCallCount()).To(Equal(0))
+				Expect(transitGatewayClient.DeleteTransitGatewayRouteCallCount()).To(Equal(0))
+			})
+		})
+
+		It("records the peering on the NetworkTopology", func() {
+			actualTopology := &v1alpha1.NetworkTopology{}
+			Expect(k8sClient.Get(ctx, request.NamespacedName, actualTopology)).To(Succeed())
+			Expect(actualTopology.Status.Peerings).To(ConsistOf(v1alpha1.TransitGatewayPeeringStatus{
+				TransitGatewayARN: peerTransitGatewayARN,
+				AttachmentID:      peeringAttachmentID,
+				State:             string(awstypes.TransitGatewayAttachmentStateAvailable),
+				RouteTableID:      routeTableID,
+				Routes:            []string{"10.10.0.0/16", "10.11.0.0/16"},
+			}))
+		})
+	})
+
+	When("the cluster is deleted", func() {
+		BeforeEach(func() {
+			transitGatewayClient.DescribeTransitGatewayPeeringAttachmentsReturns(
+				peeringAttachment(transitGatewayID, peerTransitGatewayID, awstypes.TransitGatewayAttachmentStateAvailable), nil,
+			)
+
+			patchedCluster := cluster.DeepCopy()
+			controllerutil.AddFinalizer(patchedCluster, controllers.FinalizerNetTop)
+			Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(cluster))).To(Succeed())
+			Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+		})
+
+		It("deletes the peering attachment", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(transitGatewayClient.DeleteTransitGatewayPeeringAttachmentCallCount()).To(Equal(1))
+			_, payload, _ := transitGatewayClient.DeleteTransitGatewayPeeringAttachmentArgsForCall(0)
+			Expect(*payload.TransitGatewayAttachmentId).To(Equal(peeringAttachmentID))
+		})
+
+		for _, deletionPolicy := range []v1alpha1.DeletionPolicy{v1alpha1.DeletionPolicyRetain, v1alpha1.DeletionPolicyOrphan} {
+			deletionPolicy := deletionPolicy
+
+			When(fmt.Sprintf("the deletion policy is %s", deletionPolicy), func() {
+				BeforeEach(func() {
+					patchedTopology := topology.DeepCopy()
+					patchedTopology.Spec.DeletionPolicy = deletionPolicy
+					Expect(k8sClient.Patch(ctx, patchedTopology, client.MergeFrom(topology))).To(Succeed())
+				})
+
+				It("keeps the peering attachment and its routes", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(transitGatewayClient.DeleteTransitGatewayPeeringAttachmentCallCount()).To(Equal(0))
+					Expect(transitGatewayClient.DeleteTransitGatewayRouteCallCount()).To(Equal(0))
+				})
+			})
+		}
+	})
+})
@@ -5,36 +5,53 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	"github.com/giantswarm/k8smetadata/pkg/annotation"
-
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/metrics"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/tracing"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
 )
 
 const FinalizerResourceShare = "network-topology.finalizers.giantswarm.io/share"
 
+// Reasons of the events recorded on the Cluster by the ShareReconciler.
+const (
+	EventReasonResourceShareCreated        = "ResourceShareCreated"
+	EventReasonResourceShareFailed         = "ResourceShareFailed"
+	EventReasonResourceShareDeleted        = "ResourceShareDeleted"
+	EventReasonResourceShareDeletionFailed = "ResourceShareDeletionFailed"
+	EventReasonResourceShareRetained       = "ResourceShareRetained"
+)
+
 //counterfeiter:generate . RAMClient
 type RAMClient interface {
-	ApplyResourceShare(context.Context, aws.ResourceShare) error
+	ApplyResourceShare(context.Context, aws.ResourceShare) (string, error)
 	DeleteResourceShare(context.Context, string) error
 }
 
 type ShareReconciler struct {
-	ramClient     RAMClient
-	clusterClient ClusterClient
+	ramClient      RAMClient
+	clusterClient  ClusterClient
+	deletionPolicy v1alpha1.DeletionPolicy
+	recorder       record.EventRecorder
 }
 
-func NewShareReconciler(clusterClient ClusterClient, ramClient RAMClient) *ShareReconciler {
+func NewShareReconciler(clusterClient ClusterClient, ramClient RAMClient, deletionPolicy v1alpha1.DeletionPolicy, recorder record.EventRecorder) *ShareReconciler {
 	return &ShareReconciler{
-		ramClient:     ramClient,
-		clusterClient: clusterClient,
+		ramClient:      ramClient,
+		clusterClient:  clusterClient,
+		deletionPolicy: deletionPolicy,
+		recorder:       recorder,
 	}
 }
 
@@ -42,10 +59,17 @@ func (r *ShareReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("share-reconciler").
 		For(&capi.Cluster{}).
+		Owns(&v1alpha1.NetworkTopology{}).
 		Complete(r)
 }
 
-func (r *ShareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ShareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "ShareReconciler.Reconcile", trace.WithAttributes(
+		tracing.ClusterName.String(req.Name),
+		tracing.ClusterNamespace.String(req.Namespace),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	logger := log.FromContext(ctx)
 
 	logger.Info("Reconciling")
@@ -61,20 +85,56 @@ func (r *ShareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if annotations.GetAnnotation(cluster, annotation.NetworkTopologyModeAnnotation) != annotation.NetworkTopologyModeGiantSwarmManaged {
+	topology, err := r.getNetworkTopology(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	spec := getNetworkTopologySpec(cluster, topology)
+	if spec.Mode != v1alpha1.NetworkTopologyModeGiantSwarmManaged {
 		logger.Info("Network topology mode is not set to GiantSwarmManaged, skipping sharing operation")
 		return ctrl.Result{}, nil
 	}
 
 	if !cluster.DeletionTimestamp.IsZero() {
 		logger.Info("Reconciling delete")
-		return r.reconcileDelete(ctx, cluster)
+		return r.reconcileDelete(ctx, cluster, topology, spec)
+	}
+
+	return r.reconcileNormal(ctx, cluster, topology, spec)
+}
+
+// getNetworkTopology returns the NetworkTopology of the cluster or nil if it
+// doesn't exist yet.
+func (r *ShareReconciler) getNetworkTopology(ctx context.Context, cluster *capi.Cluster) (*v1alpha1.NetworkTopology, error) {
+	topology, err := r.clusterClient.GetNetworkTopology(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to get network topology")
+		return nil, err
+	}
+
+	return topology, nil
+}
+
+// getNetworkTopologySpec returns the spec of the NetworkTopology of the
+// cluster, which is the source of truth. Like the NetworkTopologyReconciler,
+// the annotations of the cluster are only used for fields that aren't set yet,
+// e.g. while migrating a cluster from annotations before its NetworkTopology
+// is created.
+func getNetworkTopologySpec(cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) v1alpha1.NetworkTopologySpec {
+	resolved := &v1alpha1.NetworkTopology{}
+	if topology != nil {
+		resolved = topology.DeepCopy()
 	}
+	annotations.ImportNetworkTopology(cluster, resolved)
 
-	return r.reconcileNormal(ctx, cluster)
+	return resolved.Spec
 }
 
-func (r *ShareReconciler) reconcileDelete(ctx context.Context, cluster *capi.Cluster) (ctrl.Result, error) {
+func (r *ShareReconciler) reconcileDelete(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology, spec v1alpha1.NetworkTopologySpec) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	if r.clusterClient.ContainsFinalizer(cluster, FinalizerNetTop) {
@@ -82,28 +142,43 @@ func (r *ShareReconciler) reconcileDelete(ctx context.Context, cluster *capi.Clu
 		return ctrl.Result{}, nil
 	}
 
-	err := r.ramClient.DeleteResourceShare(ctx, getResourceShareName(cluster, "transit-gateway"))
-	if err != nil {
-		logger.Error(err, "failed to apply resource share")
-		return ctrl.Result{}, err
+	deletionPolicy := topology.GetDeletionPolicy(r.deletionPolicy)
+
+	resourceNames := []string{"transit-gateway", "prefix-list"}
+	if !spec.IPv6PrefixList.IsZero() {
+		resourceNames = append(resourceNames, "ipv6-prefix-list")
+	}
+	if deletionPolicy != v1alpha1.DeletionPolicyDelete {
+		logger.Info("Keeping resource shares", "deletionPolicy", deletionPolicy)
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonResourceShareRetained, "Kept RAM resource shares due to the %s deletion policy", deletionPolicy)
+		resourceNames = nil
 	}
 
-	err = r.ramClient.DeleteResourceShare(ctx, getResourceShareName(cluster, "prefix-list"))
-	if err != nil {
-		logger.Error(err, "failed to apply resource share")
-		return ctrl.Result{}, err
+	for _, resourceName := range resourceNames {
+		shareName := getResourceShareName(cluster, resourceName)
+		err := r.ramClient.DeleteResourceShare(ctx, shareName)
+		if err != nil {
+			logger.Error(err, "failed to delete resource share")
+			r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonResourceShareDeletionFailed, "Failed to delete RAM resource share %s: %v", shareName, err)
+			return ctrl.Result{}, err
+		}
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonResourceShareDeleted, "Deleted RAM resource share %s", shareName)
 	}
 
-	err = r.clusterClient.RemoveFinalizer(ctx, cluster, FinalizerResourceShare)
+	err := r.clusterClient.RemoveFinalizer(ctx, cluster, FinalizerResourceShare)
 	if err != nil {
 		logger.Error(err, "failed to remove finalizer")
 		return ctrl.Result{}, err
 	}
 
+	for _, resourceName := range []string{"transit-gateway", "prefix-list", "ipv6-prefix-list"} {
+		metrics.DeleteResourceShared(cluster.Namespace, cluster.Name, resourceName)
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *ShareReconciler) reconcileNormal(ctx context.Context, cluster *capi.Cluster) (ctrl.Result, error) {
+func (r *ShareReconciler) reconcileNormal(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology, spec v1alpha1.NetworkTopologySpec) (ctrl.Result, error) {
 	accountID, err := r.getAccountId(ctx, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -113,12 +188,23 @@ func (r *ShareReconciler) reconcileNormal(ctx context.Context, cluster *capi.Clu
 	// the networktopology reconciler needs to attach the transit gateway
 	// first, before moving on to creating the prefix list. If the transit
 	// gateway isn't shared it won't be visible in the WC's account
-	err = r.shareTransitGateway(ctx, cluster, accountID)
+	transitGatewayShareARN, err := r.shareTransitGateway(ctx, cluster, accountID, spec.TransitGateway)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	err = r.sharePrefixList(ctx, cluster, accountID)
+	prefixListShareARN, err := r.sharePrefixList(ctx, cluster, accountID, spec.PrefixList, "prefix-list")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Dual-stack clusters have a separate IPv6 prefix list
+	ipv6PrefixListShareARN, err := r.sharePrefixList(ctx, cluster, accountID, spec.IPv6PrefixList, "ipv6-prefix-list")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.setResourceShareARNs(ctx, cluster, topology, accountID, transitGatewayShareARN, prefixListShareARN, ipv6PrefixListShareARN)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -126,6 +212,40 @@ func (r *ShareReconciler) reconcileNormal(ctx context.Context, cluster *capi.Clu
 	return ctrl.Result{}, nil
 }
 
+// setResourceShareARNs records the ARNs of the resource shares on the
+// NetworkTopology of the cluster, if there is one. Resource shares that weren't
+// recorded before are reported with an event.
+func (r *ShareReconciler) setResourceShareARNs(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology, accountID string, shareARNs ...string) error {
+	logger := log.FromContext(ctx)
+
+	if topology == nil {
+		logger.Info("network topology does not exist yet, skipping recording resource share arns")
+		return nil
+	}
+
+	resourceShareARNs := []string{}
+	for _, shareARN := range shareARNs {
+		if shareARN == "" {
+			continue
+		}
+		resourceShareARNs = append(resourceShareARNs, shareARN)
+
+		if !containsString(topology.Status.ResourceShareARNs, shareARN) {
+			r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonResourceShareCreated, "Shared resources with account %s through RAM resource share %s", accountID, shareARN)
+		}
+	}
+
+	baseTopology := topology.DeepCopy()
+	topology.Status.ResourceShareARNs = resourceShareARNs
+	err := r.clusterClient.PatchNetworkTopology(ctx, topology, baseTopology)
+	if err != nil {
+		logger.Error(err, "failed to record resource share arns")
+		return err
+	}
+
+	return nil
+}
+
 func (r *ShareReconciler) getAccountId(ctx context.Context, cluster *capi.Cluster) (string, error) {
 	logger := log.FromContext(ctx)
 	awsCluster := types.NamespacedName{
@@ -152,36 +272,48 @@ func getResourceShareName(cluster *capi.Cluster, resourceName string) string {
 	return fmt.Sprintf("%s-%s", cluster.Name, resourceName)
 }
 
-func (r *ShareReconciler) shareTransitGateway(ctx context.Context, cluster *capi.Cluster, accountID string) error {
+// resourceARN returns the ARN of the referenced resource. The operator writes the
+// ARN back once it knows the resource, references with only an ID aren't
+// resolved yet.
+func resourceARN(ref *v1alpha1.AWSResourceReference) string {
+	if ref == nil {
+		return ""
+	}
+	return ref.ARN
+}
+
+func (r *ShareReconciler) shareTransitGateway(ctx context.Context, cluster *capi.Cluster, accountID string, transitGateway *v1alpha1.AWSResourceReference) (string, error) {
 	logger := log.FromContext(ctx)
-	transitGatewayAnnotation := annotations.GetNetworkTopologyTransitGateway(cluster)
+	transitGatewayRef := resourceARN(transitGateway)
 
-	if transitGatewayAnnotation == "" {
-		logger.Info("transit gateway arn annotation not set yet")
-		return nil
+	if transitGatewayRef == "" {
+		logger.Info("transit gateway arn not set yet")
+		return "", nil
 	}
 
-	logger = logger.WithValues("Annotation", transitGatewayAnnotation)
+	logger = logger.WithValues("TransitGateway", transitGatewayRef)
 
-	transitGatewayARN, err := arn.Parse(transitGatewayAnnotation)
+	transitGatewayARN, err := arn.Parse(transitGatewayRef)
 	if err != nil {
 		logger.Error(err, "failed to parse transit gateway arn")
-		return err
+		return "", err
 	}
 
 	if accountID == transitGatewayARN.AccountID {
 		logger.Info("transit gateway in same account as cluster, there is no need to share it using ram. Skipping")
-		return nil
+		metrics.SetResourceShared(cluster.Namespace, cluster.Name, "transit-gateway", false)
+		return "", nil
 	}
 
 	err = r.clusterClient.AddFinalizer(ctx, cluster, FinalizerResourceShare)
 	if err != nil {
 		logger.Error(err, "failed to add finalizer")
-		return err
+		return "", err
 	}
 
-	err = r.ramClient.ApplyResourceShare(ctx, aws.ResourceShare{
-		Name: getResourceShareName(cluster, "transit-gateway"),
+	shareName := getResourceShareName(cluster, "transit-gateway")
+	shareARN, err := r.ramClient.ApplyResourceShare(ctx, aws.ResourceShare{
+		Name: shareName,
 		ResourceArns: []string{
 			transitGatewayARN.String(),
 		},
@@ -189,35 +321,40 @@ func (r *ShareReconciler) shareTransitGateway(ctx context.Context, cluster *capi
 	})
 	if err != nil {
 		logger.Error(err, "failed to apply resource share")
-		return err
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonResourceShareFailed, "Failed to share transit gateway %s with account %s through RAM resource share %s: %v", transitGatewayARN.String(), accountID, shareName, err)
+		return "", err
 	}
+	metrics.SetResourceShared(cluster.Namespace, cluster.Name, "transit-gateway", true)
 
-	return nil
+	return shareARN, nil
 }
 
-func (r *ShareReconciler) sharePrefixList(ctx context.Context, cluster *capi.Cluster, accountID string) error {
+func (r *ShareReconciler) sharePrefixList(ctx context.Context, cluster *capi.Cluster, accountID string, prefixList *v1alpha1.AWSResourceReference, resourceName string) (string, error) {
 	logger := log.FromContext(ctx)
-	prefixListAnnotation := annotations.GetNetworkTopologyPrefixList(cluster)
-	if prefixListAnnotation == "" {
-		logger.Info("prefix list arn annotation not set yet")
-		return nil
+	prefixListRef := resourceARN(prefixList)
+
+	if prefixListRef == "" {
+		logger.Info("prefix list arn not set yet", "resource", resourceName)
+		return "", nil
 	}
 
-	logger = logger.WithValues("Annotation", prefixListAnnotation)
+	logger = logger.WithValues("PrefixList", prefixListRef)
 
-	prefixListARN, err := arn.Parse(prefixListAnnotation)
+	prefixListARN, err := arn.Parse(prefixListRef)
 	if err != nil {
-		logger.Error(err, "failed to parse prefix list arn", "Annotation", prefixListAnnotation)
-		return err
+		logger.Error(err, "failed to parse prefix list arn")
+		return "", err
 	}
 
 	if accountID == prefixListARN.AccountID {
 		logger.Info("prefix list in same account as cluster, there is no need to share it using ram. Skipping")
-		return nil
+		metrics.SetResourceShared(cluster.Namespace, cluster.Name, resourceName, false)
+		return "", nil
 	}
 
-	err = r.ramClient.ApplyResourceShare(ctx, aws.ResourceShare{
-		Name: getResourceShareName(cluster, "prefix-list"),
+	shareName := getResourceShareName(cluster, resourceName)
+	shareARN, err := r.ramClient.ApplyResourceShare(ctx, aws.ResourceShare{
+		Name: shareName,
 		ResourceArns: []string{
 			prefixListARN.String(),
 		},
@@ -225,8 +362,19 @@ func (r *ShareReconciler) sharePrefixList(ctx context.Context, cluster *capi.Clu
 	})
 	if err != nil {
 		logger.Error(err, "failed to apply resource share")
-		return err
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonResourceShareFailed, "Failed to share prefix list %s with account %s through RAM resource share %s: %v", prefixListARN.String(), accountID, shareName, err)
+		return "", err
 	}
+	metrics.SetResourceShared(cluster.Namespace, cluster.Name, resourceName, true)
 
-	return nil
+	return shareARN, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
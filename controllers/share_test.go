@@ -12,15 +12,18 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
 	"github.com/giantswarm/aws-network-topology-operator/controllers"
 	"github.com/giantswarm/aws-network-topology-operator/controllers/controllersfakes"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
 	"github.com/giantswarm/aws-network-topology-operator/tests"
 )
 
@@ -32,6 +35,7 @@ var _ = Describe("Share", func() {
 		sourceAccountID   = "123456789012"
 		transitGatewayARN = fmt.Sprintf("arn:aws:ec2:eu-west-2:%s:transit-gateway/tgw-01234567890abcdef", sourceAccountID)
 		prefixListARN     = fmt.Sprintf("arn:aws:ec2:eu-west-2:%s:prefix-list/pl-01234567890abcdef", sourceAccountID)
+		ipv6PrefixListARN = fmt.Sprintf("arn:aws:ec2:eu-west-2:%s:prefix-list/pl-0fedcba9876543210", sourceAccountID)
 		externalAccountID = "987654321098"
 		notValidArn       = "not:a:valid/arn"
 
@@ -41,9 +45,21 @@ var _ = Describe("Share", func() {
 		request         ctrl.Request
 
 		ramClient  *controllersfakes.FakeRAMClient
+		recorder   *record.FakeRecorder
 		reconciler *controllers.ShareReconciler
 	)
 
+	createNetworkTopology := func(spec v1alpha1.NetworkTopologySpec) {
+		topology := &v1alpha1.NetworkTopology{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: spec,
+		}
+		Expect(k8sClient.Create(ctx, topology)).To(Succeed())
+	}
+
 	BeforeEach(func() {
 		ctx = context.Background()
 
@@ -105,9 +121,12 @@ var _ = Describe("Share", func() {
 		}
 
 		ramClient = new(controllersfakes.FakeRAMClient)
+		recorder = record.NewFakeRecorder(100)
 		reconciler = controllers.NewShareReconciler(
 			k8sclient.NewCluster(k8sClient, types.NamespacedName{}),
 			ramClient,
+			v1alpha1.DeletionPolicyDelete,
+			recorder,
 		)
 	})
 
@@ -128,6 +147,82 @@ var _ = Describe("Share", func() {
 		Expect(resourceShare.ExternalAccountID).To(Equal(externalAccountID))
 	})
 
+	When("the cluster has a NetworkTopology", func() {
+		var otherTransitGatewayARN string
+
+		BeforeEach(func() {
+			otherTransitGatewayARN = fmt.Sprintf("arn:aws:ec2:eu-west-2:%s:transit-gateway/tgw-0fedcba9876543210", sourceAccountID)
+			createNetworkTopology(v1alpha1.NetworkTopologySpec{
+				Mode:           v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+				TransitGateway: &v1alpha1.AWSResourceReference{ARN: otherTransitGatewayARN},
+				PrefixList:     &v1alpha1.AWSResourceReference{ARN: prefixListARN},
+			})
+
+			ramClient.ApplyResourceShareReturnsOnCall(0, "transit-gateway-share-arn", nil)
+			ramClient.ApplyResourceShareReturnsOnCall(1, "prefix-list-share-arn", nil)
+		})
+
+		It("shares the resources of the NetworkTopology instead of the annotations", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ramClient.ApplyResourceShareCallCount()).To(Equal(2))
+			_, resourceShare := ramClient.ApplyResourceShareArgsForCall(0)
+			Expect(resourceShare.ResourceArns).To(ConsistOf(otherTransitGatewayARN))
+		})
+
+		It("records the resource share arns", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			topology := &v1alpha1.NetworkTopology{}
+			Expect(k8sClient.Get(ctx, request.NamespacedName, topology)).To(Succeed())
+			Expect(topology.Status.ResourceShareARNs).To(ConsistOf("transit-gateway-share-arn", "prefix-list-share-arn"))
+		})
+
+		It("records an event for every new resource share", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(recorder.Events).To(Receive(Equal(fmt.Sprintf("Normal ResourceShareCreated Shared resources with account %s through RAM resource share transit-gateway-share-arn", externalAccountID))))
+			Expect(recorder.Events).To(Receive(Equal(fmt.Sprintf("Normal ResourceShareCreated Shared resources with account %s through RAM resource share prefix-list-share-arn", externalAccountID))))
+		})
+	})
+
+	When("the mode of the NetworkTopology isn't GiantSwarmManaged", func() {
+		BeforeEach(func() {
+			createNetworkTopology(v1alpha1.NetworkTopologySpec{
+				Mode: v1alpha1.NetworkTopologyModeNone,
+			})
+		})
+
+		It("ignores the mode annotation", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ramClient.ApplyResourceShareCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the NetworkTopology only knows the ID of the transit gateway", func() {
+		BeforeEach(func() {
+			createNetworkTopology(v1alpha1.NetworkTopologySpec{
+				Mode:           v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+				TransitGateway: &v1alpha1.AWSResourceReference{ID: "tgw-01234567890abcdef"},
+				PrefixList:     &v1alpha1.AWSResourceReference{ARN: prefixListARN},
+			})
+		})
+
+		It("waits for the ARN before sharing the transit gateway", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ramClient.ApplyResourceShareCallCount()).To(Equal(1))
+			_, resourceShare := ramClient.ApplyResourceShareArgsForCall(0)
+			Expect(resourceShare.ResourceArns).To(ConsistOf(prefixListARN))
+		})
+	})
+
 	It("adds a finalizer", func() {
 		result, err := reconciler.Reconcile(ctx, request)
 		Expect(result.Requeue).To(BeFalse())
@@ -146,7 +241,7 @@ var _ = Describe("Share", func() {
 			fakeClusterClient.GetReturns(cluster, nil)
 			fakeClusterClient.GetAWSClusterRoleIdentityReturns(clusterIdentity, nil)
 			fakeClusterClient.AddFinalizerReturns(errors.New("boom"))
-			reconciler = controllers.NewShareReconciler(fakeClusterClient, ramClient)
+			reconciler = controllers.NewShareReconciler(fakeClusterClient, ramClient, v1alpha1.DeletionPolicyDelete, record.NewFakeRecorder(100))
 		})
 
 		It("returns an error", func() {
@@ -155,6 +250,25 @@ var _ = Describe("Share", func() {
 		})
 	})
 
+	When("the cluster has an IPv6 prefix list", func() {
+		BeforeEach(func() {
+			patchedCluster := cluster.DeepCopy()
+			patchedCluster.Annotations[annotations.NetworkTopologyIPv6PrefixListAnnotation] = ipv6PrefixListARN
+			Expect(k8sClient.Patch(ctx, patchedCluster, client.MergeFrom(cluster))).To(Succeed())
+		})
+
+		It("shares the IPv6 prefix list", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ramClient.ApplyResourceShareCallCount()).To(Equal(3))
+			_, resourceShare := ramClient.ApplyResourceShareArgsForCall(2)
+			Expect(resourceShare.Name).To(Equal(fmt.Sprintf("%s-ipv6-prefix-list", name)))
+			Expect(resourceShare.ResourceArns).To(ConsistOf(ipv6PrefixListARN))
+			Expect(resourceShare.ExternalAccountID).To(Equal(externalAccountID))
+		})
+	})
+
 	When("the cluster has been deleted", func() {
 		BeforeEach(func() {
 			patchedCluster := cluster.DeepCopy()
@@ -178,6 +292,9 @@ var _ = Describe("Share", func() {
 			Expect(actualNameTransitGateway).To(Equal(fmt.Sprintf("%s-transit-gateway", name)))
 			_, actualNamePrefixList := ramClient.DeleteResourceShareArgsForCall(1)
 			Expect(actualNamePrefixList).To(Equal(fmt.Sprintf("%s-prefix-list", name)))
+
+			Expect(recorder.Events).To(Receive(Equal(fmt.Sprintf("Normal ResourceShareDeleted Deleted RAM resource share %s-transit-gateway", name))))
+			Expect(recorder.Events).To(Receive(Equal(fmt.Sprintf("Normal ResourceShareDeleted Deleted RAM resource share %s-prefix-list", name))))
 		})
 
 		It("removes the finalizer", func() {
@@ -213,7 +330,7 @@ var _ = Describe("Share", func() {
 				fakeClusterClient := new(controllersfakes.FakeClusterClient)
 				fakeClusterClient.GetReturns(cluster, nil)
 				fakeClusterClient.RemoveFinalizerReturns(errors.New("boom"))
-				reconciler = controllers.NewShareReconciler(fakeClusterClient, ramClient)
+				reconciler = controllers.NewShareReconciler(fakeClusterClient, ramClient, v1alpha1.DeletionPolicyDelete, record.NewFakeRecorder(100))
 			})
 
 			It("returns an error", func() {
@@ -222,6 +339,44 @@ var _ = Describe("Share", func() {
 			})
 		})
 
+		When("the NetworkTopology has the Retain deletion policy", func() {
+			BeforeEach(func() {
+				createNetworkTopology(v1alpha1.NetworkTopologySpec{
+					Mode:           v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+					DeletionPolicy: v1alpha1.DeletionPolicyRetain,
+				})
+			})
+
+			It("keeps the resource shares", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(ramClient.DeleteResourceShareCallCount()).To(Equal(0))
+				Expect(recorder.Events).To(Receive(Equal("Normal ResourceShareRetained Kept RAM resource shares due to the Retain deletion policy")))
+
+				err = k8sClient.Get(ctx, k8sclient.GetNamespacedName(cluster), &capi.Cluster{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("the default deletion policy is Orphan", func() {
+			BeforeEach(func() {
+				reconciler = controllers.NewShareReconciler(
+					k8sclient.NewCluster(k8sClient, types.NamespacedName{}),
+					ramClient,
+					v1alpha1.DeletionPolicyOrphan,
+					recorder,
+				)
+			})
+
+			It("keeps the resource shares", func() {
+				_, err := reconciler.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(ramClient.DeleteResourceShareCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the cluster still has the networktopology finalizer", func() {
 			BeforeEach(func() {
 				patchedCluster := cluster.DeepCopy()
@@ -298,7 +453,7 @@ var _ = Describe("Share", func() {
 
 	When("applying the resource share fails", func() {
 		BeforeEach(func() {
-			ramClient.ApplyResourceShareReturns(errors.New("boom"))
+			ramClient.ApplyResourceShareReturns("", errors.New("boom"))
 		})
 
 		It("returns an error", func() {
@@ -326,7 +481,7 @@ var _ = Describe("Share", func() {
 		BeforeEach(func() {
 			fakeClusterClient := new(controllersfakes.FakeClusterClient)
 			fakeClusterClient.GetReturns(nil, errors.New("boom"))
-			reconciler = controllers.NewShareReconciler(fakeClusterClient, ramClient)
+			reconciler = controllers.NewShareReconciler(fakeClusterClient, ramClient, v1alpha1.DeletionPolicyDelete, record.NewFakeRecorder(100))
 		})
 
 		It("returns an error", func() {
@@ -335,12 +490,12 @@ var _ = Describe("Share", func() {
 		})
 	})
 
-	When("the transit gateway arn annotation is invalid", func() {
+	When("the transit gateway arn is invalid", func() {
 		BeforeEach(func() {
-			patchedCluster := cluster.DeepCopy()
-			patchedCluster.Annotations[gsannotation.NetworkTopologyTransitGatewayIDAnnotation] = notValidArn
-			err := k8sClient.Patch(context.Background(), patchedCluster, client.MergeFrom(cluster))
-			Expect(err).NotTo(HaveOccurred())
+			createNetworkTopology(v1alpha1.NetworkTopologySpec{
+				Mode:           v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+				TransitGateway: &v1alpha1.AWSResourceReference{ARN: notValidArn},
+			})
 		})
 
 		It("returns an error", func() {
@@ -349,12 +504,12 @@ var _ = Describe("Share", func() {
 		})
 	})
 
-	When("the prefix list arn annotation is invalid", func() {
+	When("the prefix list arn is invalid", func() {
 		BeforeEach(func() {
-			patchedCluster := cluster.DeepCopy()
-			patchedCluster.Annotations[gsannotation.NetworkTopologyPrefixListIDAnnotation] = notValidArn
-			err := k8sClient.Patch(context.Background(), patchedCluster, client.MergeFrom(cluster))
-			Expect(err).NotTo(HaveOccurred())
+			createNetworkTopology(v1alpha1.NetworkTopologySpec{
+				Mode:       v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+				PrefixList: &v1alpha1.AWSResourceReference{ARN: notValidArn},
+			})
 		})
 
 		It("returns an error", func() {
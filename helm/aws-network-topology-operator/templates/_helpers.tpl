@@ -53,6 +53,10 @@ room for such suffix.
 {{- include "resource.default.name" . -}}-network-policy
 {{- end -}}
 
+{{- define "resource.webhook.name" -}}
+{{- include "resource.default.name" . -}}-webhook
+{{- end -}}
+
 {{- define "resource.psp.name" -}}
 {{- include "resource.default.name" . -}}-psp
 {{- end -}}
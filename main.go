@@ -17,10 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -28,8 +30,6 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
-	"github.com/aws/aws-sdk-go/aws/session"
-	gocache "github.com/patrickmn/go-cache"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -37,14 +37,17 @@ import (
 	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
-	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
 	"github.com/giantswarm/aws-network-topology-operator/controllers"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/callback"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/tracing"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/webhooks"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -57,6 +60,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(capi.AddToScheme(scheme))
 	utilruntime.Must(capa.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 }
@@ -68,6 +72,16 @@ func main() {
 	var managementClusterName string
 	var managementClusterNamespace string
 	var snsTopic string
+	var enableWebhooks bool
+	var prefixListMaxEntriesCeiling int
+	var attachmentRecreationLimit int
+	var tracingOptions tracing.Options
+	var endpointOptions aws.EndpointOptions
+	var staticIdentitySecretNamespace string
+	var callbackBindAddress string
+	var callbackSecretFile string
+	deletionPolicy := v1alpha1.DeletionPolicyDelete
+	notificationOptions := registrar.NotificationOptions{Attributes: map[string]string{}}
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -77,6 +91,37 @@ func main() {
 	flag.StringVar(&managementClusterName, "management-cluster-name", "", "The name of the Cluster CR for the management cluster")
 	flag.StringVar(&managementClusterNamespace, "management-cluster-namespace", "", "The namespace of the Cluster CR for the management cluster")
 	flag.StringVar(&snsTopic, "sns-topic", "", "The SNS topic to send TGW attatchment requests to when running in UserManaged mode")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Enable the validating webhook for the network topology annotations of Cluster resources")
+	flag.IntVar(&prefixListMaxEntriesCeiling, "prefix-list-max-entries-ceiling", registrar.PREFIX_LIST_MAX_ENTRIES_CEILING,
+		"The maximum number of entries the prefix list is grown to. The maximum number of entries counts against the route quota of every route table referencing the prefix list.")
+	flag.IntVar(&attachmentRecreationLimit, "attachment-recreation-limit", registrar.DefaultAttachmentRecreationLimit,
+		"The number of times a failed or deleted TGW attachment is recreated before the cluster is marked as failed.")
+	flag.Func("deletion-policy", "The policy for the TGW, prefix lists and RAM shares of a deleted cluster unless set in its NetworkTopology. One of Delete, Retain or Orphan (default Delete).", func(value string) error {
+		switch policy := v1alpha1.DeletionPolicy(value); policy {
+		case v1alpha1.DeletionPolicyDelete, v1alpha1.DeletionPolicyRetain, v1alpha1.DeletionPolicyOrphan:
+			deletionPolicy = policy
+			return nil
+		}
+		return fmt.Errorf("expected Delete, Retain or Orphan, got %q", value)
+	})
+	flag.StringVar(&tracingOptions.Endpoint, "tracing-otlp-endpoint", "", "The URL of the OTLP/HTTP receiver traces are exported to, e.g. http://otel-collector:4318. Tracing is disabled if empty.")
+	flag.Float64Var(&tracingOptions.SamplingRatio, "tracing-sampling-ratio", 1.0, "The ratio of reconciliations that are traced.")
+	flag.Func("aws-endpoint", "The URL replacing the endpoints of all AWS services, or service=URL replacing the endpoint of a single service, e.g. ec2=https://vpce-1234.ec2.{region}.vpce.amazonaws.com. {region} is replaced with the region of the client. Can be repeated. The default endpoints are used if not set.", endpointOptions.AddEndpoint)
+	flag.BoolVar(&endpointOptions.UseFIPS, "aws-use-fips-endpoint", false, "Use the FIPS endpoints of the AWS services.")
+	flag.StringVar(&staticIdentitySecretNamespace, "static-identity-secret-namespace", "giantswarm", "The namespace of the secrets referenced by AWSClusterStaticIdentities, i.e. the namespace of CAPA.")
+	flag.StringVar(&notificationOptions.ContactAddress, "sns-contact-address", registrar.DefaultContactAddress, "The contact address sent with TGW attachment requests when running in UserManaged mode")
+	flag.Func("sns-message-attribute", "An additional SNS message attribute key=value sent with TGW attachment requests when running in UserManaged mode. Can be repeated.", func(value string) error {
+		key, attribute, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("expected key=value, got %q", value)
+		}
+		notificationOptions.Attributes[key] = attribute
+		return nil
+	})
+	flag.StringVar(&notificationOptions.CallbackURL, "sns-callback-url", "", "The URL sent with TGW attachment requests to report the acceptance of attachments to when running in UserManaged mode")
+	flag.DurationVar(&notificationOptions.ReminderInterval, "sns-reminder-interval", 24*time.Hour, "The interval TGW attachment requests are sent again at while the attachment is pending acceptance when running in UserManaged mode. Reminders are disabled if 0.")
+	flag.StringVar(&callbackBindAddress, "callback-bind-address", "", "The address the approval callback for TGW attachment requests binds to, e.g. :8082. The callback is disabled if empty.")
+	flag.StringVar(&callbackSecretFile, "callback-hmac-secret-file", "", "The file containing the shared secret approval callback requests are signed with.")
 	opts := zap.Options{
 		Development: true,
 		TimeEncoder: zapcore.RFC3339TimeEncoder,
@@ -91,6 +136,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLog.Error(err, "failed to flush traces")
+		}
+	}()
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -104,67 +160,94 @@ func main() {
 		os.Exit(1)
 	}
 
-	ctx := context.TODO()
-
 	managementCluster := types.NamespacedName{
 		Name:      managementClusterName,
 		Namespace: managementClusterNamespace,
 	}
 	client := k8sclient.NewCluster(mgr.GetClient(), managementCluster)
-	// Since share reconciler should work on MC account, region from credentials can be directly used.
-	session := session.Must(session.NewSession())
+	// The API reader isn't backed by the cache of the manager, so identities
+	// can be read before the manager is started and secrets aren't cached
+	credentialsFactory := aws.NewCredentialsFactory(mgr.GetAPIReader(), staticIdentitySecretNamespace, endpointOptions)
 
-	ec2Service := aws.NewEC2Client(ctx, client, managementCluster)
-	snsService := aws.NewSNSClient(ctx, snsTopic, client, managementCluster)
+	// Cache credentials and clients to avoid lots of credential requests due to client recreation
+	clientProvider := aws.NewClientProvider(credentialsFactory, aws.DefaultClusterTTL)
 
-	identity, err := getAwsClusterRoleIdentity(managementCluster)
-	if err != nil {
-		setupLog.Error(err, "unable to get AWS Cluster Role Identity for management cluster")
-		os.Exit(1)
-	}
-	ramService := aws.NewRAMClient(aws.AwsRamClientFromARN(session, identity.Spec.RoleArn, identity.Spec.ExternalID))
+	ec2Service := aws.NewEC2Client(clientProvider, managementCluster)
+	snsService := aws.NewSNSClient(snsTopic, clientProvider, managementCluster)
+
+	// Resource shares are managed in the account of the management cluster
+	ramService := aws.NewClusterRAMClient(clientProvider, managementCluster)
 
-	// Cache EC2 clients to avoid lots of credential requests due to client recreation
-	expiration := 5 * time.Minute
-	transitGatewayClientForWorkloadClusterEC2ClientCache := gocache.New(expiration, expiration/2)
 	getTransitGatewayClientForWorkloadCluster := func(workloadCluster types.NamespacedName) aws.TransitGatewayClient {
-		v, ok := transitGatewayClientForWorkloadClusterEC2ClientCache.Get(workloadCluster.String())
-		var ec2ServiceWorkloadCluster *aws.EC2Client
-		if ok {
-			ec2ServiceWorkloadCluster = v.(*aws.EC2Client)
-		} else {
-			ec2ServiceWorkloadCluster = aws.NewEC2Client(
-				ctx,
-
-				// k8s client points to management cluster since that has the `AWSCluster` object which
-				// in turn references `AWSClusterRoleIdentity` to determine the AWS account of the
-				// workload cluster
-				client,
-
-				workloadCluster)
-
-			transitGatewayClientForWorkloadClusterEC2ClientCache.SetDefault(workloadCluster.String(), ec2ServiceWorkloadCluster)
-		}
+		// The client provider reads from the management cluster since that has the
+		// `AWSCluster` object which in turn references the CAPA identity determining the
+		// AWS account of the workload cluster
+		ec2ServiceWorkloadCluster := aws.NewEC2Client(clientProvider, workloadCluster)
 
 		return aws.NewTGWClient(*ec2ServiceWorkloadCluster, *snsService)
 	}
 
 	registrars := []controllers.Registrar{
-		registrar.NewTransitGateway(aws.NewTGWClient(*ec2Service, *snsService), client, getTransitGatewayClientForWorkloadCluster),
+		registrar.NewTransitGateway(aws.NewTGWClient(*ec2Service, *snsService), client, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{
+			PrefixListMaxEntriesCeiling: int32(prefixListMaxEntriesCeiling),
+			AttachmentRecreationLimit:   int32(attachmentRecreationLimit),
+			DeletionPolicy:              deletionPolicy,
+			ResourceShareClient:         ramService,
+			Notification:                notificationOptions,
+		}, mgr.GetEventRecorderFor("aws-network-topology-operator")),
+		registrar.NewTransitGatewayPeering(aws.NewTGWClient(*ec2Service, *snsService), client, deletionPolicy),
 	}
-	controller := controllers.NewNetworkTopologyReconciler(client, registrars)
+	controller := controllers.NewNetworkTopologyReconciler(client, registrars, mgr.GetEventRecorderFor("aws-network-topology-operator"))
 	err = controller.SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "failed to setup controller", "controller", "Cluster")
 		os.Exit(1)
 	}
-	shareController := controllers.NewShareReconciler(client, ramService)
+	identityController := controllers.NewIdentityReconciler(clientProvider)
+	err = identityController.SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "failed to setup controller", "controller", "Identity")
+		os.Exit(1)
+	}
+	shareController := controllers.NewShareReconciler(client, ramService, deletionPolicy, mgr.GetEventRecorderFor("share-reconciler"))
 	err = shareController.SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "failed to setup controller", "controller", "Share")
 		os.Exit(1)
 	}
 
+	if enableWebhooks {
+		err = webhooks.NewClusterValidator(mgr.GetClient()).SetupWithManager(mgr)
+		if err != nil {
+			setupLog.Error(err, "failed to setup webhook", "webhook", "Cluster")
+			os.Exit(1)
+		}
+		err = webhooks.NewNetworkTopologyValidator().SetupWithManager(mgr)
+		if err != nil {
+			setupLog.Error(err, "failed to setup webhook", "webhook", "NetworkTopology")
+			os.Exit(1)
+		}
+	}
+
+	if callbackBindAddress != "" {
+		secret, err := os.ReadFile(callbackSecretFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read approval callback secret")
+			os.Exit(1)
+		}
+		secret = bytes.TrimSpace(secret)
+		if len(secret) == 0 {
+			setupLog.Error(fmt.Errorf("callback-hmac-secret-file %q is empty", callbackSecretFile), "Approval callback secret required")
+			os.Exit(1)
+		}
+
+		err = mgr.Add(callback.NewServer(callbackBindAddress, callback.NewHandler(mgr.GetClient(), secret)))
+		if err != nil {
+			setupLog.Error(err, "failed to setup approval callback")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -179,32 +262,7 @@ func main() {
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
+		_ = shutdownTracing(context.Background())
 		os.Exit(1)
 	}
 }
-
-func getAwsClusterRoleIdentity(managementCluster types.NamespacedName) (*capa.AWSClusterRoleIdentity, error) {
-	ctx := context.Background()
-	identity := &capa.AWSClusterRoleIdentity{}
-	cluster := &capa.AWSCluster{}
-
-	clientConfig, err := ctrl.GetConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	ctrlClient, err := ctrlclient.New(clientConfig, ctrlclient.Options{Scheme: scheme})
-	if err != nil {
-		return nil, err
-	}
-
-	if err := ctrlClient.Get(ctx, managementCluster, cluster); err != nil {
-		return nil, err
-	}
-
-	err = ctrlClient.Get(ctx, types.NamespacedName{Name: cluster.Spec.IdentityRef.Name}, identity)
-	if err != nil {
-		return nil, err
-	}
-	return identity, nil
-}
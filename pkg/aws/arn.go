@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/pkg/errors"
 )
 
 func GetARNResourceID(resourceARN string) (string, error) {
@@ -14,5 +15,8 @@ func GetARNResourceID(resourceARN string) (string, error) {
 
 	// The ARN struct holds the resource in the format "<resource-type>/<resource-name>"
 	resourceSplit := strings.Split(gatewayARN.Resource, "/")
+	if len(resourceSplit) != 2 || resourceSplit[1] == "" {
+		return "", errors.Errorf("arn: invalid resource %q, expected <resource-type>/<resource-name>", gatewayARN.Resource)
+	}
 	return resourceSplit[1], nil
 }
@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package awsfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+)
+
+type FakeResourceShareClient struct {
+	DeleteResourceSharesOfStub        func(context.Context, string) ([]string, error)
+	deleteResourceSharesOfMutex       sync.RWMutex
+	deleteResourceSharesOfArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteResourceSharesOfReturns struct {
+		result1 []string
+		result2 error
+	}
+	deleteResourceSharesOfReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResourceShareClient) DeleteResourceSharesOf(arg1 context.Context, arg2 string) ([]string, error) {
+	fake.deleteResourceSharesOfMutex.Lock()
+	ret, specificReturn := fake.deleteResourceSharesOfReturnsOnCall[len(fake.deleteResourceSharesOfArgsForCall)]
+	fake.deleteResourceSharesOfArgsForCall = append(fake.deleteResourceSharesOfArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteResourceSharesOfStub
+	fakeReturns := fake.deleteResourceSharesOfReturns
+	fake.recordInvocation("DeleteResourceSharesOf", []interface{}{arg1, arg2})
+	fake.deleteResourceSharesOfMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceShareClient) DeleteResourceSharesOfCallCount() int {
+	fake.deleteResourceSharesOfMutex.RLock()
+	defer fake.deleteResourceSharesOfMutex.RUnlock()
+	return len(fake.deleteResourceSharesOfArgsForCall)
+}
+
+func (fake *FakeResourceShareClient) DeleteResourceSharesOfCalls(stub func(context.Context, string) ([]string, error)) {
+	fake.deleteResourceSharesOfMutex.Lock()
+	defer fake.deleteResourceSharesOfMutex.Unlock()
+	fake.DeleteResourceSharesOfStub = stub
+}
+
+func (fake *FakeResourceShareClient) DeleteResourceSharesOfArgsForCall(i int) (context.Context, string) {
+	fake.deleteResourceSharesOfMutex.RLock()
+	defer fake.deleteResourceSharesOfMutex.RUnlock()
+	argsForCall := fake.deleteResourceSharesOfArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResourceShareClient) DeleteResourceSharesOfReturns(result1 []string, result2 error) {
+	fake.deleteResourceSharesOfMutex.Lock()
+	defer fake.deleteResourceSharesOfMutex.Unlock()
+	fake.DeleteResourceSharesOfStub = nil
+	fake.deleteResourceSharesOfReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceShareClient) DeleteResourceSharesOfReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.deleteResourceSharesOfMutex.Lock()
+	defer fake.deleteResourceSharesOfMutex.Unlock()
+	fake.DeleteResourceSharesOfStub = nil
+	if fake.deleteResourceSharesOfReturnsOnCall == nil {
+		fake.deleteResourceSharesOfReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.deleteResourceSharesOfReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceShareClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteResourceSharesOfMutex.RLock()
+	defer fake.deleteResourceSharesOfMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeResourceShareClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ aws.ResourceShareClient = new(FakeResourceShareClient)
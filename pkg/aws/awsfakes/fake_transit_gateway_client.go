@@ -7,11 +7,40 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
-
 	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
 )
 
 type FakeTransitGatewayClient struct {
+	AcceptTransitGatewayPeeringAttachmentStub        func(context.Context, *ec2.AcceptTransitGatewayPeeringAttachmentInput, ...func(*ec2.Options)) (*ec2.AcceptTransitGatewayPeeringAttachmentOutput, error)
+	acceptTransitGatewayPeeringAttachmentMutex       sync.RWMutex
+	acceptTransitGatewayPeeringAttachmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.AcceptTransitGatewayPeeringAttachmentInput
+		arg3 []func(*ec2.Options)
+	}
+	acceptTransitGatewayPeeringAttachmentReturns struct {
+		result1 *ec2.AcceptTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}
+	acceptTransitGatewayPeeringAttachmentReturnsOnCall map[int]struct {
+		result1 *ec2.AcceptTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}
+	AssociateTransitGatewayRouteTableStub        func(context.Context, *ec2.AssociateTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.AssociateTransitGatewayRouteTableOutput, error)
+	associateTransitGatewayRouteTableMutex       sync.RWMutex
+	associateTransitGatewayRouteTableArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.AssociateTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}
+	associateTransitGatewayRouteTableReturns struct {
+		result1 *ec2.AssociateTransitGatewayRouteTableOutput
+		result2 error
+	}
+	associateTransitGatewayRouteTableReturnsOnCall map[int]struct {
+		result1 *ec2.AssociateTransitGatewayRouteTableOutput
+		result2 error
+	}
 	CreateManagedPrefixListStub        func(context.Context, *ec2.CreateManagedPrefixListInput, ...func(*ec2.Options)) (*ec2.CreateManagedPrefixListOutput, error)
 	createManagedPrefixListMutex       sync.RWMutex
 	createManagedPrefixListArgsForCall []struct {
@@ -57,6 +86,51 @@ type FakeTransitGatewayClient struct {
 		result1 *ec2.CreateTransitGatewayOutput
 		result2 error
 	}
+	CreateTransitGatewayPeeringAttachmentStub        func(context.Context, *ec2.CreateTransitGatewayPeeringAttachmentInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayPeeringAttachmentOutput, error)
+	createTransitGatewayPeeringAttachmentMutex       sync.RWMutex
+	createTransitGatewayPeeringAttachmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.CreateTransitGatewayPeeringAttachmentInput
+		arg3 []func(*ec2.Options)
+	}
+	createTransitGatewayPeeringAttachmentReturns struct {
+		result1 *ec2.CreateTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}
+	createTransitGatewayPeeringAttachmentReturnsOnCall map[int]struct {
+		result1 *ec2.CreateTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}
+	CreateTransitGatewayRouteStub        func(context.Context, *ec2.CreateTransitGatewayRouteInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteOutput, error)
+	createTransitGatewayRouteMutex       sync.RWMutex
+	createTransitGatewayRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.CreateTransitGatewayRouteInput
+		arg3 []func(*ec2.Options)
+	}
+	createTransitGatewayRouteReturns struct {
+		result1 *ec2.CreateTransitGatewayRouteOutput
+		result2 error
+	}
+	createTransitGatewayRouteReturnsOnCall map[int]struct {
+		result1 *ec2.CreateTransitGatewayRouteOutput
+		result2 error
+	}
+	CreateTransitGatewayRouteTableStub        func(context.Context, *ec2.CreateTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteTableOutput, error)
+	createTransitGatewayRouteTableMutex       sync.RWMutex
+	createTransitGatewayRouteTableArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.CreateTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}
+	createTransitGatewayRouteTableReturns struct {
+		result1 *ec2.CreateTransitGatewayRouteTableOutput
+		result2 error
+	}
+	createTransitGatewayRouteTableReturnsOnCall map[int]struct {
+		result1 *ec2.CreateTransitGatewayRouteTableOutput
+		result2 error
+	}
 	CreateTransitGatewayVpcAttachmentStub        func(context.Context, *ec2.CreateTransitGatewayVpcAttachmentInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error)
 	createTransitGatewayVpcAttachmentMutex       sync.RWMutex
 	createTransitGatewayVpcAttachmentArgsForCall []struct {
@@ -72,6 +146,21 @@ type FakeTransitGatewayClient struct {
 		result1 *ec2.CreateTransitGatewayVpcAttachmentOutput
 		result2 error
 	}
+	DeleteManagedPrefixListStub        func(context.Context, *ec2.DeleteManagedPrefixListInput, ...func(*ec2.Options)) (*ec2.DeleteManagedPrefixListOutput, error)
+	deleteManagedPrefixListMutex       sync.RWMutex
+	deleteManagedPrefixListArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteManagedPrefixListInput
+		arg3 []func(*ec2.Options)
+	}
+	deleteManagedPrefixListReturns struct {
+		result1 *ec2.DeleteManagedPrefixListOutput
+		result2 error
+	}
+	deleteManagedPrefixListReturnsOnCall map[int]struct {
+		result1 *ec2.DeleteManagedPrefixListOutput
+		result2 error
+	}
 	DeleteRouteStub        func(context.Context, *ec2.DeleteRouteInput, ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error)
 	deleteRouteMutex       sync.RWMutex
 	deleteRouteArgsForCall []struct {
@@ -102,6 +191,51 @@ type FakeTransitGatewayClient struct {
 		result1 *ec2.DeleteTransitGatewayOutput
 		result2 error
 	}
+	DeleteTransitGatewayPeeringAttachmentStub        func(context.Context, *ec2.DeleteTransitGatewayPeeringAttachmentInput, ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayPeeringAttachmentOutput, error)
+	deleteTransitGatewayPeeringAttachmentMutex       sync.RWMutex
+	deleteTransitGatewayPeeringAttachmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteTransitGatewayPeeringAttachmentInput
+		arg3 []func(*ec2.Options)
+	}
+	deleteTransitGatewayPeeringAttachmentReturns struct {
+		result1 *ec2.DeleteTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}
+	deleteTransitGatewayPeeringAttachmentReturnsOnCall map[int]struct {
+		result1 *ec2.DeleteTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}
+	DeleteTransitGatewayRouteStub        func(context.Context, *ec2.DeleteTransitGatewayRouteInput, ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteOutput, error)
+	deleteTransitGatewayRouteMutex       sync.RWMutex
+	deleteTransitGatewayRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteTransitGatewayRouteInput
+		arg3 []func(*ec2.Options)
+	}
+	deleteTransitGatewayRouteReturns struct {
+		result1 *ec2.DeleteTransitGatewayRouteOutput
+		result2 error
+	}
+	deleteTransitGatewayRouteReturnsOnCall map[int]struct {
+		result1 *ec2.DeleteTransitGatewayRouteOutput
+		result2 error
+	}
+	DeleteTransitGatewayRouteTableStub        func(context.Context, *ec2.DeleteTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteTableOutput, error)
+	deleteTransitGatewayRouteTableMutex       sync.RWMutex
+	deleteTransitGatewayRouteTableArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}
+	deleteTransitGatewayRouteTableReturns struct {
+		result1 *ec2.DeleteTransitGatewayRouteTableOutput
+		result2 error
+	}
+	deleteTransitGatewayRouteTableReturnsOnCall map[int]struct {
+		result1 *ec2.DeleteTransitGatewayRouteTableOutput
+		result2 error
+	}
 	DeleteTransitGatewayVpcAttachmentStub        func(context.Context, *ec2.DeleteTransitGatewayVpcAttachmentInput, ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayVpcAttachmentOutput, error)
 	deleteTransitGatewayVpcAttachmentMutex       sync.RWMutex
 	deleteTransitGatewayVpcAttachmentArgsForCall []struct {
@@ -162,6 +296,51 @@ type FakeTransitGatewayClient struct {
 		result1 *ec2.DescribeSubnetsOutput
 		result2 error
 	}
+	DescribeTransitGatewayAttachmentsStub        func(context.Context, *ec2.DescribeTransitGatewayAttachmentsInput, ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayAttachmentsOutput, error)
+	describeTransitGatewayAttachmentsMutex       sync.RWMutex
+	describeTransitGatewayAttachmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeTransitGatewayAttachmentsInput
+		arg3 []func(*ec2.Options)
+	}
+	describeTransitGatewayAttachmentsReturns struct {
+		result1 *ec2.DescribeTransitGatewayAttachmentsOutput
+		result2 error
+	}
+	describeTransitGatewayAttachmentsReturnsOnCall map[int]struct {
+		result1 *ec2.DescribeTransitGatewayAttachmentsOutput
+		result2 error
+	}
+	DescribeTransitGatewayPeeringAttachmentsStub        func(context.Context, *ec2.DescribeTransitGatewayPeeringAttachmentsInput, ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error)
+	describeTransitGatewayPeeringAttachmentsMutex       sync.RWMutex
+	describeTransitGatewayPeeringAttachmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeTransitGatewayPeeringAttachmentsInput
+		arg3 []func(*ec2.Options)
+	}
+	describeTransitGatewayPeeringAttachmentsReturns struct {
+		result1 *ec2.DescribeTransitGatewayPeeringAttachmentsOutput
+		result2 error
+	}
+	describeTransitGatewayPeeringAttachmentsReturnsOnCall map[int]struct {
+		result1 *ec2.DescribeTransitGatewayPeeringAttachmentsOutput
+		result2 error
+	}
+	DescribeTransitGatewayRouteTablesStub        func(context.Context, *ec2.DescribeTransitGatewayRouteTablesInput, ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayRouteTablesOutput, error)
+	describeTransitGatewayRouteTablesMutex       sync.RWMutex
+	describeTransitGatewayRouteTablesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeTransitGatewayRouteTablesInput
+		arg3 []func(*ec2.Options)
+	}
+	describeTransitGatewayRouteTablesReturns struct {
+		result1 *ec2.DescribeTransitGatewayRouteTablesOutput
+		result2 error
+	}
+	describeTransitGatewayRouteTablesReturnsOnCall map[int]struct {
+		result1 *ec2.DescribeTransitGatewayRouteTablesOutput
+		result2 error
+	}
 	DescribeTransitGatewayVpcAttachmentsStub        func(context.Context, *ec2.DescribeTransitGatewayVpcAttachmentsInput, ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error)
 	describeTransitGatewayVpcAttachmentsMutex       sync.RWMutex
 	describeTransitGatewayVpcAttachmentsArgsForCall []struct {
@@ -192,6 +371,81 @@ type FakeTransitGatewayClient struct {
 		result1 *ec2.DescribeTransitGatewaysOutput
 		result2 error
 	}
+	DescribeVpcsStub        func(context.Context, *ec2.DescribeVpcsInput, ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error)
+	describeVpcsMutex       sync.RWMutex
+	describeVpcsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeVpcsInput
+		arg3 []func(*ec2.Options)
+	}
+	describeVpcsReturns struct {
+		result1 *ec2.DescribeVpcsOutput
+		result2 error
+	}
+	describeVpcsReturnsOnCall map[int]struct {
+		result1 *ec2.DescribeVpcsOutput
+		result2 error
+	}
+	DisableTransitGatewayRouteTablePropagationStub        func(context.Context, *ec2.DisableTransitGatewayRouteTablePropagationInput, ...func(*ec2.Options)) (*ec2.DisableTransitGatewayRouteTablePropagationOutput, error)
+	disableTransitGatewayRouteTablePropagationMutex       sync.RWMutex
+	disableTransitGatewayRouteTablePropagationArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DisableTransitGatewayRouteTablePropagationInput
+		arg3 []func(*ec2.Options)
+	}
+	disableTransitGatewayRouteTablePropagationReturns struct {
+		result1 *ec2.DisableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}
+	disableTransitGatewayRouteTablePropagationReturnsOnCall map[int]struct {
+		result1 *ec2.DisableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}
+	DisassociateTransitGatewayRouteTableStub        func(context.Context, *ec2.DisassociateTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.DisassociateTransitGatewayRouteTableOutput, error)
+	disassociateTransitGatewayRouteTableMutex       sync.RWMutex
+	disassociateTransitGatewayRouteTableArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.DisassociateTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}
+	disassociateTransitGatewayRouteTableReturns struct {
+		result1 *ec2.DisassociateTransitGatewayRouteTableOutput
+		result2 error
+	}
+	disassociateTransitGatewayRouteTableReturnsOnCall map[int]struct {
+		result1 *ec2.DisassociateTransitGatewayRouteTableOutput
+		result2 error
+	}
+	EnableTransitGatewayRouteTablePropagationStub        func(context.Context, *ec2.EnableTransitGatewayRouteTablePropagationInput, ...func(*ec2.Options)) (*ec2.EnableTransitGatewayRouteTablePropagationOutput, error)
+	enableTransitGatewayRouteTablePropagationMutex       sync.RWMutex
+	enableTransitGatewayRouteTablePropagationArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.EnableTransitGatewayRouteTablePropagationInput
+		arg3 []func(*ec2.Options)
+	}
+	enableTransitGatewayRouteTablePropagationReturns struct {
+		result1 *ec2.EnableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}
+	enableTransitGatewayRouteTablePropagationReturnsOnCall map[int]struct {
+		result1 *ec2.EnableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}
+	GetManagedPrefixListAssociationsStub        func(context.Context, *ec2.GetManagedPrefixListAssociationsInput, ...func(*ec2.Options)) (*ec2.GetManagedPrefixListAssociationsOutput, error)
+	getManagedPrefixListAssociationsMutex       sync.RWMutex
+	getManagedPrefixListAssociationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.GetManagedPrefixListAssociationsInput
+		arg3 []func(*ec2.Options)
+	}
+	getManagedPrefixListAssociationsReturns struct {
+		result1 *ec2.GetManagedPrefixListAssociationsOutput
+		result2 error
+	}
+	getManagedPrefixListAssociationsReturnsOnCall map[int]struct {
+		result1 *ec2.GetManagedPrefixListAssociationsOutput
+		result2 error
+	}
 	GetManagedPrefixListEntriesStub        func(context.Context, *ec2.GetManagedPrefixListEntriesInput, ...func(*ec2.Options)) (*ec2.GetManagedPrefixListEntriesOutput, error)
 	getManagedPrefixListEntriesMutex       sync.RWMutex
 	getManagedPrefixListEntriesArgsForCall []struct {
@@ -207,6 +461,21 @@ type FakeTransitGatewayClient struct {
 		result1 *ec2.GetManagedPrefixListEntriesOutput
 		result2 error
 	}
+	GetTransitGatewayAttachmentPropagationsStub        func(context.Context, *ec2.GetTransitGatewayAttachmentPropagationsInput, ...func(*ec2.Options)) (*ec2.GetTransitGatewayAttachmentPropagationsOutput, error)
+	getTransitGatewayAttachmentPropagationsMutex       sync.RWMutex
+	getTransitGatewayAttachmentPropagationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.GetTransitGatewayAttachmentPropagationsInput
+		arg3 []func(*ec2.Options)
+	}
+	getTransitGatewayAttachmentPropagationsReturns struct {
+		result1 *ec2.GetTransitGatewayAttachmentPropagationsOutput
+		result2 error
+	}
+	getTransitGatewayAttachmentPropagationsReturnsOnCall map[int]struct {
+		result1 *ec2.GetTransitGatewayAttachmentPropagationsOutput
+		result2 error
+	}
 	ModifyManagedPrefixListStub        func(context.Context, *ec2.ModifyManagedPrefixListInput, ...func(*ec2.Options)) (*ec2.ModifyManagedPrefixListOutput, error)
 	modifyManagedPrefixListMutex       sync.RWMutex
 	modifyManagedPrefixListArgsForCall []struct {
@@ -222,6 +491,21 @@ type FakeTransitGatewayClient struct {
 		result1 *ec2.ModifyManagedPrefixListOutput
 		result2 error
 	}
+	ModifyTransitGatewayVpcAttachmentStub        func(context.Context, *ec2.ModifyTransitGatewayVpcAttachmentInput, ...func(*ec2.Options)) (*ec2.ModifyTransitGatewayVpcAttachmentOutput, error)
+	modifyTransitGatewayVpcAttachmentMutex       sync.RWMutex
+	modifyTransitGatewayVpcAttachmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.ModifyTransitGatewayVpcAttachmentInput
+		arg3 []func(*ec2.Options)
+	}
+	modifyTransitGatewayVpcAttachmentReturns struct {
+		result1 *ec2.ModifyTransitGatewayVpcAttachmentOutput
+		result2 error
+	}
+	modifyTransitGatewayVpcAttachmentReturnsOnCall map[int]struct {
+		result1 *ec2.ModifyTransitGatewayVpcAttachmentOutput
+		result2 error
+	}
 	PublishSNSMessageStub        func(context.Context, *sns.PublishInput, ...func(*sns.Options)) (*sns.PublishOutput, error)
 	publishSNSMessageMutex       sync.RWMutex
 	publishSNSMessageArgsForCall []struct {
@@ -237,10 +521,157 @@ type FakeTransitGatewayClient struct {
 		result1 *sns.PublishOutput
 		result2 error
 	}
+	SearchTransitGatewayRoutesStub        func(context.Context, *ec2.SearchTransitGatewayRoutesInput, ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error)
+	searchTransitGatewayRoutesMutex       sync.RWMutex
+	searchTransitGatewayRoutesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *ec2.SearchTransitGatewayRoutesInput
+		arg3 []func(*ec2.Options)
+	}
+	searchTransitGatewayRoutesReturns struct {
+		result1 *ec2.SearchTransitGatewayRoutesOutput
+		result2 error
+	}
+	searchTransitGatewayRoutesReturnsOnCall map[int]struct {
+		result1 *ec2.SearchTransitGatewayRoutesOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTransitGatewayClient) AcceptTransitGatewayPeeringAttachment(arg1 context.Context, arg2 *ec2.AcceptTransitGatewayPeeringAttachmentInput, arg3 ...func(*ec2.Options)) (*ec2.AcceptTransitGatewayPeeringAttachmentOutput, error) {
+	fake.acceptTransitGatewayPeeringAttachmentMutex.Lock()
+	ret, specificReturn := fake.acceptTransitGatewayPeeringAttachmentReturnsOnCall[len(fake.acceptTransitGatewayPeeringAttachmentArgsForCall)]
+	fake.acceptTransitGatewayPeeringAttachmentArgsForCall = append(fake.acceptTransitGatewayPeeringAttachmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.AcceptTransitGatewayPeeringAttachmentInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.AcceptTransitGatewayPeeringAttachmentStub
+	fakeReturns := fake.acceptTransitGatewayPeeringAttachmentReturns
+	fake.recordInvocation("AcceptTransitGatewayPeeringAttachment", []interface{}{arg1, arg2, arg3})
+	fake.acceptTransitGatewayPeeringAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) AcceptTransitGatewayPeeringAttachmentCallCount() int {
+	fake.acceptTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.acceptTransitGatewayPeeringAttachmentMutex.RUnlock()
+	return len(fake.acceptTransitGatewayPeeringAttachmentArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) AcceptTransitGatewayPeeringAttachmentCalls(stub func(context.Context, *ec2.AcceptTransitGatewayPeeringAttachmentInput, ...func(*ec2.Options)) (*ec2.AcceptTransitGatewayPeeringAttachmentOutput, error)) {
+	fake.acceptTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.acceptTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.AcceptTransitGatewayPeeringAttachmentStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) AcceptTransitGatewayPeeringAttachmentArgsForCall(i int) (context.Context, *ec2.AcceptTransitGatewayPeeringAttachmentInput, []func(*ec2.Options)) {
+	fake.acceptTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.acceptTransitGatewayPeeringAttachmentMutex.RUnlock()
+	argsForCall := fake.acceptTransitGatewayPeeringAttachmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) AcceptTransitGatewayPeeringAttachmentReturns(result1 *ec2.AcceptTransitGatewayPeeringAttachmentOutput, result2 error) {
+	fake.acceptTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.acceptTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.AcceptTransitGatewayPeeringAttachmentStub = nil
+	fake.acceptTransitGatewayPeeringAttachmentReturns = struct {
+		result1 *ec2.AcceptTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) AcceptTransitGatewayPeeringAttachmentReturnsOnCall(i int, result1 *ec2.AcceptTransitGatewayPeeringAttachmentOutput, result2 error) {
+	fake.acceptTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.acceptTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.AcceptTransitGatewayPeeringAttachmentStub = nil
+	if fake.acceptTransitGatewayPeeringAttachmentReturnsOnCall == nil {
+		fake.acceptTransitGatewayPeeringAttachmentReturnsOnCall = make(map[int]struct {
+			result1 *ec2.AcceptTransitGatewayPeeringAttachmentOutput
+			result2 error
+		})
+	}
+	fake.acceptTransitGatewayPeeringAttachmentReturnsOnCall[i] = struct {
+		result1 *ec2.AcceptTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) AssociateTransitGatewayRouteTable(arg1 context.Context, arg2 *ec2.AssociateTransitGatewayRouteTableInput, arg3 ...func(*ec2.Options)) (*ec2.AssociateTransitGatewayRouteTableOutput, error) {
+	fake.associateTransitGatewayRouteTableMutex.Lock()
+	ret, specificReturn := fake.associateTransitGatewayRouteTableReturnsOnCall[len(fake.associateTransitGatewayRouteTableArgsForCall)]
+	fake.associateTransitGatewayRouteTableArgsForCall = append(fake.associateTransitGatewayRouteTableArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.AssociateTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.AssociateTransitGatewayRouteTableStub
+	fakeReturns := fake.associateTransitGatewayRouteTableReturns
+	fake.recordInvocation("AssociateTransitGatewayRouteTable", []interface{}{arg1, arg2, arg3})
+	fake.associateTransitGatewayRouteTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) AssociateTransitGatewayRouteTableCallCount() int {
+	fake.associateTransitGatewayRouteTableMutex.RLock()
+	defer fake.associateTransitGatewayRouteTableMutex.RUnlock()
+	return len(fake.associateTransitGatewayRouteTableArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) AssociateTransitGatewayRouteTableCalls(stub func(context.Context, *ec2.AssociateTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.AssociateTransitGatewayRouteTableOutput, error)) {
+	fake.associateTransitGatewayRouteTableMutex.Lock()
+	defer fake.associateTransitGatewayRouteTableMutex.Unlock()
+	fake.AssociateTransitGatewayRouteTableStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) AssociateTransitGatewayRouteTableArgsForCall(i int) (context.Context, *ec2.AssociateTransitGatewayRouteTableInput, []func(*ec2.Options)) {
+	fake.associateTransitGatewayRouteTableMutex.RLock()
+	defer fake.associateTransitGatewayRouteTableMutex.RUnlock()
+	argsForCall := fake.associateTransitGatewayRouteTableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) AssociateTransitGatewayRouteTableReturns(result1 *ec2.AssociateTransitGatewayRouteTableOutput, result2 error) {
+	fake.associateTransitGatewayRouteTableMutex.Lock()
+	defer fake.associateTransitGatewayRouteTableMutex.Unlock()
+	fake.AssociateTransitGatewayRouteTableStub = nil
+	fake.associateTransitGatewayRouteTableReturns = struct {
+		result1 *ec2.AssociateTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) AssociateTransitGatewayRouteTableReturnsOnCall(i int, result1 *ec2.AssociateTransitGatewayRouteTableOutput, result2 error) {
+	fake.associateTransitGatewayRouteTableMutex.Lock()
+	defer fake.associateTransitGatewayRouteTableMutex.Unlock()
+	fake.AssociateTransitGatewayRouteTableStub = nil
+	if fake.associateTransitGatewayRouteTableReturnsOnCall == nil {
+		fake.associateTransitGatewayRouteTableReturnsOnCall = make(map[int]struct {
+			result1 *ec2.AssociateTransitGatewayRouteTableOutput
+			result2 error
+		})
+	}
+	fake.associateTransitGatewayRouteTableReturnsOnCall[i] = struct {
+		result1 *ec2.AssociateTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTransitGatewayClient) CreateManagedPrefixList(arg1 context.Context, arg2 *ec2.CreateManagedPrefixListInput, arg3 ...func(*ec2.Options)) (*ec2.CreateManagedPrefixListOutput, error) {
 	fake.createManagedPrefixListMutex.Lock()
 	ret, specificReturn := fake.createManagedPrefixListReturnsOnCall[len(fake.createManagedPrefixListArgsForCall)]
@@ -439,18 +870,18 @@ func (fake *FakeTransitGatewayClient) CreateTransitGatewayReturnsOnCall(i int, r
 	}{result1, result2}
 }
 
-func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachment(arg1 context.Context, arg2 *ec2.CreateTransitGatewayVpcAttachmentInput, arg3 ...func(*ec2.Options)) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error) {
-	fake.createTransitGatewayVpcAttachmentMutex.Lock()
-	ret, specificReturn := fake.createTransitGatewayVpcAttachmentReturnsOnCall[len(fake.createTransitGatewayVpcAttachmentArgsForCall)]
-	fake.createTransitGatewayVpcAttachmentArgsForCall = append(fake.createTransitGatewayVpcAttachmentArgsForCall, struct {
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayPeeringAttachment(arg1 context.Context, arg2 *ec2.CreateTransitGatewayPeeringAttachmentInput, arg3 ...func(*ec2.Options)) (*ec2.CreateTransitGatewayPeeringAttachmentOutput, error) {
+	fake.createTransitGatewayPeeringAttachmentMutex.Lock()
+	ret, specificReturn := fake.createTransitGatewayPeeringAttachmentReturnsOnCall[len(fake.createTransitGatewayPeeringAttachmentArgsForCall)]
+	fake.createTransitGatewayPeeringAttachmentArgsForCall = append(fake.createTransitGatewayPeeringAttachmentArgsForCall, struct {
 		arg1 context.Context
-		arg2 *ec2.CreateTransitGatewayVpcAttachmentInput
+		arg2 *ec2.CreateTransitGatewayPeeringAttachmentInput
 		arg3 []func(*ec2.Options)
 	}{arg1, arg2, arg3})
-	stub := fake.CreateTransitGatewayVpcAttachmentStub
-	fakeReturns := fake.createTransitGatewayVpcAttachmentReturns
-	fake.recordInvocation("CreateTransitGatewayVpcAttachment", []interface{}{arg1, arg2, arg3})
-	fake.createTransitGatewayVpcAttachmentMutex.Unlock()
+	stub := fake.CreateTransitGatewayPeeringAttachmentStub
+	fakeReturns := fake.createTransitGatewayPeeringAttachmentReturns
+	fake.recordInvocation("CreateTransitGatewayPeeringAttachment", []interface{}{arg1, arg2, arg3})
+	fake.createTransitGatewayPeeringAttachmentMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3...)
 	}
@@ -460,63 +891,63 @@ func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachment(arg1 con
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentCallCount() int {
-	fake.createTransitGatewayVpcAttachmentMutex.RLock()
-	defer fake.createTransitGatewayVpcAttachmentMutex.RUnlock()
-	return len(fake.createTransitGatewayVpcAttachmentArgsForCall)
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayPeeringAttachmentCallCount() int {
+	fake.createTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.createTransitGatewayPeeringAttachmentMutex.RUnlock()
+	return len(fake.createTransitGatewayPeeringAttachmentArgsForCall)
 }
 
-func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentCalls(stub func(context.Context, *ec2.CreateTransitGatewayVpcAttachmentInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error)) {
-	fake.createTransitGatewayVpcAttachmentMutex.Lock()
-	defer fake.createTransitGatewayVpcAttachmentMutex.Unlock()
-	fake.CreateTransitGatewayVpcAttachmentStub = stub
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayPeeringAttachmentCalls(stub func(context.Context, *ec2.CreateTransitGatewayPeeringAttachmentInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayPeeringAttachmentOutput, error)) {
+	fake.createTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.createTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.CreateTransitGatewayPeeringAttachmentStub = stub
 }
 
-func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentArgsForCall(i int) (context.Context, *ec2.CreateTransitGatewayVpcAttachmentInput, []func(*ec2.Options)) {
-	fake.createTransitGatewayVpcAttachmentMutex.RLock()
-	defer fake.createTransitGatewayVpcAttachmentMutex.RUnlock()
-	argsForCall := fake.createTransitGatewayVpcAttachmentArgsForCall[i]
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayPeeringAttachmentArgsForCall(i int) (context.Context, *ec2.CreateTransitGatewayPeeringAttachmentInput, []func(*ec2.Options)) {
+	fake.createTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.createTransitGatewayPeeringAttachmentMutex.RUnlock()
+	argsForCall := fake.createTransitGatewayPeeringAttachmentArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentReturns(result1 *ec2.CreateTransitGatewayVpcAttachmentOutput, result2 error) {
-	fake.createTransitGatewayVpcAttachmentMutex.Lock()
-	defer fake.createTransitGatewayVpcAttachmentMutex.Unlock()
-	fake.CreateTransitGatewayVpcAttachmentStub = nil
-	fake.createTransitGatewayVpcAttachmentReturns = struct {
-		result1 *ec2.CreateTransitGatewayVpcAttachmentOutput
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayPeeringAttachmentReturns(result1 *ec2.CreateTransitGatewayPeeringAttachmentOutput, result2 error) {
+	fake.createTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.createTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.CreateTransitGatewayPeeringAttachmentStub = nil
+	fake.createTransitGatewayPeeringAttachmentReturns = struct {
+		result1 *ec2.CreateTransitGatewayPeeringAttachmentOutput
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentReturnsOnCall(i int, result1 *ec2.CreateTransitGatewayVpcAttachmentOutput, result2 error) {
-	fake.createTransitGatewayVpcAttachmentMutex.Lock()
-	defer fake.createTransitGatewayVpcAttachmentMutex.Unlock()
-	fake.CreateTransitGatewayVpcAttachmentStub = nil
-	if fake.createTransitGatewayVpcAttachmentReturnsOnCall == nil {
-		fake.createTransitGatewayVpcAttachmentReturnsOnCall = make(map[int]struct {
-			result1 *ec2.CreateTransitGatewayVpcAttachmentOutput
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayPeeringAttachmentReturnsOnCall(i int, result1 *ec2.CreateTransitGatewayPeeringAttachmentOutput, result2 error) {
+	fake.createTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.createTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.CreateTransitGatewayPeeringAttachmentStub = nil
+	if fake.createTransitGatewayPeeringAttachmentReturnsOnCall == nil {
+		fake.createTransitGatewayPeeringAttachmentReturnsOnCall = make(map[int]struct {
+			result1 *ec2.CreateTransitGatewayPeeringAttachmentOutput
 			result2 error
 		})
 	}
-	fake.createTransitGatewayVpcAttachmentReturnsOnCall[i] = struct {
-		result1 *ec2.CreateTransitGatewayVpcAttachmentOutput
+	fake.createTransitGatewayPeeringAttachmentReturnsOnCall[i] = struct {
+		result1 *ec2.CreateTransitGatewayPeeringAttachmentOutput
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTransitGatewayClient) DeleteRoute(arg1 context.Context, arg2 *ec2.DeleteRouteInput, arg3 ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
-	fake.deleteRouteMutex.Lock()
-	ret, specificReturn := fake.deleteRouteReturnsOnCall[len(fake.deleteRouteArgsForCall)]
-	fake.deleteRouteArgsForCall = append(fake.deleteRouteArgsForCall, struct {
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRoute(arg1 context.Context, arg2 *ec2.CreateTransitGatewayRouteInput, arg3 ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteOutput, error) {
+	fake.createTransitGatewayRouteMutex.Lock()
+	ret, specificReturn := fake.createTransitGatewayRouteReturnsOnCall[len(fake.createTransitGatewayRouteArgsForCall)]
+	fake.createTransitGatewayRouteArgsForCall = append(fake.createTransitGatewayRouteArgsForCall, struct {
 		arg1 context.Context
-		arg2 *ec2.DeleteRouteInput
+		arg2 *ec2.CreateTransitGatewayRouteInput
 		arg3 []func(*ec2.Options)
 	}{arg1, arg2, arg3})
-	stub := fake.DeleteRouteStub
-	fakeReturns := fake.deleteRouteReturns
-	fake.recordInvocation("DeleteRoute", []interface{}{arg1, arg2, arg3})
-	fake.deleteRouteMutex.Unlock()
+	stub := fake.CreateTransitGatewayRouteStub
+	fakeReturns := fake.createTransitGatewayRouteReturns
+	fake.recordInvocation("CreateTransitGatewayRoute", []interface{}{arg1, arg2, arg3})
+	fake.createTransitGatewayRouteMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3...)
 	}
@@ -526,20 +957,284 @@ func (fake *FakeTransitGatewayClient) DeleteRoute(arg1 context.Context, arg2 *ec
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeTransitGatewayClient) DeleteRouteCallCount() int {
-	fake.deleteRouteMutex.RLock()
-	defer fake.deleteRouteMutex.RUnlock()
-	return len(fake.deleteRouteArgsForCall)
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteCallCount() int {
+	fake.createTransitGatewayRouteMutex.RLock()
+	defer fake.createTransitGatewayRouteMutex.RUnlock()
+	return len(fake.createTransitGatewayRouteArgsForCall)
 }
 
-func (fake *FakeTransitGatewayClient) DeleteRouteCalls(stub func(context.Context, *ec2.DeleteRouteInput, ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error)) {
-	fake.deleteRouteMutex.Lock()
-	defer fake.deleteRouteMutex.Unlock()
-	fake.DeleteRouteStub = stub
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteCalls(stub func(context.Context, *ec2.CreateTransitGatewayRouteInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteOutput, error)) {
+	fake.createTransitGatewayRouteMutex.Lock()
+	defer fake.createTransitGatewayRouteMutex.Unlock()
+	fake.CreateTransitGatewayRouteStub = stub
 }
 
-func (fake *FakeTransitGatewayClient) DeleteRouteArgsForCall(i int) (context.Context, *ec2.DeleteRouteInput, []func(*ec2.Options)) {
-	fake.deleteRouteMutex.RLock()
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteArgsForCall(i int) (context.Context, *ec2.CreateTransitGatewayRouteInput, []func(*ec2.Options)) {
+	fake.createTransitGatewayRouteMutex.RLock()
+	defer fake.createTransitGatewayRouteMutex.RUnlock()
+	argsForCall := fake.createTransitGatewayRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteReturns(result1 *ec2.CreateTransitGatewayRouteOutput, result2 error) {
+	fake.createTransitGatewayRouteMutex.Lock()
+	defer fake.createTransitGatewayRouteMutex.Unlock()
+	fake.CreateTransitGatewayRouteStub = nil
+	fake.createTransitGatewayRouteReturns = struct {
+		result1 *ec2.CreateTransitGatewayRouteOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteReturnsOnCall(i int, result1 *ec2.CreateTransitGatewayRouteOutput, result2 error) {
+	fake.createTransitGatewayRouteMutex.Lock()
+	defer fake.createTransitGatewayRouteMutex.Unlock()
+	fake.CreateTransitGatewayRouteStub = nil
+	if fake.createTransitGatewayRouteReturnsOnCall == nil {
+		fake.createTransitGatewayRouteReturnsOnCall = make(map[int]struct {
+			result1 *ec2.CreateTransitGatewayRouteOutput
+			result2 error
+		})
+	}
+	fake.createTransitGatewayRouteReturnsOnCall[i] = struct {
+		result1 *ec2.CreateTransitGatewayRouteOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteTable(arg1 context.Context, arg2 *ec2.CreateTransitGatewayRouteTableInput, arg3 ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteTableOutput, error) {
+	fake.createTransitGatewayRouteTableMutex.Lock()
+	ret, specificReturn := fake.createTransitGatewayRouteTableReturnsOnCall[len(fake.createTransitGatewayRouteTableArgsForCall)]
+	fake.createTransitGatewayRouteTableArgsForCall = append(fake.createTransitGatewayRouteTableArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.CreateTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CreateTransitGatewayRouteTableStub
+	fakeReturns := fake.createTransitGatewayRouteTableReturns
+	fake.recordInvocation("CreateTransitGatewayRouteTable", []interface{}{arg1, arg2, arg3})
+	fake.createTransitGatewayRouteTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteTableCallCount() int {
+	fake.createTransitGatewayRouteTableMutex.RLock()
+	defer fake.createTransitGatewayRouteTableMutex.RUnlock()
+	return len(fake.createTransitGatewayRouteTableArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteTableCalls(stub func(context.Context, *ec2.CreateTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteTableOutput, error)) {
+	fake.createTransitGatewayRouteTableMutex.Lock()
+	defer fake.createTransitGatewayRouteTableMutex.Unlock()
+	fake.CreateTransitGatewayRouteTableStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteTableArgsForCall(i int) (context.Context, *ec2.CreateTransitGatewayRouteTableInput, []func(*ec2.Options)) {
+	fake.createTransitGatewayRouteTableMutex.RLock()
+	defer fake.createTransitGatewayRouteTableMutex.RUnlock()
+	argsForCall := fake.createTransitGatewayRouteTableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteTableReturns(result1 *ec2.CreateTransitGatewayRouteTableOutput, result2 error) {
+	fake.createTransitGatewayRouteTableMutex.Lock()
+	defer fake.createTransitGatewayRouteTableMutex.Unlock()
+	fake.CreateTransitGatewayRouteTableStub = nil
+	fake.createTransitGatewayRouteTableReturns = struct {
+		result1 *ec2.CreateTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayRouteTableReturnsOnCall(i int, result1 *ec2.CreateTransitGatewayRouteTableOutput, result2 error) {
+	fake.createTransitGatewayRouteTableMutex.Lock()
+	defer fake.createTransitGatewayRouteTableMutex.Unlock()
+	fake.CreateTransitGatewayRouteTableStub = nil
+	if fake.createTransitGatewayRouteTableReturnsOnCall == nil {
+		fake.createTransitGatewayRouteTableReturnsOnCall = make(map[int]struct {
+			result1 *ec2.CreateTransitGatewayRouteTableOutput
+			result2 error
+		})
+	}
+	fake.createTransitGatewayRouteTableReturnsOnCall[i] = struct {
+		result1 *ec2.CreateTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachment(arg1 context.Context, arg2 *ec2.CreateTransitGatewayVpcAttachmentInput, arg3 ...func(*ec2.Options)) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error) {
+	fake.createTransitGatewayVpcAttachmentMutex.Lock()
+	ret, specificReturn := fake.createTransitGatewayVpcAttachmentReturnsOnCall[len(fake.createTransitGatewayVpcAttachmentArgsForCall)]
+	fake.createTransitGatewayVpcAttachmentArgsForCall = append(fake.createTransitGatewayVpcAttachmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.CreateTransitGatewayVpcAttachmentInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.CreateTransitGatewayVpcAttachmentStub
+	fakeReturns := fake.createTransitGatewayVpcAttachmentReturns
+	fake.recordInvocation("CreateTransitGatewayVpcAttachment", []interface{}{arg1, arg2, arg3})
+	fake.createTransitGatewayVpcAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentCallCount() int {
+	fake.createTransitGatewayVpcAttachmentMutex.RLock()
+	defer fake.createTransitGatewayVpcAttachmentMutex.RUnlock()
+	return len(fake.createTransitGatewayVpcAttachmentArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentCalls(stub func(context.Context, *ec2.CreateTransitGatewayVpcAttachmentInput, ...func(*ec2.Options)) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error)) {
+	fake.createTransitGatewayVpcAttachmentMutex.Lock()
+	defer fake.createTransitGatewayVpcAttachmentMutex.Unlock()
+	fake.CreateTransitGatewayVpcAttachmentStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentArgsForCall(i int) (context.Context, *ec2.CreateTransitGatewayVpcAttachmentInput, []func(*ec2.Options)) {
+	fake.createTransitGatewayVpcAttachmentMutex.RLock()
+	defer fake.createTransitGatewayVpcAttachmentMutex.RUnlock()
+	argsForCall := fake.createTransitGatewayVpcAttachmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentReturns(result1 *ec2.CreateTransitGatewayVpcAttachmentOutput, result2 error) {
+	fake.createTransitGatewayVpcAttachmentMutex.Lock()
+	defer fake.createTransitGatewayVpcAttachmentMutex.Unlock()
+	fake.CreateTransitGatewayVpcAttachmentStub = nil
+	fake.createTransitGatewayVpcAttachmentReturns = struct {
+		result1 *ec2.CreateTransitGatewayVpcAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) CreateTransitGatewayVpcAttachmentReturnsOnCall(i int, result1 *ec2.CreateTransitGatewayVpcAttachmentOutput, result2 error) {
+	fake.createTransitGatewayVpcAttachmentMutex.Lock()
+	defer fake.createTransitGatewayVpcAttachmentMutex.Unlock()
+	fake.CreateTransitGatewayVpcAttachmentStub = nil
+	if fake.createTransitGatewayVpcAttachmentReturnsOnCall == nil {
+		fake.createTransitGatewayVpcAttachmentReturnsOnCall = make(map[int]struct {
+			result1 *ec2.CreateTransitGatewayVpcAttachmentOutput
+			result2 error
+		})
+	}
+	fake.createTransitGatewayVpcAttachmentReturnsOnCall[i] = struct {
+		result1 *ec2.CreateTransitGatewayVpcAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteManagedPrefixList(arg1 context.Context, arg2 *ec2.DeleteManagedPrefixListInput, arg3 ...func(*ec2.Options)) (*ec2.DeleteManagedPrefixListOutput, error) {
+	fake.deleteManagedPrefixListMutex.Lock()
+	ret, specificReturn := fake.deleteManagedPrefixListReturnsOnCall[len(fake.deleteManagedPrefixListArgsForCall)]
+	fake.deleteManagedPrefixListArgsForCall = append(fake.deleteManagedPrefixListArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteManagedPrefixListInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteManagedPrefixListStub
+	fakeReturns := fake.deleteManagedPrefixListReturns
+	fake.recordInvocation("DeleteManagedPrefixList", []interface{}{arg1, arg2, arg3})
+	fake.deleteManagedPrefixListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DeleteManagedPrefixListCallCount() int {
+	fake.deleteManagedPrefixListMutex.RLock()
+	defer fake.deleteManagedPrefixListMutex.RUnlock()
+	return len(fake.deleteManagedPrefixListArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DeleteManagedPrefixListCalls(stub func(context.Context, *ec2.DeleteManagedPrefixListInput, ...func(*ec2.Options)) (*ec2.DeleteManagedPrefixListOutput, error)) {
+	fake.deleteManagedPrefixListMutex.Lock()
+	defer fake.deleteManagedPrefixListMutex.Unlock()
+	fake.DeleteManagedPrefixListStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DeleteManagedPrefixListArgsForCall(i int) (context.Context, *ec2.DeleteManagedPrefixListInput, []func(*ec2.Options)) {
+	fake.deleteManagedPrefixListMutex.RLock()
+	defer fake.deleteManagedPrefixListMutex.RUnlock()
+	argsForCall := fake.deleteManagedPrefixListArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DeleteManagedPrefixListReturns(result1 *ec2.DeleteManagedPrefixListOutput, result2 error) {
+	fake.deleteManagedPrefixListMutex.Lock()
+	defer fake.deleteManagedPrefixListMutex.Unlock()
+	fake.DeleteManagedPrefixListStub = nil
+	fake.deleteManagedPrefixListReturns = struct {
+		result1 *ec2.DeleteManagedPrefixListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteManagedPrefixListReturnsOnCall(i int, result1 *ec2.DeleteManagedPrefixListOutput, result2 error) {
+	fake.deleteManagedPrefixListMutex.Lock()
+	defer fake.deleteManagedPrefixListMutex.Unlock()
+	fake.DeleteManagedPrefixListStub = nil
+	if fake.deleteManagedPrefixListReturnsOnCall == nil {
+		fake.deleteManagedPrefixListReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DeleteManagedPrefixListOutput
+			result2 error
+		})
+	}
+	fake.deleteManagedPrefixListReturnsOnCall[i] = struct {
+		result1 *ec2.DeleteManagedPrefixListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteRoute(arg1 context.Context, arg2 *ec2.DeleteRouteInput, arg3 ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
+	fake.deleteRouteMutex.Lock()
+	ret, specificReturn := fake.deleteRouteReturnsOnCall[len(fake.deleteRouteArgsForCall)]
+	fake.deleteRouteArgsForCall = append(fake.deleteRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteRouteInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteRouteStub
+	fakeReturns := fake.deleteRouteReturns
+	fake.recordInvocation("DeleteRoute", []interface{}{arg1, arg2, arg3})
+	fake.deleteRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DeleteRouteCallCount() int {
+	fake.deleteRouteMutex.RLock()
+	defer fake.deleteRouteMutex.RUnlock()
+	return len(fake.deleteRouteArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DeleteRouteCalls(stub func(context.Context, *ec2.DeleteRouteInput, ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error)) {
+	fake.deleteRouteMutex.Lock()
+	defer fake.deleteRouteMutex.Unlock()
+	fake.DeleteRouteStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DeleteRouteArgsForCall(i int) (context.Context, *ec2.DeleteRouteInput, []func(*ec2.Options)) {
+	fake.deleteRouteMutex.RLock()
 	defer fake.deleteRouteMutex.RUnlock()
 	argsForCall := fake.deleteRouteArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
@@ -637,6 +1332,204 @@ func (fake *FakeTransitGatewayClient) DeleteTransitGatewayReturnsOnCall(i int, r
 	}{result1, result2}
 }
 
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayPeeringAttachment(arg1 context.Context, arg2 *ec2.DeleteTransitGatewayPeeringAttachmentInput, arg3 ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayPeeringAttachmentOutput, error) {
+	fake.deleteTransitGatewayPeeringAttachmentMutex.Lock()
+	ret, specificReturn := fake.deleteTransitGatewayPeeringAttachmentReturnsOnCall[len(fake.deleteTransitGatewayPeeringAttachmentArgsForCall)]
+	fake.deleteTransitGatewayPeeringAttachmentArgsForCall = append(fake.deleteTransitGatewayPeeringAttachmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteTransitGatewayPeeringAttachmentInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteTransitGatewayPeeringAttachmentStub
+	fakeReturns := fake.deleteTransitGatewayPeeringAttachmentReturns
+	fake.recordInvocation("DeleteTransitGatewayPeeringAttachment", []interface{}{arg1, arg2, arg3})
+	fake.deleteTransitGatewayPeeringAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayPeeringAttachmentCallCount() int {
+	fake.deleteTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.deleteTransitGatewayPeeringAttachmentMutex.RUnlock()
+	return len(fake.deleteTransitGatewayPeeringAttachmentArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayPeeringAttachmentCalls(stub func(context.Context, *ec2.DeleteTransitGatewayPeeringAttachmentInput, ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayPeeringAttachmentOutput, error)) {
+	fake.deleteTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.deleteTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.DeleteTransitGatewayPeeringAttachmentStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayPeeringAttachmentArgsForCall(i int) (context.Context, *ec2.DeleteTransitGatewayPeeringAttachmentInput, []func(*ec2.Options)) {
+	fake.deleteTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.deleteTransitGatewayPeeringAttachmentMutex.RUnlock()
+	argsForCall := fake.deleteTransitGatewayPeeringAttachmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayPeeringAttachmentReturns(result1 *ec2.DeleteTransitGatewayPeeringAttachmentOutput, result2 error) {
+	fake.deleteTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.deleteTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.DeleteTransitGatewayPeeringAttachmentStub = nil
+	fake.deleteTransitGatewayPeeringAttachmentReturns = struct {
+		result1 *ec2.DeleteTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayPeeringAttachmentReturnsOnCall(i int, result1 *ec2.DeleteTransitGatewayPeeringAttachmentOutput, result2 error) {
+	fake.deleteTransitGatewayPeeringAttachmentMutex.Lock()
+	defer fake.deleteTransitGatewayPeeringAttachmentMutex.Unlock()
+	fake.DeleteTransitGatewayPeeringAttachmentStub = nil
+	if fake.deleteTransitGatewayPeeringAttachmentReturnsOnCall == nil {
+		fake.deleteTransitGatewayPeeringAttachmentReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DeleteTransitGatewayPeeringAttachmentOutput
+			result2 error
+		})
+	}
+	fake.deleteTransitGatewayPeeringAttachmentReturnsOnCall[i] = struct {
+		result1 *ec2.DeleteTransitGatewayPeeringAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRoute(arg1 context.Context, arg2 *ec2.DeleteTransitGatewayRouteInput, arg3 ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteOutput, error) {
+	fake.deleteTransitGatewayRouteMutex.Lock()
+	ret, specificReturn := fake.deleteTransitGatewayRouteReturnsOnCall[len(fake.deleteTransitGatewayRouteArgsForCall)]
+	fake.deleteTransitGatewayRouteArgsForCall = append(fake.deleteTransitGatewayRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteTransitGatewayRouteInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteTransitGatewayRouteStub
+	fakeReturns := fake.deleteTransitGatewayRouteReturns
+	fake.recordInvocation("DeleteTransitGatewayRoute", []interface{}{arg1, arg2, arg3})
+	fake.deleteTransitGatewayRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteCallCount() int {
+	fake.deleteTransitGatewayRouteMutex.RLock()
+	defer fake.deleteTransitGatewayRouteMutex.RUnlock()
+	return len(fake.deleteTransitGatewayRouteArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteCalls(stub func(context.Context, *ec2.DeleteTransitGatewayRouteInput, ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteOutput, error)) {
+	fake.deleteTransitGatewayRouteMutex.Lock()
+	defer fake.deleteTransitGatewayRouteMutex.Unlock()
+	fake.DeleteTransitGatewayRouteStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteArgsForCall(i int) (context.Context, *ec2.DeleteTransitGatewayRouteInput, []func(*ec2.Options)) {
+	fake.deleteTransitGatewayRouteMutex.RLock()
+	defer fake.deleteTransitGatewayRouteMutex.RUnlock()
+	argsForCall := fake.deleteTransitGatewayRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteReturns(result1 *ec2.DeleteTransitGatewayRouteOutput, result2 error) {
+	fake.deleteTransitGatewayRouteMutex.Lock()
+	defer fake.deleteTransitGatewayRouteMutex.Unlock()
+	fake.DeleteTransitGatewayRouteStub = nil
+	fake.deleteTransitGatewayRouteReturns = struct {
+		result1 *ec2.DeleteTransitGatewayRouteOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteReturnsOnCall(i int, result1 *ec2.DeleteTransitGatewayRouteOutput, result2 error) {
+	fake.deleteTransitGatewayRouteMutex.Lock()
+	defer fake.deleteTransitGatewayRouteMutex.Unlock()
+	fake.DeleteTransitGatewayRouteStub = nil
+	if fake.deleteTransitGatewayRouteReturnsOnCall == nil {
+		fake.deleteTransitGatewayRouteReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DeleteTransitGatewayRouteOutput
+			result2 error
+		})
+	}
+	fake.deleteTransitGatewayRouteReturnsOnCall[i] = struct {
+		result1 *ec2.DeleteTransitGatewayRouteOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteTable(arg1 context.Context, arg2 *ec2.DeleteTransitGatewayRouteTableInput, arg3 ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteTableOutput, error) {
+	fake.deleteTransitGatewayRouteTableMutex.Lock()
+	ret, specificReturn := fake.deleteTransitGatewayRouteTableReturnsOnCall[len(fake.deleteTransitGatewayRouteTableArgsForCall)]
+	fake.deleteTransitGatewayRouteTableArgsForCall = append(fake.deleteTransitGatewayRouteTableArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DeleteTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteTransitGatewayRouteTableStub
+	fakeReturns := fake.deleteTransitGatewayRouteTableReturns
+	fake.recordInvocation("DeleteTransitGatewayRouteTable", []interface{}{arg1, arg2, arg3})
+	fake.deleteTransitGatewayRouteTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteTableCallCount() int {
+	fake.deleteTransitGatewayRouteTableMutex.RLock()
+	defer fake.deleteTransitGatewayRouteTableMutex.RUnlock()
+	return len(fake.deleteTransitGatewayRouteTableArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteTableCalls(stub func(context.Context, *ec2.DeleteTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteTableOutput, error)) {
+	fake.deleteTransitGatewayRouteTableMutex.Lock()
+	defer fake.deleteTransitGatewayRouteTableMutex.Unlock()
+	fake.DeleteTransitGatewayRouteTableStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteTableArgsForCall(i int) (context.Context, *ec2.DeleteTransitGatewayRouteTableInput, []func(*ec2.Options)) {
+	fake.deleteTransitGatewayRouteTableMutex.RLock()
+	defer fake.deleteTransitGatewayRouteTableMutex.RUnlock()
+	argsForCall := fake.deleteTransitGatewayRouteTableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteTableReturns(result1 *ec2.DeleteTransitGatewayRouteTableOutput, result2 error) {
+	fake.deleteTransitGatewayRouteTableMutex.Lock()
+	defer fake.deleteTransitGatewayRouteTableMutex.Unlock()
+	fake.DeleteTransitGatewayRouteTableStub = nil
+	fake.deleteTransitGatewayRouteTableReturns = struct {
+		result1 *ec2.DeleteTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DeleteTransitGatewayRouteTableReturnsOnCall(i int, result1 *ec2.DeleteTransitGatewayRouteTableOutput, result2 error) {
+	fake.deleteTransitGatewayRouteTableMutex.Lock()
+	defer fake.deleteTransitGatewayRouteTableMutex.Unlock()
+	fake.DeleteTransitGatewayRouteTableStub = nil
+	if fake.deleteTransitGatewayRouteTableReturnsOnCall == nil {
+		fake.deleteTransitGatewayRouteTableReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DeleteTransitGatewayRouteTableOutput
+			result2 error
+		})
+	}
+	fake.deleteTransitGatewayRouteTableReturnsOnCall[i] = struct {
+		result1 *ec2.DeleteTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTransitGatewayClient) DeleteTransitGatewayVpcAttachment(arg1 context.Context, arg2 *ec2.DeleteTransitGatewayVpcAttachmentInput, arg3 ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayVpcAttachmentOutput, error) {
 	fake.deleteTransitGatewayVpcAttachmentMutex.Lock()
 	ret, specificReturn := fake.deleteTransitGatewayVpcAttachmentReturnsOnCall[len(fake.deleteTransitGatewayVpcAttachmentArgsForCall)]
@@ -875,28 +1768,226 @@ func (fake *FakeTransitGatewayClient) DescribeSubnetsArgsForCall(i int) (context
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeTransitGatewayClient) DescribeSubnetsReturns(result1 *ec2.DescribeSubnetsOutput, result2 error) {
-	fake.describeSubnetsMutex.Lock()
-	defer fake.describeSubnetsMutex.Unlock()
-	fake.DescribeSubnetsStub = nil
-	fake.describeSubnetsReturns = struct {
-		result1 *ec2.DescribeSubnetsOutput
+func (fake *FakeTransitGatewayClient) DescribeSubnetsReturns(result1 *ec2.DescribeSubnetsOutput, result2 error) {
+	fake.describeSubnetsMutex.Lock()
+	defer fake.describeSubnetsMutex.Unlock()
+	fake.DescribeSubnetsStub = nil
+	fake.describeSubnetsReturns = struct {
+		result1 *ec2.DescribeSubnetsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DescribeSubnetsReturnsOnCall(i int, result1 *ec2.DescribeSubnetsOutput, result2 error) {
+	fake.describeSubnetsMutex.Lock()
+	defer fake.describeSubnetsMutex.Unlock()
+	fake.DescribeSubnetsStub = nil
+	if fake.describeSubnetsReturnsOnCall == nil {
+		fake.describeSubnetsReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DescribeSubnetsOutput
+			result2 error
+		})
+	}
+	fake.describeSubnetsReturnsOnCall[i] = struct {
+		result1 *ec2.DescribeSubnetsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayAttachments(arg1 context.Context, arg2 *ec2.DescribeTransitGatewayAttachmentsInput, arg3 ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayAttachmentsOutput, error) {
+	fake.describeTransitGatewayAttachmentsMutex.Lock()
+	ret, specificReturn := fake.describeTransitGatewayAttachmentsReturnsOnCall[len(fake.describeTransitGatewayAttachmentsArgsForCall)]
+	fake.describeTransitGatewayAttachmentsArgsForCall = append(fake.describeTransitGatewayAttachmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeTransitGatewayAttachmentsInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeTransitGatewayAttachmentsStub
+	fakeReturns := fake.describeTransitGatewayAttachmentsReturns
+	fake.recordInvocation("DescribeTransitGatewayAttachments", []interface{}{arg1, arg2, arg3})
+	fake.describeTransitGatewayAttachmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayAttachmentsCallCount() int {
+	fake.describeTransitGatewayAttachmentsMutex.RLock()
+	defer fake.describeTransitGatewayAttachmentsMutex.RUnlock()
+	return len(fake.describeTransitGatewayAttachmentsArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayAttachmentsCalls(stub func(context.Context, *ec2.DescribeTransitGatewayAttachmentsInput, ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayAttachmentsOutput, error)) {
+	fake.describeTransitGatewayAttachmentsMutex.Lock()
+	defer fake.describeTransitGatewayAttachmentsMutex.Unlock()
+	fake.DescribeTransitGatewayAttachmentsStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayAttachmentsArgsForCall(i int) (context.Context, *ec2.DescribeTransitGatewayAttachmentsInput, []func(*ec2.Options)) {
+	fake.describeTransitGatewayAttachmentsMutex.RLock()
+	defer fake.describeTransitGatewayAttachmentsMutex.RUnlock()
+	argsForCall := fake.describeTransitGatewayAttachmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayAttachmentsReturns(result1 *ec2.DescribeTransitGatewayAttachmentsOutput, result2 error) {
+	fake.describeTransitGatewayAttachmentsMutex.Lock()
+	defer fake.describeTransitGatewayAttachmentsMutex.Unlock()
+	fake.DescribeTransitGatewayAttachmentsStub = nil
+	fake.describeTransitGatewayAttachmentsReturns = struct {
+		result1 *ec2.DescribeTransitGatewayAttachmentsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayAttachmentsReturnsOnCall(i int, result1 *ec2.DescribeTransitGatewayAttachmentsOutput, result2 error) {
+	fake.describeTransitGatewayAttachmentsMutex.Lock()
+	defer fake.describeTransitGatewayAttachmentsMutex.Unlock()
+	fake.DescribeTransitGatewayAttachmentsStub = nil
+	if fake.describeTransitGatewayAttachmentsReturnsOnCall == nil {
+		fake.describeTransitGatewayAttachmentsReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DescribeTransitGatewayAttachmentsOutput
+			result2 error
+		})
+	}
+	fake.describeTransitGatewayAttachmentsReturnsOnCall[i] = struct {
+		result1 *ec2.DescribeTransitGatewayAttachmentsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayPeeringAttachments(arg1 context.Context, arg2 *ec2.DescribeTransitGatewayPeeringAttachmentsInput, arg3 ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error) {
+	fake.describeTransitGatewayPeeringAttachmentsMutex.Lock()
+	ret, specificReturn := fake.describeTransitGatewayPeeringAttachmentsReturnsOnCall[len(fake.describeTransitGatewayPeeringAttachmentsArgsForCall)]
+	fake.describeTransitGatewayPeeringAttachmentsArgsForCall = append(fake.describeTransitGatewayPeeringAttachmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeTransitGatewayPeeringAttachmentsInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeTransitGatewayPeeringAttachmentsStub
+	fakeReturns := fake.describeTransitGatewayPeeringAttachmentsReturns
+	fake.recordInvocation("DescribeTransitGatewayPeeringAttachments", []interface{}{arg1, arg2, arg3})
+	fake.describeTransitGatewayPeeringAttachmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayPeeringAttachmentsCallCount() int {
+	fake.describeTransitGatewayPeeringAttachmentsMutex.RLock()
+	defer fake.describeTransitGatewayPeeringAttachmentsMutex.RUnlock()
+	return len(fake.describeTransitGatewayPeeringAttachmentsArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayPeeringAttachmentsCalls(stub func(context.Context, *ec2.DescribeTransitGatewayPeeringAttachmentsInput, ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error)) {
+	fake.describeTransitGatewayPeeringAttachmentsMutex.Lock()
+	defer fake.describeTransitGatewayPeeringAttachmentsMutex.Unlock()
+	fake.DescribeTransitGatewayPeeringAttachmentsStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayPeeringAttachmentsArgsForCall(i int) (context.Context, *ec2.DescribeTransitGatewayPeeringAttachmentsInput, []func(*ec2.Options)) {
+	fake.describeTransitGatewayPeeringAttachmentsMutex.RLock()
+	defer fake.describeTransitGatewayPeeringAttachmentsMutex.RUnlock()
+	argsForCall := fake.describeTransitGatewayPeeringAttachmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayPeeringAttachmentsReturns(result1 *ec2.DescribeTransitGatewayPeeringAttachmentsOutput, result2 error) {
+	fake.describeTransitGatewayPeeringAttachmentsMutex.Lock()
+	defer fake.describeTransitGatewayPeeringAttachmentsMutex.Unlock()
+	fake.DescribeTransitGatewayPeeringAttachmentsStub = nil
+	fake.describeTransitGatewayPeeringAttachmentsReturns = struct {
+		result1 *ec2.DescribeTransitGatewayPeeringAttachmentsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayPeeringAttachmentsReturnsOnCall(i int, result1 *ec2.DescribeTransitGatewayPeeringAttachmentsOutput, result2 error) {
+	fake.describeTransitGatewayPeeringAttachmentsMutex.Lock()
+	defer fake.describeTransitGatewayPeeringAttachmentsMutex.Unlock()
+	fake.DescribeTransitGatewayPeeringAttachmentsStub = nil
+	if fake.describeTransitGatewayPeeringAttachmentsReturnsOnCall == nil {
+		fake.describeTransitGatewayPeeringAttachmentsReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DescribeTransitGatewayPeeringAttachmentsOutput
+			result2 error
+		})
+	}
+	fake.describeTransitGatewayPeeringAttachmentsReturnsOnCall[i] = struct {
+		result1 *ec2.DescribeTransitGatewayPeeringAttachmentsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayRouteTables(arg1 context.Context, arg2 *ec2.DescribeTransitGatewayRouteTablesInput, arg3 ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayRouteTablesOutput, error) {
+	fake.describeTransitGatewayRouteTablesMutex.Lock()
+	ret, specificReturn := fake.describeTransitGatewayRouteTablesReturnsOnCall[len(fake.describeTransitGatewayRouteTablesArgsForCall)]
+	fake.describeTransitGatewayRouteTablesArgsForCall = append(fake.describeTransitGatewayRouteTablesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeTransitGatewayRouteTablesInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeTransitGatewayRouteTablesStub
+	fakeReturns := fake.describeTransitGatewayRouteTablesReturns
+	fake.recordInvocation("DescribeTransitGatewayRouteTables", []interface{}{arg1, arg2, arg3})
+	fake.describeTransitGatewayRouteTablesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayRouteTablesCallCount() int {
+	fake.describeTransitGatewayRouteTablesMutex.RLock()
+	defer fake.describeTransitGatewayRouteTablesMutex.RUnlock()
+	return len(fake.describeTransitGatewayRouteTablesArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayRouteTablesCalls(stub func(context.Context, *ec2.DescribeTransitGatewayRouteTablesInput, ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayRouteTablesOutput, error)) {
+	fake.describeTransitGatewayRouteTablesMutex.Lock()
+	defer fake.describeTransitGatewayRouteTablesMutex.Unlock()
+	fake.DescribeTransitGatewayRouteTablesStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayRouteTablesArgsForCall(i int) (context.Context, *ec2.DescribeTransitGatewayRouteTablesInput, []func(*ec2.Options)) {
+	fake.describeTransitGatewayRouteTablesMutex.RLock()
+	defer fake.describeTransitGatewayRouteTablesMutex.RUnlock()
+	argsForCall := fake.describeTransitGatewayRouteTablesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayRouteTablesReturns(result1 *ec2.DescribeTransitGatewayRouteTablesOutput, result2 error) {
+	fake.describeTransitGatewayRouteTablesMutex.Lock()
+	defer fake.describeTransitGatewayRouteTablesMutex.Unlock()
+	fake.DescribeTransitGatewayRouteTablesStub = nil
+	fake.describeTransitGatewayRouteTablesReturns = struct {
+		result1 *ec2.DescribeTransitGatewayRouteTablesOutput
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTransitGatewayClient) DescribeSubnetsReturnsOnCall(i int, result1 *ec2.DescribeSubnetsOutput, result2 error) {
-	fake.describeSubnetsMutex.Lock()
-	defer fake.describeSubnetsMutex.Unlock()
-	fake.DescribeSubnetsStub = nil
-	if fake.describeSubnetsReturnsOnCall == nil {
-		fake.describeSubnetsReturnsOnCall = make(map[int]struct {
-			result1 *ec2.DescribeSubnetsOutput
+func (fake *FakeTransitGatewayClient) DescribeTransitGatewayRouteTablesReturnsOnCall(i int, result1 *ec2.DescribeTransitGatewayRouteTablesOutput, result2 error) {
+	fake.describeTransitGatewayRouteTablesMutex.Lock()
+	defer fake.describeTransitGatewayRouteTablesMutex.Unlock()
+	fake.DescribeTransitGatewayRouteTablesStub = nil
+	if fake.describeTransitGatewayRouteTablesReturnsOnCall == nil {
+		fake.describeTransitGatewayRouteTablesReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DescribeTransitGatewayRouteTablesOutput
 			result2 error
 		})
 	}
-	fake.describeSubnetsReturnsOnCall[i] = struct {
-		result1 *ec2.DescribeSubnetsOutput
+	fake.describeTransitGatewayRouteTablesReturnsOnCall[i] = struct {
+		result1 *ec2.DescribeTransitGatewayRouteTablesOutput
 		result2 error
 	}{result1, result2}
 }
@@ -1033,6 +2124,336 @@ func (fake *FakeTransitGatewayClient) DescribeTransitGatewaysReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *FakeTransitGatewayClient) DescribeVpcs(arg1 context.Context, arg2 *ec2.DescribeVpcsInput, arg3 ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	fake.describeVpcsMutex.Lock()
+	ret, specificReturn := fake.describeVpcsReturnsOnCall[len(fake.describeVpcsArgsForCall)]
+	fake.describeVpcsArgsForCall = append(fake.describeVpcsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DescribeVpcsInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DescribeVpcsStub
+	fakeReturns := fake.describeVpcsReturns
+	fake.recordInvocation("DescribeVpcs", []interface{}{arg1, arg2, arg3})
+	fake.describeVpcsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DescribeVpcsCallCount() int {
+	fake.describeVpcsMutex.RLock()
+	defer fake.describeVpcsMutex.RUnlock()
+	return len(fake.describeVpcsArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DescribeVpcsCalls(stub func(context.Context, *ec2.DescribeVpcsInput, ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error)) {
+	fake.describeVpcsMutex.Lock()
+	defer fake.describeVpcsMutex.Unlock()
+	fake.DescribeVpcsStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DescribeVpcsArgsForCall(i int) (context.Context, *ec2.DescribeVpcsInput, []func(*ec2.Options)) {
+	fake.describeVpcsMutex.RLock()
+	defer fake.describeVpcsMutex.RUnlock()
+	argsForCall := fake.describeVpcsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DescribeVpcsReturns(result1 *ec2.DescribeVpcsOutput, result2 error) {
+	fake.describeVpcsMutex.Lock()
+	defer fake.describeVpcsMutex.Unlock()
+	fake.DescribeVpcsStub = nil
+	fake.describeVpcsReturns = struct {
+		result1 *ec2.DescribeVpcsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DescribeVpcsReturnsOnCall(i int, result1 *ec2.DescribeVpcsOutput, result2 error) {
+	fake.describeVpcsMutex.Lock()
+	defer fake.describeVpcsMutex.Unlock()
+	fake.DescribeVpcsStub = nil
+	if fake.describeVpcsReturnsOnCall == nil {
+		fake.describeVpcsReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DescribeVpcsOutput
+			result2 error
+		})
+	}
+	fake.describeVpcsReturnsOnCall[i] = struct {
+		result1 *ec2.DescribeVpcsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DisableTransitGatewayRouteTablePropagation(arg1 context.Context, arg2 *ec2.DisableTransitGatewayRouteTablePropagationInput, arg3 ...func(*ec2.Options)) (*ec2.DisableTransitGatewayRouteTablePropagationOutput, error) {
+	fake.disableTransitGatewayRouteTablePropagationMutex.Lock()
+	ret, specificReturn := fake.disableTransitGatewayRouteTablePropagationReturnsOnCall[len(fake.disableTransitGatewayRouteTablePropagationArgsForCall)]
+	fake.disableTransitGatewayRouteTablePropagationArgsForCall = append(fake.disableTransitGatewayRouteTablePropagationArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DisableTransitGatewayRouteTablePropagationInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DisableTransitGatewayRouteTablePropagationStub
+	fakeReturns := fake.disableTransitGatewayRouteTablePropagationReturns
+	fake.recordInvocation("DisableTransitGatewayRouteTablePropagation", []interface{}{arg1, arg2, arg3})
+	fake.disableTransitGatewayRouteTablePropagationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DisableTransitGatewayRouteTablePropagationCallCount() int {
+	fake.disableTransitGatewayRouteTablePropagationMutex.RLock()
+	defer fake.disableTransitGatewayRouteTablePropagationMutex.RUnlock()
+	return len(fake.disableTransitGatewayRouteTablePropagationArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DisableTransitGatewayRouteTablePropagationCalls(stub func(context.Context, *ec2.DisableTransitGatewayRouteTablePropagationInput, ...func(*ec2.Options)) (*ec2.DisableTransitGatewayRouteTablePropagationOutput, error)) {
+	fake.disableTransitGatewayRouteTablePropagationMutex.Lock()
+	defer fake.disableTransitGatewayRouteTablePropagationMutex.Unlock()
+	fake.DisableTransitGatewayRouteTablePropagationStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DisableTransitGatewayRouteTablePropagationArgsForCall(i int) (context.Context, *ec2.DisableTransitGatewayRouteTablePropagationInput, []func(*ec2.Options)) {
+	fake.disableTransitGatewayRouteTablePropagationMutex.RLock()
+	defer fake.disableTransitGatewayRouteTablePropagationMutex.RUnlock()
+	argsForCall := fake.disableTransitGatewayRouteTablePropagationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DisableTransitGatewayRouteTablePropagationReturns(result1 *ec2.DisableTransitGatewayRouteTablePropagationOutput, result2 error) {
+	fake.disableTransitGatewayRouteTablePropagationMutex.Lock()
+	defer fake.disableTransitGatewayRouteTablePropagationMutex.Unlock()
+	fake.DisableTransitGatewayRouteTablePropagationStub = nil
+	fake.disableTransitGatewayRouteTablePropagationReturns = struct {
+		result1 *ec2.DisableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DisableTransitGatewayRouteTablePropagationReturnsOnCall(i int, result1 *ec2.DisableTransitGatewayRouteTablePropagationOutput, result2 error) {
+	fake.disableTransitGatewayRouteTablePropagationMutex.Lock()
+	defer fake.disableTransitGatewayRouteTablePropagationMutex.Unlock()
+	fake.DisableTransitGatewayRouteTablePropagationStub = nil
+	if fake.disableTransitGatewayRouteTablePropagationReturnsOnCall == nil {
+		fake.disableTransitGatewayRouteTablePropagationReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DisableTransitGatewayRouteTablePropagationOutput
+			result2 error
+		})
+	}
+	fake.disableTransitGatewayRouteTablePropagationReturnsOnCall[i] = struct {
+		result1 *ec2.DisableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DisassociateTransitGatewayRouteTable(arg1 context.Context, arg2 *ec2.DisassociateTransitGatewayRouteTableInput, arg3 ...func(*ec2.Options)) (*ec2.DisassociateTransitGatewayRouteTableOutput, error) {
+	fake.disassociateTransitGatewayRouteTableMutex.Lock()
+	ret, specificReturn := fake.disassociateTransitGatewayRouteTableReturnsOnCall[len(fake.disassociateTransitGatewayRouteTableArgsForCall)]
+	fake.disassociateTransitGatewayRouteTableArgsForCall = append(fake.disassociateTransitGatewayRouteTableArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.DisassociateTransitGatewayRouteTableInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.DisassociateTransitGatewayRouteTableStub
+	fakeReturns := fake.disassociateTransitGatewayRouteTableReturns
+	fake.recordInvocation("DisassociateTransitGatewayRouteTable", []interface{}{arg1, arg2, arg3})
+	fake.disassociateTransitGatewayRouteTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) DisassociateTransitGatewayRouteTableCallCount() int {
+	fake.disassociateTransitGatewayRouteTableMutex.RLock()
+	defer fake.disassociateTransitGatewayRouteTableMutex.RUnlock()
+	return len(fake.disassociateTransitGatewayRouteTableArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) DisassociateTransitGatewayRouteTableCalls(stub func(context.Context, *ec2.DisassociateTransitGatewayRouteTableInput, ...func(*ec2.Options)) (*ec2.DisassociateTransitGatewayRouteTableOutput, error)) {
+	fake.disassociateTransitGatewayRouteTableMutex.Lock()
+	defer fake.disassociateTransitGatewayRouteTableMutex.Unlock()
+	fake.DisassociateTransitGatewayRouteTableStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) DisassociateTransitGatewayRouteTableArgsForCall(i int) (context.Context, *ec2.DisassociateTransitGatewayRouteTableInput, []func(*ec2.Options)) {
+	fake.disassociateTransitGatewayRouteTableMutex.RLock()
+	defer fake.disassociateTransitGatewayRouteTableMutex.RUnlock()
+	argsForCall := fake.disassociateTransitGatewayRouteTableArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) DisassociateTransitGatewayRouteTableReturns(result1 *ec2.DisassociateTransitGatewayRouteTableOutput, result2 error) {
+	fake.disassociateTransitGatewayRouteTableMutex.Lock()
+	defer fake.disassociateTransitGatewayRouteTableMutex.Unlock()
+	fake.DisassociateTransitGatewayRouteTableStub = nil
+	fake.disassociateTransitGatewayRouteTableReturns = struct {
+		result1 *ec2.DisassociateTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) DisassociateTransitGatewayRouteTableReturnsOnCall(i int, result1 *ec2.DisassociateTransitGatewayRouteTableOutput, result2 error) {
+	fake.disassociateTransitGatewayRouteTableMutex.Lock()
+	defer fake.disassociateTransitGatewayRouteTableMutex.Unlock()
+	fake.DisassociateTransitGatewayRouteTableStub = nil
+	if fake.disassociateTransitGatewayRouteTableReturnsOnCall == nil {
+		fake.disassociateTransitGatewayRouteTableReturnsOnCall = make(map[int]struct {
+			result1 *ec2.DisassociateTransitGatewayRouteTableOutput
+			result2 error
+		})
+	}
+	fake.disassociateTransitGatewayRouteTableReturnsOnCall[i] = struct {
+		result1 *ec2.DisassociateTransitGatewayRouteTableOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) EnableTransitGatewayRouteTablePropagation(arg1 context.Context, arg2 *ec2.EnableTransitGatewayRouteTablePropagationInput, arg3 ...func(*ec2.Options)) (*ec2.EnableTransitGatewayRouteTablePropagationOutput, error) {
+	fake.enableTransitGatewayRouteTablePropagationMutex.Lock()
+	ret, specificReturn := fake.enableTransitGatewayRouteTablePropagationReturnsOnCall[len(fake.enableTransitGatewayRouteTablePropagationArgsForCall)]
+	fake.enableTransitGatewayRouteTablePropagationArgsForCall = append(fake.enableTransitGatewayRouteTablePropagationArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.EnableTransitGatewayRouteTablePropagationInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.EnableTransitGatewayRouteTablePropagationStub
+	fakeReturns := fake.enableTransitGatewayRouteTablePropagationReturns
+	fake.recordInvocation("EnableTransitGatewayRouteTablePropagation", []interface{}{arg1, arg2, arg3})
+	fake.enableTransitGatewayRouteTablePropagationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) EnableTransitGatewayRouteTablePropagationCallCount() int {
+	fake.enableTransitGatewayRouteTablePropagationMutex.RLock()
+	defer fake.enableTransitGatewayRouteTablePropagationMutex.RUnlock()
+	return len(fake.enableTransitGatewayRouteTablePropagationArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) EnableTransitGatewayRouteTablePropagationCalls(stub func(context.Context, *ec2.EnableTransitGatewayRouteTablePropagationInput, ...func(*ec2.Options)) (*ec2.EnableTransitGatewayRouteTablePropagationOutput, error)) {
+	fake.enableTransitGatewayRouteTablePropagationMutex.Lock()
+	defer fake.enableTransitGatewayRouteTablePropagationMutex.Unlock()
+	fake.EnableTransitGatewayRouteTablePropagationStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) EnableTransitGatewayRouteTablePropagationArgsForCall(i int) (context.Context, *ec2.EnableTransitGatewayRouteTablePropagationInput, []func(*ec2.Options)) {
+	fake.enableTransitGatewayRouteTablePropagationMutex.RLock()
+	defer fake.enableTransitGatewayRouteTablePropagationMutex.RUnlock()
+	argsForCall := fake.enableTransitGatewayRouteTablePropagationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) EnableTransitGatewayRouteTablePropagationReturns(result1 *ec2.EnableTransitGatewayRouteTablePropagationOutput, result2 error) {
+	fake.enableTransitGatewayRouteTablePropagationMutex.Lock()
+	defer fake.enableTransitGatewayRouteTablePropagationMutex.Unlock()
+	fake.EnableTransitGatewayRouteTablePropagationStub = nil
+	fake.enableTransitGatewayRouteTablePropagationReturns = struct {
+		result1 *ec2.EnableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) EnableTransitGatewayRouteTablePropagationReturnsOnCall(i int, result1 *ec2.EnableTransitGatewayRouteTablePropagationOutput, result2 error) {
+	fake.enableTransitGatewayRouteTablePropagationMutex.Lock()
+	defer fake.enableTransitGatewayRouteTablePropagationMutex.Unlock()
+	fake.EnableTransitGatewayRouteTablePropagationStub = nil
+	if fake.enableTransitGatewayRouteTablePropagationReturnsOnCall == nil {
+		fake.enableTransitGatewayRouteTablePropagationReturnsOnCall = make(map[int]struct {
+			result1 *ec2.EnableTransitGatewayRouteTablePropagationOutput
+			result2 error
+		})
+	}
+	fake.enableTransitGatewayRouteTablePropagationReturnsOnCall[i] = struct {
+		result1 *ec2.EnableTransitGatewayRouteTablePropagationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) GetManagedPrefixListAssociations(arg1 context.Context, arg2 *ec2.GetManagedPrefixListAssociationsInput, arg3 ...func(*ec2.Options)) (*ec2.GetManagedPrefixListAssociationsOutput, error) {
+	fake.getManagedPrefixListAssociationsMutex.Lock()
+	ret, specificReturn := fake.getManagedPrefixListAssociationsReturnsOnCall[len(fake.getManagedPrefixListAssociationsArgsForCall)]
+	fake.getManagedPrefixListAssociationsArgsForCall = append(fake.getManagedPrefixListAssociationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.GetManagedPrefixListAssociationsInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.GetManagedPrefixListAssociationsStub
+	fakeReturns := fake.getManagedPrefixListAssociationsReturns
+	fake.recordInvocation("GetManagedPrefixListAssociations", []interface{}{arg1, arg2, arg3})
+	fake.getManagedPrefixListAssociationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) GetManagedPrefixListAssociationsCallCount() int {
+	fake.getManagedPrefixListAssociationsMutex.RLock()
+	defer fake.getManagedPrefixListAssociationsMutex.RUnlock()
+	return len(fake.getManagedPrefixListAssociationsArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) GetManagedPrefixListAssociationsCalls(stub func(context.Context, *ec2.GetManagedPrefixListAssociationsInput, ...func(*ec2.Options)) (*ec2.GetManagedPrefixListAssociationsOutput, error)) {
+	fake.getManagedPrefixListAssociationsMutex.Lock()
+	defer fake.getManagedPrefixListAssociationsMutex.Unlock()
+	fake.GetManagedPrefixListAssociationsStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) GetManagedPrefixListAssociationsArgsForCall(i int) (context.Context, *ec2.GetManagedPrefixListAssociationsInput, []func(*ec2.Options)) {
+	fake.getManagedPrefixListAssociationsMutex.RLock()
+	defer fake.getManagedPrefixListAssociationsMutex.RUnlock()
+	argsForCall := fake.getManagedPrefixListAssociationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) GetManagedPrefixListAssociationsReturns(result1 *ec2.GetManagedPrefixListAssociationsOutput, result2 error) {
+	fake.getManagedPrefixListAssociationsMutex.Lock()
+	defer fake.getManagedPrefixListAssociationsMutex.Unlock()
+	fake.GetManagedPrefixListAssociationsStub = nil
+	fake.getManagedPrefixListAssociationsReturns = struct {
+		result1 *ec2.GetManagedPrefixListAssociationsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) GetManagedPrefixListAssociationsReturnsOnCall(i int, result1 *ec2.GetManagedPrefixListAssociationsOutput, result2 error) {
+	fake.getManagedPrefixListAssociationsMutex.Lock()
+	defer fake.getManagedPrefixListAssociationsMutex.Unlock()
+	fake.GetManagedPrefixListAssociationsStub = nil
+	if fake.getManagedPrefixListAssociationsReturnsOnCall == nil {
+		fake.getManagedPrefixListAssociationsReturnsOnCall = make(map[int]struct {
+			result1 *ec2.GetManagedPrefixListAssociationsOutput
+			result2 error
+		})
+	}
+	fake.getManagedPrefixListAssociationsReturnsOnCall[i] = struct {
+		result1 *ec2.GetManagedPrefixListAssociationsOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTransitGatewayClient) GetManagedPrefixListEntries(arg1 context.Context, arg2 *ec2.GetManagedPrefixListEntriesInput, arg3 ...func(*ec2.Options)) (*ec2.GetManagedPrefixListEntriesOutput, error) {
 	fake.getManagedPrefixListEntriesMutex.Lock()
 	ret, specificReturn := fake.getManagedPrefixListEntriesReturnsOnCall[len(fake.getManagedPrefixListEntriesArgsForCall)]
@@ -1099,6 +2520,72 @@ func (fake *FakeTransitGatewayClient) GetManagedPrefixListEntriesReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *FakeTransitGatewayClient) GetTransitGatewayAttachmentPropagations(arg1 context.Context, arg2 *ec2.GetTransitGatewayAttachmentPropagationsInput, arg3 ...func(*ec2.Options)) (*ec2.GetTransitGatewayAttachmentPropagationsOutput, error) {
+	fake.getTransitGatewayAttachmentPropagationsMutex.Lock()
+	ret, specificReturn := fake.getTransitGatewayAttachmentPropagationsReturnsOnCall[len(fake.getTransitGatewayAttachmentPropagationsArgsForCall)]
+	fake.getTransitGatewayAttachmentPropagationsArgsForCall = append(fake.getTransitGatewayAttachmentPropagationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.GetTransitGatewayAttachmentPropagationsInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.GetTransitGatewayAttachmentPropagationsStub
+	fakeReturns := fake.getTransitGatewayAttachmentPropagationsReturns
+	fake.recordInvocation("GetTransitGatewayAttachmentPropagations", []interface{}{arg1, arg2, arg3})
+	fake.getTransitGatewayAttachmentPropagationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) GetTransitGatewayAttachmentPropagationsCallCount() int {
+	fake.getTransitGatewayAttachmentPropagationsMutex.RLock()
+	defer fake.getTransitGatewayAttachmentPropagationsMutex.RUnlock()
+	return len(fake.getTransitGatewayAttachmentPropagationsArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) GetTransitGatewayAttachmentPropagationsCalls(stub func(context.Context, *ec2.GetTransitGatewayAttachmentPropagationsInput, ...func(*ec2.Options)) (*ec2.GetTransitGatewayAttachmentPropagationsOutput, error)) {
+	fake.getTransitGatewayAttachmentPropagationsMutex.Lock()
+	defer fake.getTransitGatewayAttachmentPropagationsMutex.Unlock()
+	fake.GetTransitGatewayAttachmentPropagationsStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) GetTransitGatewayAttachmentPropagationsArgsForCall(i int) (context.Context, *ec2.GetTransitGatewayAttachmentPropagationsInput, []func(*ec2.Options)) {
+	fake.getTransitGatewayAttachmentPropagationsMutex.RLock()
+	defer fake.getTransitGatewayAttachmentPropagationsMutex.RUnlock()
+	argsForCall := fake.getTransitGatewayAttachmentPropagationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) GetTransitGatewayAttachmentPropagationsReturns(result1 *ec2.GetTransitGatewayAttachmentPropagationsOutput, result2 error) {
+	fake.getTransitGatewayAttachmentPropagationsMutex.Lock()
+	defer fake.getTransitGatewayAttachmentPropagationsMutex.Unlock()
+	fake.GetTransitGatewayAttachmentPropagationsStub = nil
+	fake.getTransitGatewayAttachmentPropagationsReturns = struct {
+		result1 *ec2.GetTransitGatewayAttachmentPropagationsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) GetTransitGatewayAttachmentPropagationsReturnsOnCall(i int, result1 *ec2.GetTransitGatewayAttachmentPropagationsOutput, result2 error) {
+	fake.getTransitGatewayAttachmentPropagationsMutex.Lock()
+	defer fake.getTransitGatewayAttachmentPropagationsMutex.Unlock()
+	fake.GetTransitGatewayAttachmentPropagationsStub = nil
+	if fake.getTransitGatewayAttachmentPropagationsReturnsOnCall == nil {
+		fake.getTransitGatewayAttachmentPropagationsReturnsOnCall = make(map[int]struct {
+			result1 *ec2.GetTransitGatewayAttachmentPropagationsOutput
+			result2 error
+		})
+	}
+	fake.getTransitGatewayAttachmentPropagationsReturnsOnCall[i] = struct {
+		result1 *ec2.GetTransitGatewayAttachmentPropagationsOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTransitGatewayClient) ModifyManagedPrefixList(arg1 context.Context, arg2 *ec2.ModifyManagedPrefixListInput, arg3 ...func(*ec2.Options)) (*ec2.ModifyManagedPrefixListOutput, error) {
 	fake.modifyManagedPrefixListMutex.Lock()
 	ret, specificReturn := fake.modifyManagedPrefixListReturnsOnCall[len(fake.modifyManagedPrefixListArgsForCall)]
@@ -1165,6 +2652,72 @@ func (fake *FakeTransitGatewayClient) ModifyManagedPrefixListReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *FakeTransitGatewayClient) ModifyTransitGatewayVpcAttachment(arg1 context.Context, arg2 *ec2.ModifyTransitGatewayVpcAttachmentInput, arg3 ...func(*ec2.Options)) (*ec2.ModifyTransitGatewayVpcAttachmentOutput, error) {
+	fake.modifyTransitGatewayVpcAttachmentMutex.Lock()
+	ret, specificReturn := fake.modifyTransitGatewayVpcAttachmentReturnsOnCall[len(fake.modifyTransitGatewayVpcAttachmentArgsForCall)]
+	fake.modifyTransitGatewayVpcAttachmentArgsForCall = append(fake.modifyTransitGatewayVpcAttachmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.ModifyTransitGatewayVpcAttachmentInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.ModifyTransitGatewayVpcAttachmentStub
+	fakeReturns := fake.modifyTransitGatewayVpcAttachmentReturns
+	fake.recordInvocation("ModifyTransitGatewayVpcAttachment", []interface{}{arg1, arg2, arg3})
+	fake.modifyTransitGatewayVpcAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) ModifyTransitGatewayVpcAttachmentCallCount() int {
+	fake.modifyTransitGatewayVpcAttachmentMutex.RLock()
+	defer fake.modifyTransitGatewayVpcAttachmentMutex.RUnlock()
+	return len(fake.modifyTransitGatewayVpcAttachmentArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) ModifyTransitGatewayVpcAttachmentCalls(stub func(context.Context, *ec2.ModifyTransitGatewayVpcAttachmentInput, ...func(*ec2.Options)) (*ec2.ModifyTransitGatewayVpcAttachmentOutput, error)) {
+	fake.modifyTransitGatewayVpcAttachmentMutex.Lock()
+	defer fake.modifyTransitGatewayVpcAttachmentMutex.Unlock()
+	fake.ModifyTransitGatewayVpcAttachmentStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) ModifyTransitGatewayVpcAttachmentArgsForCall(i int) (context.Context, *ec2.ModifyTransitGatewayVpcAttachmentInput, []func(*ec2.Options)) {
+	fake.modifyTransitGatewayVpcAttachmentMutex.RLock()
+	defer fake.modifyTransitGatewayVpcAttachmentMutex.RUnlock()
+	argsForCall := fake.modifyTransitGatewayVpcAttachmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) ModifyTransitGatewayVpcAttachmentReturns(result1 *ec2.ModifyTransitGatewayVpcAttachmentOutput, result2 error) {
+	fake.modifyTransitGatewayVpcAttachmentMutex.Lock()
+	defer fake.modifyTransitGatewayVpcAttachmentMutex.Unlock()
+	fake.ModifyTransitGatewayVpcAttachmentStub = nil
+	fake.modifyTransitGatewayVpcAttachmentReturns = struct {
+		result1 *ec2.ModifyTransitGatewayVpcAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) ModifyTransitGatewayVpcAttachmentReturnsOnCall(i int, result1 *ec2.ModifyTransitGatewayVpcAttachmentOutput, result2 error) {
+	fake.modifyTransitGatewayVpcAttachmentMutex.Lock()
+	defer fake.modifyTransitGatewayVpcAttachmentMutex.Unlock()
+	fake.ModifyTransitGatewayVpcAttachmentStub = nil
+	if fake.modifyTransitGatewayVpcAttachmentReturnsOnCall == nil {
+		fake.modifyTransitGatewayVpcAttachmentReturnsOnCall = make(map[int]struct {
+			result1 *ec2.ModifyTransitGatewayVpcAttachmentOutput
+			result2 error
+		})
+	}
+	fake.modifyTransitGatewayVpcAttachmentReturnsOnCall[i] = struct {
+		result1 *ec2.ModifyTransitGatewayVpcAttachmentOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTransitGatewayClient) PublishSNSMessage(arg1 context.Context, arg2 *sns.PublishInput, arg3 ...func(*sns.Options)) (*sns.PublishOutput, error) {
 	fake.publishSNSMessageMutex.Lock()
 	ret, specificReturn := fake.publishSNSMessageReturnsOnCall[len(fake.publishSNSMessageArgsForCall)]
@@ -1231,21 +2784,105 @@ func (fake *FakeTransitGatewayClient) PublishSNSMessageReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTransitGatewayClient) SearchTransitGatewayRoutes(arg1 context.Context, arg2 *ec2.SearchTransitGatewayRoutesInput, arg3 ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error) {
+	fake.searchTransitGatewayRoutesMutex.Lock()
+	ret, specificReturn := fake.searchTransitGatewayRoutesReturnsOnCall[len(fake.searchTransitGatewayRoutesArgsForCall)]
+	fake.searchTransitGatewayRoutesArgsForCall = append(fake.searchTransitGatewayRoutesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *ec2.SearchTransitGatewayRoutesInput
+		arg3 []func(*ec2.Options)
+	}{arg1, arg2, arg3})
+	stub := fake.SearchTransitGatewayRoutesStub
+	fakeReturns := fake.searchTransitGatewayRoutesReturns
+	fake.recordInvocation("SearchTransitGatewayRoutes", []interface{}{arg1, arg2, arg3})
+	fake.searchTransitGatewayRoutesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTransitGatewayClient) SearchTransitGatewayRoutesCallCount() int {
+	fake.searchTransitGatewayRoutesMutex.RLock()
+	defer fake.searchTransitGatewayRoutesMutex.RUnlock()
+	return len(fake.searchTransitGatewayRoutesArgsForCall)
+}
+
+func (fake *FakeTransitGatewayClient) SearchTransitGatewayRoutesCalls(stub func(context.Context, *ec2.SearchTransitGatewayRoutesInput, ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error)) {
+	fake.searchTransitGatewayRoutesMutex.Lock()
+	defer fake.searchTransitGatewayRoutesMutex.Unlock()
+	fake.SearchTransitGatewayRoutesStub = stub
+}
+
+func (fake *FakeTransitGatewayClient) SearchTransitGatewayRoutesArgsForCall(i int) (context.Context, *ec2.SearchTransitGatewayRoutesInput, []func(*ec2.Options)) {
+	fake.searchTransitGatewayRoutesMutex.RLock()
+	defer fake.searchTransitGatewayRoutesMutex.RUnlock()
+	argsForCall := fake.searchTransitGatewayRoutesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTransitGatewayClient) SearchTransitGatewayRoutesReturns(result1 *ec2.SearchTransitGatewayRoutesOutput, result2 error) {
+	fake.searchTransitGatewayRoutesMutex.Lock()
+	defer fake.searchTransitGatewayRoutesMutex.Unlock()
+	fake.SearchTransitGatewayRoutesStub = nil
+	fake.searchTransitGatewayRoutesReturns = struct {
+		result1 *ec2.SearchTransitGatewayRoutesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTransitGatewayClient) SearchTransitGatewayRoutesReturnsOnCall(i int, result1 *ec2.SearchTransitGatewayRoutesOutput, result2 error) {
+	fake.searchTransitGatewayRoutesMutex.Lock()
+	defer fake.searchTransitGatewayRoutesMutex.Unlock()
+	fake.SearchTransitGatewayRoutesStub = nil
+	if fake.searchTransitGatewayRoutesReturnsOnCall == nil {
+		fake.searchTransitGatewayRoutesReturnsOnCall = make(map[int]struct {
+			result1 *ec2.SearchTransitGatewayRoutesOutput
+			result2 error
+		})
+	}
+	fake.searchTransitGatewayRoutesReturnsOnCall[i] = struct {
+		result1 *ec2.SearchTransitGatewayRoutesOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTransitGatewayClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.acceptTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.acceptTransitGatewayPeeringAttachmentMutex.RUnlock()
+	fake.associateTransitGatewayRouteTableMutex.RLock()
+	defer fake.associateTransitGatewayRouteTableMutex.RUnlock()
 	fake.createManagedPrefixListMutex.RLock()
 	defer fake.createManagedPrefixListMutex.RUnlock()
 	fake.createRouteMutex.RLock()
 	defer fake.createRouteMutex.RUnlock()
 	fake.createTransitGatewayMutex.RLock()
 	defer fake.createTransitGatewayMutex.RUnlock()
+	fake.createTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.createTransitGatewayPeeringAttachmentMutex.RUnlock()
+	fake.createTransitGatewayRouteMutex.RLock()
+	defer fake.createTransitGatewayRouteMutex.RUnlock()
+	fake.createTransitGatewayRouteTableMutex.RLock()
+	defer fake.createTransitGatewayRouteTableMutex.RUnlock()
 	fake.createTransitGatewayVpcAttachmentMutex.RLock()
 	defer fake.createTransitGatewayVpcAttachmentMutex.RUnlock()
+	fake.deleteManagedPrefixListMutex.RLock()
+	defer fake.deleteManagedPrefixListMutex.RUnlock()
 	fake.deleteRouteMutex.RLock()
 	defer fake.deleteRouteMutex.RUnlock()
 	fake.deleteTransitGatewayMutex.RLock()
 	defer fake.deleteTransitGatewayMutex.RUnlock()
+	fake.deleteTransitGatewayPeeringAttachmentMutex.RLock()
+	defer fake.deleteTransitGatewayPeeringAttachmentMutex.RUnlock()
+	fake.deleteTransitGatewayRouteMutex.RLock()
+	defer fake.deleteTransitGatewayRouteMutex.RUnlock()
+	fake.deleteTransitGatewayRouteTableMutex.RLock()
+	defer fake.deleteTransitGatewayRouteTableMutex.RUnlock()
 	fake.deleteTransitGatewayVpcAttachmentMutex.RLock()
 	defer fake.deleteTransitGatewayVpcAttachmentMutex.RUnlock()
 	fake.describeManagedPrefixListsMutex.RLock()
@@ -1254,16 +2891,38 @@ func (fake *FakeTransitGatewayClient) Invocations() map[string][][]interface{} {
 	defer fake.describeRouteTablesMutex.RUnlock()
 	fake.describeSubnetsMutex.RLock()
 	defer fake.describeSubnetsMutex.RUnlock()
+	fake.describeTransitGatewayAttachmentsMutex.RLock()
+	defer fake.describeTransitGatewayAttachmentsMutex.RUnlock()
+	fake.describeTransitGatewayPeeringAttachmentsMutex.RLock()
+	defer fake.describeTransitGatewayPeeringAttachmentsMutex.RUnlock()
+	fake.describeTransitGatewayRouteTablesMutex.RLock()
+	defer fake.describeTransitGatewayRouteTablesMutex.RUnlock()
 	fake.describeTransitGatewayVpcAttachmentsMutex.RLock()
 	defer fake.describeTransitGatewayVpcAttachmentsMutex.RUnlock()
 	fake.describeTransitGatewaysMutex.RLock()
 	defer fake.describeTransitGatewaysMutex.RUnlock()
+	fake.describeVpcsMutex.RLock()
+	defer fake.describeVpcsMutex.RUnlock()
+	fake.disableTransitGatewayRouteTablePropagationMutex.RLock()
+	defer fake.disableTransitGatewayRouteTablePropagationMutex.RUnlock()
+	fake.disassociateTransitGatewayRouteTableMutex.RLock()
+	defer fake.disassociateTransitGatewayRouteTableMutex.RUnlock()
+	fake.enableTransitGatewayRouteTablePropagationMutex.RLock()
+	defer fake.enableTransitGatewayRouteTablePropagationMutex.RUnlock()
+	fake.getManagedPrefixListAssociationsMutex.RLock()
+	defer fake.getManagedPrefixListAssociationsMutex.RUnlock()
 	fake.getManagedPrefixListEntriesMutex.RLock()
 	defer fake.getManagedPrefixListEntriesMutex.RUnlock()
+	fake.getTransitGatewayAttachmentPropagationsMutex.RLock()
+	defer fake.getTransitGatewayAttachmentPropagationsMutex.RUnlock()
 	fake.modifyManagedPrefixListMutex.RLock()
 	defer fake.modifyManagedPrefixListMutex.RUnlock()
+	fake.modifyTransitGatewayVpcAttachmentMutex.RLock()
+	defer fake.modifyTransitGatewayVpcAttachmentMutex.RUnlock()
 	fake.publishSNSMessageMutex.RLock()
 	defer fake.publishSNSMessageMutex.RUnlock()
+	fake.searchTransitGatewayRoutesMutex.RLock()
+	defer fake.searchTransitGatewayRoutesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,209 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ram"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/metrics"
+)
+
+// DefaultClusterTTL is how long the identity of a cluster is cached before its
+// AWSCluster is read again, e.g. to pick up a changed identityRef or region.
+const DefaultClusterTTL = 5 * time.Minute
+
+// ClientProvider provides the AWS API clients of clusters. Credentials are
+// cached by their IdentityKey and shared between clusters with the same
+// identity. They're evicted when an AWSClusterRoleIdentity they were derived
+// from changes, see InvalidateRoleIdentity. Changes of other identities of the
+// chain are picked up once the identity of a cluster is resolved again, as
+// they change the IdentityKey.
+type ClientProvider struct {
+	credentialsFactory *CredentialsFactory
+	clusterTTL         time.Duration
+
+	mu          sync.Mutex
+	session     *session.Session
+	credentials map[IdentityKey]*cachedCredentials
+	clusters    map[types.NamespacedName]*clusterClients
+}
+
+type cachedCredentials struct {
+	credentials    aws.CredentialsProvider
+	roleIdentities []string
+}
+
+type clusterClients struct {
+	key      IdentityKey
+	expires  time.Time
+	config   aws.Config
+	identity *Identity
+
+	ec2Client *ec2.Client
+	snsClient *sns.Client
+	ramClient *ram.RAM
+}
+
+// NewClientProvider creates a ClientProvider resolving the identities of
+// clusters with the credentialsFactory. The identity of a cluster is resolved
+// again after clusterTTL.
+func NewClientProvider(credentialsFactory *CredentialsFactory, clusterTTL time.Duration) *ClientProvider {
+	return &ClientProvider{
+		credentialsFactory: credentialsFactory,
+		clusterTTL:         clusterTTL,
+		credentials:        map[IdentityKey]*cachedCredentials{},
+		clusters:           map[types.NamespacedName]*clusterClients{},
+	}
+}
+
+// EC2 returns the EC2 client of the cluster.
+func (p *ClientProvider) EC2(ctx context.Context, cluster types.NamespacedName) (*ec2.Client, error) {
+	clients, err := p.clusterClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if clients.ec2Client == nil {
+		clients.ec2Client = ec2.NewFromConfig(clients.config)
+	}
+	return clients.ec2Client, nil
+}
+
+// SNS returns the SNS client of the cluster.
+func (p *ClientProvider) SNS(ctx context.Context, cluster types.NamespacedName) (*sns.Client, error) {
+	clients, err := p.clusterClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if clients.snsClient == nil {
+		clients.snsClient = sns.NewFromConfig(clients.config)
+	}
+	return clients.snsClient, nil
+}
+
+// RAM returns the RAM client of the cluster. RAM is only available in the AWS
+// SDK v1, so the client is created from a session shared by all clusters.
+func (p *ClientProvider) RAM(ctx context.Context, cluster types.NamespacedName) (*ram.RAM, error) {
+	clients, err := p.clusterClients(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if clients.ramClient == nil {
+		if p.session == nil {
+			p.session, err = NewSession(p.credentialsFactory.endpointOptions)
+			if err != nil {
+				return nil, err
+			}
+		}
+		clients.ramClient = AwsRamClientFromIdentity(p.session, clients.identity)
+	}
+	return clients.ramClient, nil
+}
+
+// InvalidateRoleIdentity evicts the credentials derived from the
+// AWSClusterRoleIdentity with the given name together with the clients of the
+// clusters using them.
+func (p *ClientProvider) InvalidateRoleIdentity(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	evicted := 0
+	for key, cached := range p.credentials {
+		if containsString(cached.roleIdentities, name) {
+			delete(p.credentials, key)
+			evicted++
+		}
+	}
+
+	for cluster, clients := range p.clusters {
+		if _, ok := p.credentials[clients.key]; !ok {
+			delete(p.clusters, cluster)
+		}
+	}
+
+	metrics.RecordCredentialsCacheEvictions(evicted)
+}
+
+func (p *ClientProvider) clusterClients(ctx context.Context, cluster types.NamespacedName) (*clusterClients, error) {
+	p.mu.Lock()
+	clients, ok := p.clusters[cluster]
+	if ok && time.Now().Before(clients.expires) {
+		p.mu.Unlock()
+		metrics.RecordCredentialsCacheRequest(metrics.CacheHit)
+		return clients, nil
+	}
+	p.mu.Unlock()
+
+	identity, err := p.credentialsFactory.ClusterIdentity(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cached, ok := p.credentials[identity.Key]
+	if ok {
+		metrics.RecordCredentialsCacheRequest(metrics.CacheHit)
+	} else {
+		metrics.RecordCredentialsCacheRequest(metrics.CacheMiss)
+		log.FromContext(ctx).Info("caching AWS credentials", "cluster", cluster.Name, "roleARN", identity.Key.RoleARN, "region", identity.Key.Region)
+
+		cached = &cachedCredentials{
+			credentials:    identity.Credentials,
+			roleIdentities: identity.RoleIdentities,
+		}
+		p.credentials[identity.Key] = cached
+	}
+	identity.Credentials = cached.credentials
+
+	// Keep the clients if the identity of the cluster didn't change
+	clients, ok = p.clusters[cluster]
+	if ok && clients.key == identity.Key {
+		clients.expires = time.Now().Add(p.clusterTTL)
+		return clients, nil
+	}
+
+	cfg, err := p.credentialsFactory.Config(ctx, cluster.Name, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	clients = &clusterClients{
+		key:      identity.Key,
+		expires:  time.Now().Add(p.clusterTTL),
+		config:   cfg,
+		identity: identity,
+	}
+	p.clusters[cluster] = clients
+
+	return clients, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+var _ = Describe("ClientProvider", func() {
+	const (
+		region          = "eu-central-1"
+		sourceAccount   = "111111111111"
+		clusterAccount  = "222222222222"
+		rotatedAccount  = "333333333333"
+		roleIdentityRef = "role"
+	)
+
+	var (
+		ctx context.Context
+
+		server    *fakeaws.Server
+		k8sClient client.Client
+		provider  *aws.ClientProvider
+	)
+
+	roleARN := func(account string) string {
+		return fmt.Sprintf("arn:aws:iam::%s:role/test", account)
+	}
+
+	createAWSCluster := func(name string) types.NamespacedName {
+		awsCluster := &capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: capa.AWSClusterSpec{
+				Region: region,
+				IdentityRef: &capa.AWSIdentityReference{
+					Name: roleIdentityRef,
+					Kind: capa.ClusterRoleIdentityKind,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, awsCluster)).To(Succeed())
+		return types.NamespacedName{Name: name, Namespace: "test"}
+	}
+
+	describeVPCs := func(cluster types.NamespacedName) {
+		client, err := provider.EC2(ctx, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		server = fakeaws.NewServer(region)
+		DeferCleanup(server.Close)
+
+		Expect(os.Setenv("AWS_ACCESS_KEY_ID", fakeaws.AccessKey(sourceAccount))).To(Succeed())
+		Expect(os.Setenv("AWS_SECRET_ACCESS_KEY", "fake-secret-access-key")).To(Succeed())
+		Expect(os.Setenv("AWS_EC2_METADATA_DISABLED", "true")).To(Succeed())
+
+		scheme := runtime.NewScheme()
+		Expect(capa.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		identity := &capa.AWSClusterRoleIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: roleIdentityRef},
+			Spec: capa.AWSClusterRoleIdentitySpec{
+				AWSRoleSpec: capa.AWSRoleSpec{RoleArn: roleARN(clusterAccount)},
+			},
+		}
+		Expect(k8sClient.Create(ctx, identity)).To(Succeed())
+
+		factory := aws.NewCredentialsFactory(k8sClient, "giantswarm", aws.EndpointOptions{URL: server.URL()})
+		provider = aws.NewClientProvider(factory, time.Hour)
+	})
+
+	It("shares the credentials of clusters with the same identity", func() {
+		describeVPCs(createAWSCluster("cluster-1"))
+		describeVPCs(createAWSCluster("cluster-2"))
+
+		Expect(server.AssumedRoles()).To(HaveLen(1))
+	})
+
+	It("reuses the clients of a cluster", func() {
+		cluster := createAWSCluster("cluster-1")
+
+		first, err := provider.EC2(ctx, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := provider.EC2(ctx, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+	})
+
+	It("is safe for concurrent use", func() {
+		clusters := []types.NamespacedName{createAWSCluster("cluster-1"), createAWSCluster("cluster-2")}
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			for _, cluster := range clusters {
+				wg.Add(1)
+				go func(client *aws.EC2Client) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					_, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{})
+					Expect(err).NotTo(HaveOccurred())
+				}(aws.NewEC2Client(provider, cluster))
+			}
+		}
+		wg.Wait()
+
+		Expect(server.AssumedRoles()).NotTo(BeEmpty())
+	})
+
+	It("returns an error if the identity of the cluster can't be resolved", func() {
+		_, err := provider.EC2(ctx, types.NamespacedName{Name: "missing", Namespace: "test"})
+		Expect(err).To(HaveOccurred())
+
+		_, err = provider.SNS(ctx, types.NamespacedName{Name: "missing", Namespace: "test"})
+		Expect(err).To(HaveOccurred())
+
+		_, err = provider.RAM(ctx, types.NamespacedName{Name: "missing", Namespace: "test"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("resolves the RAM client of a cluster on every call", func() {
+		cluster := types.NamespacedName{Name: "cluster-1", Namespace: "test"}
+		ramClient := aws.NewClusterRAMClient(provider, cluster)
+
+		_, err := ramClient.DeleteResourceSharesOf(ctx, "arn:aws:ec2:eu-central-1:222222222222:prefix-list/pl-1")
+		Expect(err).To(HaveOccurred())
+
+		createAWSCluster(cluster.Name)
+		_, err = ramClient.ApplyResourceShare(ctx, aws.ResourceShare{
+			Name:              "share",
+			ResourceArns:      []string{"arn:aws:ec2:eu-central-1:222222222222:prefix-list/pl-1"},
+			ExternalAccountID: sourceAccount,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(server.ResourceShares()).To(ConsistOf(HaveField("OwningAccountID", clusterAccount)))
+	})
+
+	When("the AWSClusterRoleIdentity changes", func() {
+		var cluster types.NamespacedName
+
+		BeforeEach(func() {
+			cluster = createAWSCluster("cluster-1")
+			describeVPCs(cluster)
+
+			identity := &capa.AWSClusterRoleIdentity{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: roleIdentityRef}, identity)).To(Succeed())
+			identity.Spec.RoleArn = roleARN(rotatedAccount)
+			Expect(k8sClient.Update(ctx, identity)).To(Succeed())
+		})
+
+		It("keeps the cached credentials until the identity is invalidated", func() {
+			describeVPCs(cluster)
+			Expect(server.AssumedRoles()).To(HaveLen(1))
+
+			provider.InvalidateRoleIdentity(roleIdentityRef)
+			describeVPCs(cluster)
+
+			assumedRoles := server.AssumedRoles()
+			Expect(assumedRoles).To(HaveLen(2))
+			Expect(assumedRoles[1].RoleArn).To(Equal(roleARN(rotatedAccount)))
+		})
+
+		It("uses the new identity for RAM once the identity is invalidated", func() {
+			provider.InvalidateRoleIdentity(roleIdentityRef)
+
+			_, err := aws.NewClusterRAMClient(provider, cluster).ApplyResourceShare(ctx, aws.ResourceShare{
+				Name:              "share",
+				ResourceArns:      []string{"arn:aws:ec2:eu-central-1:333333333333:prefix-list/pl-1"},
+				ExternalAccountID: sourceAccount,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ResourceShares()).To(ConsistOf(HaveField("OwningAccountID", rotatedAccount)))
+		})
+
+		It("ignores the invalidation of other identities", func() {
+			provider.InvalidateRoleIdentity("other")
+			describeVPCs(cluster)
+
+			Expect(server.AssumedRoles()).To(HaveLen(1))
+		})
+	})
+
+	When("the source identity of the AWSClusterRoleIdentity changes", func() {
+		const sourceIdentityRef = "source"
+
+		var cluster types.NamespacedName
+
+		BeforeEach(func() {
+			source := &capa.AWSClusterRoleIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: sourceIdentityRef, Generation: 1},
+				Spec: capa.AWSClusterRoleIdentitySpec{
+					AWSRoleSpec: capa.AWSRoleSpec{RoleArn: roleARN(sourceAccount)},
+				},
+			}
+			Expect(k8sClient.Create(ctx, source)).To(Succeed())
+
+			identity := &capa.AWSClusterRoleIdentity{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: roleIdentityRef}, identity)).To(Succeed())
+			identity.Spec.SourceIdentityRef = &capa.AWSIdentityReference{Name: sourceIdentityRef, Kind: capa.ClusterRoleIdentityKind}
+			Expect(k8sClient.Update(ctx, identity)).To(Succeed())
+
+			// Resolve the identity of the cluster on every call
+			provider = aws.NewClientProvider(aws.NewCredentialsFactory(k8sClient, "giantswarm", aws.EndpointOptions{URL: server.URL()}), 0)
+
+			cluster = createAWSCluster("cluster-1")
+			describeVPCs(cluster)
+			Expect(server.AssumedRoles()).To(HaveLen(2))
+
+			// The fake client doesn't maintain the generation
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: sourceIdentityRef}, source)).To(Succeed())
+			source.Spec.RoleArn = roleARN(rotatedAccount)
+			source.Generation = 2
+			Expect(k8sClient.Update(ctx, source)).To(Succeed())
+		})
+
+		It("assumes the role through the new source identity", func() {
+			describeVPCs(cluster)
+
+			assumedRoles := server.AssumedRoles()
+			Expect(assumedRoles).To(HaveLen(4))
+			Expect(assumedRoles[2].RoleArn).To(Equal(roleARN(rotatedAccount)))
+			Expect(assumedRoles[3].RoleArn).To(Equal(roleARN(clusterAccount)))
+		})
+	})
+})
@@ -0,0 +1,308 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	credentialsv1 "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultSessionName is the role session name used if the
+	// AWSClusterRoleIdentity doesn't set one.
+	DefaultSessionName = "aws-network-topology-operator"
+
+	// maxRoleChainLength limits the number of roles assumed through
+	// SourceIdentityRef to break reference cycles.
+	maxRoleChainLength = 5
+
+	// Keys of the secret referenced by an AWSClusterStaticIdentity
+	staticIdentityAccessKeyID     = "AccessKeyID"
+	staticIdentitySecretAccessKey = "SecretAccessKey"
+	staticIdentitySessionToken    = "SessionToken"
+)
+
+// IdentityKey identifies the credentials of an Identity. Clusters with the same
+// IdentityKey share their credentials.
+type IdentityKey struct {
+	RoleARN    string
+	ExternalID string
+	Region     string
+
+	// StaticIdentity is the name of the AWSClusterStaticIdentity providing the
+	// credentials if no role is assumed.
+	StaticIdentity string
+
+	// Chain lists the AWSClusterStaticIdentity and AWSClusterRoleIdentities the
+	// credentials are derived from, starting with the source of the role chain,
+	// together with their generation. A change of any of them changes the key,
+	// so credentials assumed through an outdated source aren't shared.
+	Chain string
+}
+
+// Identity is the AWS identity of a cluster.
+type Identity struct {
+	// Key of the credentials of the identity.
+	Key IdentityKey
+
+	// RoleIdentities are the names of the AWSClusterRoleIdentities the
+	// credentials were derived from, starting with the source of the role chain.
+	RoleIdentities []string
+
+	// AccountID of the identity. It is "unknown" if it can't be told from
+	// the identity without calling AWS, e.g. for static credentials.
+	AccountID string
+
+	// Region of the cluster. The region of the environment is used if empty.
+	Region string
+
+	// Credentials of the identity.
+	Credentials aws.CredentialsProvider
+}
+
+// CredentialsFactory builds the AWS credentials of clusters from the CAPA
+// identity referenced by their AWSCluster, following the same semantics as
+// CAPA:
+//   - AWSClusterControllerIdentity uses the credentials of the operator
+//   - AWSClusterStaticIdentity uses the credentials of the referenced secret
+//   - AWSClusterRoleIdentity assumes the role with the credentials of its
+//     SourceIdentityRef, or of the operator if it isn't set
+type CredentialsFactory struct {
+	client          client.Reader
+	secretNamespace string
+	endpointOptions EndpointOptions
+}
+
+// NewCredentialsFactory creates a CredentialsFactory. The secrets of
+// AWSClusterStaticIdentities are read from the secretNamespace.
+func NewCredentialsFactory(client client.Reader, secretNamespace string, endpointOptions EndpointOptions) *CredentialsFactory {
+	return &CredentialsFactory{
+		client:          client,
+		secretNamespace: secretNamespace,
+		endpointOptions: endpointOptions,
+	}
+}
+
+// ClusterIdentity returns the identity of the AWSCluster with the given
+// namespace/name.
+func (f *CredentialsFactory) ClusterIdentity(ctx context.Context, cluster types.NamespacedName) (*Identity, error) {
+	awsCluster := &capa.AWSCluster{}
+	if err := f.client.Get(ctx, cluster, awsCluster); err != nil {
+		return nil, errors.Wrapf(err, "failed to get AWSCluster %s", cluster)
+	}
+
+	identityRef := awsCluster.Spec.IdentityRef
+	if identityRef == nil {
+		// CAPA falls back to the controller identity
+		identityRef = &capa.AWSIdentityReference{
+			Name: capa.AWSClusterControllerIdentityName,
+			Kind: capa.ControllerIdentityKind,
+		}
+	}
+
+	identity, err := f.identity(ctx, cluster.Name, awsCluster.Spec.Region, identityRef, 0)
+	if err != nil {
+		return nil, err
+	}
+	identity.Credentials = aws.NewCredentialsCache(identity.Credentials)
+
+	return identity, nil
+}
+
+// ClusterConfig returns the AWS SDK config for the identity and region of the
+// AWSCluster with the given namespace/name.
+func (f *CredentialsFactory) ClusterConfig(ctx context.Context, cluster types.NamespacedName) (aws.Config, error) {
+	identity, err := f.ClusterIdentity(ctx, cluster)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	return f.Config(ctx, cluster.Name, identity)
+}
+
+// Config returns the AWS SDK config for the identity, tracing the API calls
+// as calls of the cluster with the given name.
+func (f *CredentialsFactory) Config(ctx context.Context, clusterName string, identity *Identity) (aws.Config, error) {
+	return loadConfig(ctx, identity.Region, f.endpointOptions,
+		config.WithCredentialsProvider(identity.Credentials),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			withTracing(clusterName, identity.AccountID),
+			withMetrics(identity.AccountID),
+		}),
+	)
+}
+
+func (f *CredentialsFactory) identity(ctx context.Context, clusterName, region string, ref *capa.AWSIdentityReference, chainLength int) (*Identity, error) {
+	switch ref.Kind {
+	case capa.ControllerIdentityKind:
+		cfg, err := loadConfig(ctx, region, f.endpointOptions)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load AWS SDK config")
+		}
+		return &Identity{
+			Key:         IdentityKey{Region: region},
+			AccountID:   getAccountID(""),
+			Region:      region,
+			Credentials: cfg.Credentials,
+		}, nil
+
+	case capa.ClusterStaticIdentityKind:
+		return f.staticIdentity(ctx, region, ref.Name)
+
+	case capa.ClusterRoleIdentityKind:
+		if chainLength >= maxRoleChainLength {
+			return nil, errors.Errorf("role chain of AWSClusterRoleIdentity %s exceeds %d roles", ref.Name, maxRoleChainLength)
+		}
+		return f.roleIdentity(ctx, clusterName, region, ref.Name, chainLength)
+	}
+
+	return nil, errors.Errorf("unsupported identity kind %q", ref.Kind)
+}
+
+func (f *CredentialsFactory) staticIdentity(ctx context.Context, region, name string) (*Identity, error) {
+	identity := &capa.AWSClusterStaticIdentity{}
+	if err := f.client.Get(ctx, types.NamespacedName{Name: name}, identity); err != nil {
+		return nil, errors.Wrapf(err, "failed to get AWSClusterStaticIdentity %s", name)
+	}
+
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{Name: identity.Spec.SecretRef, Namespace: f.secretNamespace}
+	if err := f.client.Get(ctx, secretName, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret %s of AWSClusterStaticIdentity %s", secretName, name)
+	}
+
+	accessKeyID := string(secret.Data[staticIdentityAccessKeyID])
+	secretAccessKey := string(secret.Data[staticIdentitySecretAccessKey])
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("secret %s of AWSClusterStaticIdentity %s is missing %s or %s", secretName, name, staticIdentityAccessKeyID, staticIdentitySecretAccessKey)
+	}
+
+	return &Identity{
+		Key:         IdentityKey{Region: region, StaticIdentity: name, Chain: chainLink(capa.ClusterStaticIdentityKind, name, identity.Generation)},
+		AccountID:   getAccountID(""),
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, string(secret.Data[staticIdentitySessionToken])),
+	}, nil
+}
+
+func (f *CredentialsFactory) roleIdentity(ctx context.Context, clusterName, region, name string, chainLength int) (*Identity, error) {
+	identity := &capa.AWSClusterRoleIdentity{}
+	if err := f.client.Get(ctx, types.NamespacedName{Name: name}, identity); err != nil {
+		return nil, errors.Wrapf(err, "failed to get AWSClusterRoleIdentity %s", name)
+	}
+
+	sourceRef := identity.Spec.SourceIdentityRef
+	if sourceRef == nil {
+		sourceRef = &capa.AWSIdentityReference{
+			Name: capa.AWSClusterControllerIdentityName,
+			Kind: capa.ControllerIdentityKind,
+		}
+	}
+	source, err := f.identity(ctx, clusterName, region, sourceRef, chainLength+1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get source identity of AWSClusterRoleIdentity %s", name)
+	}
+
+	roleARN := identity.Spec.RoleArn
+	cfg, err := loadConfig(ctx, region, f.endpointOptions,
+		config.WithCredentialsProvider(source.Credentials),
+		config.WithAPIOptions([]func(*middleware.Stack) error{withTracing(clusterName, getAccountID(roleARN))}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load AWS SDK config")
+	}
+
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = DefaultSessionName
+		if identity.Spec.SessionName != "" {
+			o.RoleSessionName = identity.Spec.SessionName
+		}
+		if identity.Spec.ExternalID != "" {
+			o.ExternalID = aws.String(identity.Spec.ExternalID)
+		}
+		if identity.Spec.DurationSeconds > 0 {
+			o.Duration = time.Duration(identity.Spec.DurationSeconds) * time.Second
+		}
+		if identity.Spec.InlinePolicy != "" {
+			o.Policy = aws.String(identity.Spec.InlinePolicy)
+		}
+		o.PolicyARNs = toPolicyDescriptors(identity.Spec.PolicyARNs)
+	})
+
+	chain := chainLink(capa.ClusterRoleIdentityKind, name, identity.Generation)
+	if source.Key.Chain != "" {
+		chain = source.Key.Chain + " > " + chain
+	}
+
+	return &Identity{
+		Key:            IdentityKey{RoleARN: roleARN, ExternalID: identity.Spec.ExternalID, Region: region, Chain: chain},
+		RoleIdentities: append(source.RoleIdentities, name),
+		AccountID:      getAccountID(roleARN),
+		Region:         region,
+		Credentials:    aws.NewCredentialsCache(provider),
+	}, nil
+}
+
+// chainLink describes an identity of the IdentityKey chain.
+func chainLink(kind capa.AWSIdentityKind, name string, generation int64) string {
+	return fmt.Sprintf("%s/%s@%d", kind, name, generation)
+}
+
+func toPolicyDescriptors(policyARNs []string) []ststypes.PolicyDescriptorType {
+	if len(policyARNs) == 0 {
+		return nil
+	}
+
+	descriptors := []ststypes.PolicyDescriptorType{}
+	for _, arn := range policyARNs {
+		descriptors = append(descriptors, ststypes.PolicyDescriptorType{Arn: aws.String(arn)})
+	}
+	return descriptors
+}
+
+// V1Credentials adapts the credentials of the identity to the AWS SDK v1 used
+// by the RAM client.
+func (i *Identity) V1Credentials() *credentialsv1.Credentials {
+	return credentialsv1.NewCredentials(&v1CredentialsProvider{provider: i.Credentials})
+}
+
+type v1CredentialsProvider struct {
+	provider    aws.CredentialsProvider
+	credentials aws.Credentials
+}
+
+func (p *v1CredentialsProvider) Retrieve() (credentialsv1.Value, error) {
+	return p.RetrieveWithContext(context.Background())
+}
+
+func (p *v1CredentialsProvider) RetrieveWithContext(ctx credentialsv1.Context) (credentialsv1.Value, error) {
+	creds, err := p.provider.Retrieve(ctx)
+	if err != nil {
+		return credentialsv1.Value{}, err
+	}
+	p.credentials = creds
+
+	return credentialsv1.Value{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		ProviderName:    creds.Source,
+	}, nil
+}
+
+func (p *v1CredentialsProvider) IsExpired() bool {
+	return p.credentials.Expired()
+}
@@ -0,0 +1,252 @@
+package aws_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+var _ = Describe("CredentialsFactory", func() {
+	const (
+		region          = "eu-central-1"
+		secretNamespace = "giantswarm"
+		sourceAccount   = "111111111111"
+		clusterAccount  = "222222222222"
+	)
+
+	var (
+		ctx context.Context
+
+		server    *fakeaws.Server
+		k8sClient client.Client
+		factory   *aws.CredentialsFactory
+
+		cluster types.NamespacedName
+	)
+
+	roleARN := func(account string) string {
+		return fmt.Sprintf("arn:aws:iam::%s:role/test", account)
+	}
+
+	createAWSCluster := func(identityRef *capa.AWSIdentityReference) {
+		awsCluster := &capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name,
+				Namespace: cluster.Namespace,
+			},
+			Spec: capa.AWSClusterSpec{
+				Region:      region,
+				IdentityRef: identityRef,
+			},
+		}
+		Expect(k8sClient.Create(ctx, awsCluster)).To(Succeed())
+	}
+
+	createRoleIdentity := func(name string, spec capa.AWSClusterRoleIdentitySpec) *capa.AWSIdentityReference {
+		identity := &capa.AWSClusterRoleIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       spec,
+		}
+		Expect(k8sClient.Create(ctx, identity)).To(Succeed())
+		return &capa.AWSIdentityReference{Name: name, Kind: capa.ClusterRoleIdentityKind}
+	}
+
+	retrieveAccessKey := func() string {
+		identity, err := factory.ClusterIdentity(ctx, cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		creds, err := identity.Credentials.Retrieve(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return creds.AccessKeyID
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		server = fakeaws.NewServer(region)
+		DeferCleanup(server.Close)
+
+		// The controller identity uses the credentials of the environment
+		Expect(os.Setenv("AWS_ACCESS_KEY_ID", fakeaws.AccessKey(sourceAccount))).To(Succeed())
+		Expect(os.Setenv("AWS_SECRET_ACCESS_KEY", "fake-secret-access-key")).To(Succeed())
+		Expect(os.Setenv("AWS_EC2_METADATA_DISABLED", "true")).To(Succeed())
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(capa.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		factory = aws.NewCredentialsFactory(k8sClient, secretNamespace, aws.EndpointOptions{URL: server.URL()})
+		cluster = types.NamespacedName{Name: "test-cluster", Namespace: "test"}
+	})
+
+	When("the AWSCluster doesn't reference an identity", func() {
+		BeforeEach(func() {
+			createAWSCluster(nil)
+		})
+
+		It("uses the controller identity", func() {
+			Expect(retrieveAccessKey()).To(Equal(fakeaws.AccessKey(sourceAccount)))
+			Expect(server.AssumedRoles()).To(BeEmpty())
+		})
+	})
+
+	When("the AWSCluster references an AWSClusterRoleIdentity", func() {
+		BeforeEach(func() {
+			createAWSCluster(createRoleIdentity("role", capa.AWSClusterRoleIdentitySpec{
+				AWSRoleSpec: capa.AWSRoleSpec{
+					RoleArn:         roleARN(clusterAccount),
+					SessionName:     "test-session",
+					DurationSeconds: 1800,
+				},
+				ExternalID: "test-external-id",
+			}))
+		})
+
+		It("assumes the role with the external ID, session name and duration", func() {
+			identity, err := factory.ClusterIdentity(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity.AccountID).To(Equal(clusterAccount))
+			Expect(identity.Region).To(Equal(region))
+
+			creds, err := identity.Credentials.Retrieve(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(creds.AccessKeyID).To(Equal(fakeaws.AccessKey(clusterAccount)))
+
+			Expect(server.AssumedRoles()).To(ConsistOf(fakeaws.AssumedRole{
+				Account:         sourceAccount,
+				RoleArn:         roleARN(clusterAccount),
+				RoleSessionName: "test-session",
+				ExternalID:      "test-external-id",
+				DurationSeconds: "1800",
+			}))
+		})
+
+		It("returns a config for the region of the cluster", func() {
+			cfg, err := factory.ClusterConfig(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Region).To(Equal(region))
+
+			server.AddVPC(clusterAccount, ec2types.Vpc{VpcId: awssdk.String("vpc-test")})
+			out, err := ec2.NewFromConfig(cfg).DescribeVpcs(ctx, &ec2.DescribeVpcsInput{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Vpcs).To(ConsistOf(HaveField("VpcId", PointTo(Equal("vpc-test")))))
+		})
+
+		It("adapts the credentials to the AWS SDK v1", func() {
+			identity, err := factory.ClusterIdentity(ctx, cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			creds, err := identity.V1Credentials().Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(creds.AccessKeyID).To(Equal(fakeaws.AccessKey(clusterAccount)))
+			Expect(creds.SessionToken).NotTo(BeEmpty())
+		})
+	})
+
+	When("the AWSClusterRoleIdentity doesn't set a session name", func() {
+		BeforeEach(func() {
+			createAWSCluster(createRoleIdentity("role", capa.AWSClusterRoleIdentitySpec{
+				AWSRoleSpec: capa.AWSRoleSpec{RoleArn: roleARN(clusterAccount)},
+			}))
+		})
+
+		It("uses the default session name", func() {
+			Expect(retrieveAccessKey()).To(Equal(fakeaws.AccessKey(clusterAccount)))
+			Expect(server.AssumedRoles()).To(ConsistOf(HaveField("RoleSessionName", aws.DefaultSessionName)))
+		})
+	})
+
+	When("the AWSClusterRoleIdentity references a source identity", func() {
+		const chainAccount = "333333333333"
+
+		BeforeEach(func() {
+			source := createRoleIdentity("source", capa.AWSClusterRoleIdentitySpec{
+				AWSRoleSpec: capa.AWSRoleSpec{RoleArn: roleARN(chainAccount)},
+			})
+			createAWSCluster(createRoleIdentity("role", capa.AWSClusterRoleIdentitySpec{
+				AWSRoleSpec:       capa.AWSRoleSpec{RoleArn: roleARN(clusterAccount)},
+				SourceIdentityRef: source,
+			}))
+		})
+
+		It("assumes the role with the credentials of the source identity", func() {
+			Expect(retrieveAccessKey()).To(Equal(fakeaws.AccessKey(clusterAccount)))
+			Expect(server.AssumedRoles()).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Account": Equal(sourceAccount),
+					"RoleArn": Equal(roleARN(chainAccount)),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Account": Equal(chainAccount),
+					"RoleArn": Equal(roleARN(clusterAccount)),
+				}),
+			))
+		})
+	})
+
+	When("the source identities form a cycle", func() {
+		BeforeEach(func() {
+			createAWSCluster(createRoleIdentity("role", capa.AWSClusterRoleIdentitySpec{
+				AWSRoleSpec: capa.AWSRoleSpec{RoleArn: roleARN(clusterAccount)},
+				SourceIdentityRef: &capa.AWSIdentityReference{
+					Name: "role",
+					Kind: capa.ClusterRoleIdentityKind,
+				},
+			}))
+		})
+
+		It("returns an error", func() {
+			_, err := factory.ClusterIdentity(ctx, cluster)
+			Expect(err).To(MatchError(ContainSubstring("role chain")))
+		})
+	})
+
+	When("the AWSCluster references an AWSClusterStaticIdentity", func() {
+		BeforeEach(func() {
+			identity := &capa.AWSClusterStaticIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: "static"},
+				Spec:       capa.AWSClusterStaticIdentitySpec{SecretRef: "static-credentials"},
+			}
+			Expect(k8sClient.Create(ctx, identity)).To(Succeed())
+
+			createAWSCluster(&capa.AWSIdentityReference{Name: "static", Kind: capa.ClusterStaticIdentityKind})
+		})
+
+		It("uses the credentials of the secret", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "static-credentials", Namespace: secretNamespace},
+				Data: map[string][]byte{
+					"AccessKeyID":     []byte(fakeaws.AccessKey(clusterAccount)),
+					"SecretAccessKey": []byte("fake-secret-access-key"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			Expect(retrieveAccessKey()).To(Equal(fakeaws.AccessKey(clusterAccount)))
+			Expect(server.AssumedRoles()).To(BeEmpty())
+		})
+
+		It("returns an error if the secret doesn't exist", func() {
+			_, err := factory.ClusterIdentity(ctx, cluster)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -3,66 +3,40 @@ package aws
 import (
 	"context"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
-	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/log"
-
-	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
 )
 
+// EC2Client calls the EC2 API of a cluster. It doesn't hold any state besides
+// the cluster and is safe for concurrent use, the clients are cached by the
+// ClientProvider.
 type EC2Client struct {
-	ctx       context.Context
-	ec2Client *ec2.Client
-	k8sClient *k8sclient.Cluster
-	cluster   types.NamespacedName
+	clientProvider *ClientProvider
+	cluster        types.NamespacedName
 }
 
-func NewEC2Client(ctx context.Context, k8sClient *k8sclient.Cluster, cluster types.NamespacedName) *EC2Client {
+func NewEC2Client(clientProvider *ClientProvider, cluster types.NamespacedName) *EC2Client {
 	return &EC2Client{
-		ctx:       ctx,
-		ec2Client: nil,
-		k8sClient: k8sClient,
-		cluster:   cluster,
+		clientProvider: clientProvider,
+		cluster:        cluster,
 	}
 }
 
-func (e *EC2Client) client() (*ec2.Client, error) {
-	if e.ec2Client == nil {
-		logger := log.FromContext(e.ctx)
-
-		logger.Info("assuming ClusterRoleIdentity role of cluster", "cluster", e.cluster.Name)
-
-		identity, err := e.k8sClient.GetAWSClusterRoleIdentity(e.ctx, e.cluster)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get ClusterRoleIdentity of cluster %s", e.cluster.Name)
-		}
-		roleARN := identity.Spec.RoleArn
-
-		cfg, err := config.LoadDefaultConfig(e.ctx)
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to load AWS SDK config")
-		}
-
-		creds := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN)
-
-		cfg, err = config.LoadDefaultConfig(e.ctx, config.WithCredentialsProvider(aws.NewCredentialsCache(creds)))
-		if err != nil {
-			return nil, errors.Wrapf(err, "unable to assume IAM role %s", roleARN)
-		}
-
-		e.ec2Client = ec2.NewFromConfig(cfg)
+// client returns the EC2 client of the cluster from the client provider, so
+// changes of the identity of the cluster are picked up. The identity is looked
+// up with the context of the call.
+func (e *EC2Client) client(ctx context.Context) (*ec2.Client, error) {
+	// The EC2 API of the cluster is served in the region of the cluster
+	client, err := e.clientProvider.EC2(ctx, e.cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get EC2 client of cluster %s", e.cluster.Name)
 	}
-
-	return e.ec2Client, nil
+	return client, nil
 }
 
 func (e *EC2Client) CreateTransitGateway(ctx context.Context, params *ec2.CreateTransitGatewayInput, optFns ...func(*ec2.Options)) (*ec2.CreateTransitGatewayOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +44,7 @@ func (e *EC2Client) CreateTransitGateway(ctx context.Context, params *ec2.Create
 }
 
 func (e *EC2Client) CreateTransitGatewayVpcAttachment(ctx context.Context, params *ec2.CreateTransitGatewayVpcAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +52,7 @@ func (e *EC2Client) CreateTransitGatewayVpcAttachment(ctx context.Context, param
 }
 
 func (e *EC2Client) DeleteTransitGateway(ctx context.Context, params *ec2.DeleteTransitGatewayInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +60,7 @@ func (e *EC2Client) DeleteTransitGateway(ctx context.Context, params *ec2.Delete
 }
 
 func (e *EC2Client) DeleteTransitGatewayVpcAttachment(ctx context.Context, params *ec2.DeleteTransitGatewayVpcAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayVpcAttachmentOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +68,7 @@ func (e *EC2Client) DeleteTransitGatewayVpcAttachment(ctx context.Context, param
 }
 
 func (e *EC2Client) DescribeTransitGateways(ctx context.Context, params *ec2.DescribeTransitGatewaysInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewaysOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -102,15 +76,143 @@ func (e *EC2Client) DescribeTransitGateways(ctx context.Context, params *ec2.Des
 }
 
 func (e *EC2Client) DescribeTransitGatewayVpcAttachments(ctx context.Context, params *ec2.DescribeTransitGatewayVpcAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return client.DescribeTransitGatewayVpcAttachments(ctx, params, optFns...)
 }
 
+func (e *EC2Client) CreateTransitGatewayRouteTable(ctx context.Context, params *ec2.CreateTransitGatewayRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteTableOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateTransitGatewayRouteTable(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DeleteTransitGatewayRouteTable(ctx context.Context, params *ec2.DeleteTransitGatewayRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteTableOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteTransitGatewayRouteTable(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DescribeTransitGatewayRouteTables(ctx context.Context, params *ec2.DescribeTransitGatewayRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayRouteTablesOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DescribeTransitGatewayRouteTables(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DescribeTransitGatewayAttachments(ctx context.Context, params *ec2.DescribeTransitGatewayAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayAttachmentsOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DescribeTransitGatewayAttachments(ctx, params, optFns...)
+}
+
+func (e *EC2Client) AssociateTransitGatewayRouteTable(ctx context.Context, params *ec2.AssociateTransitGatewayRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.AssociateTransitGatewayRouteTableOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.AssociateTransitGatewayRouteTable(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DisassociateTransitGatewayRouteTable(ctx context.Context, params *ec2.DisassociateTransitGatewayRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.DisassociateTransitGatewayRouteTableOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DisassociateTransitGatewayRouteTable(ctx, params, optFns...)
+}
+
+func (e *EC2Client) EnableTransitGatewayRouteTablePropagation(ctx context.Context, params *ec2.EnableTransitGatewayRouteTablePropagationInput, optFns ...func(*ec2.Options)) (*ec2.EnableTransitGatewayRouteTablePropagationOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.EnableTransitGatewayRouteTablePropagation(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DisableTransitGatewayRouteTablePropagation(ctx context.Context, params *ec2.DisableTransitGatewayRouteTablePropagationInput, optFns ...func(*ec2.Options)) (*ec2.DisableTransitGatewayRouteTablePropagationOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DisableTransitGatewayRouteTablePropagation(ctx, params, optFns...)
+}
+
+func (e *EC2Client) GetTransitGatewayAttachmentPropagations(ctx context.Context, params *ec2.GetTransitGatewayAttachmentPropagationsInput, optFns ...func(*ec2.Options)) (*ec2.GetTransitGatewayAttachmentPropagationsOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetTransitGatewayAttachmentPropagations(ctx, params, optFns...)
+}
+
+func (e *EC2Client) CreateTransitGatewayPeeringAttachment(ctx context.Context, params *ec2.CreateTransitGatewayPeeringAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.CreateTransitGatewayPeeringAttachmentOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateTransitGatewayPeeringAttachment(ctx, params, optFns...)
+}
+
+func (e *EC2Client) AcceptTransitGatewayPeeringAttachment(ctx context.Context, params *ec2.AcceptTransitGatewayPeeringAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.AcceptTransitGatewayPeeringAttachmentOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.AcceptTransitGatewayPeeringAttachment(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DeleteTransitGatewayPeeringAttachment(ctx context.Context, params *ec2.DeleteTransitGatewayPeeringAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayPeeringAttachmentOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteTransitGatewayPeeringAttachment(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DescribeTransitGatewayPeeringAttachments(ctx context.Context, params *ec2.DescribeTransitGatewayPeeringAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayPeeringAttachmentsOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DescribeTransitGatewayPeeringAttachments(ctx, params, optFns...)
+}
+
+func (e *EC2Client) CreateTransitGatewayRoute(ctx context.Context, params *ec2.CreateTransitGatewayRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateTransitGatewayRouteOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateTransitGatewayRoute(ctx, params, optFns...)
+}
+
+func (e *EC2Client) DeleteTransitGatewayRoute(ctx context.Context, params *ec2.DeleteTransitGatewayRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayRouteOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteTransitGatewayRoute(ctx, params, optFns...)
+}
+
+func (e *EC2Client) SearchTransitGatewayRoutes(ctx context.Context, params *ec2.SearchTransitGatewayRoutesInput, optFns ...func(*ec2.Options)) (*ec2.SearchTransitGatewayRoutesOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.SearchTransitGatewayRoutes(ctx, params, optFns...)
+}
+
 func (e *EC2Client) CreateRoute(ctx context.Context, params *ec2.CreateRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +220,7 @@ func (e *EC2Client) CreateRoute(ctx context.Context, params *ec2.CreateRouteInpu
 }
 
 func (e *EC2Client) DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +228,7 @@ func (e *EC2Client) DescribeRouteTables(ctx context.Context, params *ec2.Describ
 }
 
 func (e *EC2Client) DeleteRoute(ctx context.Context, params *ec2.DeleteRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +236,7 @@ func (e *EC2Client) DeleteRoute(ctx context.Context, params *ec2.DeleteRouteInpu
 }
 
 func (e *EC2Client) CreateManagedPrefixList(ctx context.Context, params *ec2.CreateManagedPrefixListInput, optFns ...func(*ec2.Options)) (*ec2.CreateManagedPrefixListOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +244,7 @@ func (e *EC2Client) CreateManagedPrefixList(ctx context.Context, params *ec2.Cre
 }
 
 func (e *EC2Client) DescribeManagedPrefixLists(ctx context.Context, params *ec2.DescribeManagedPrefixListsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeManagedPrefixListsOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -150,15 +252,31 @@ func (e *EC2Client) DescribeManagedPrefixLists(ctx context.Context, params *ec2.
 }
 
 func (e *EC2Client) ModifyManagedPrefixList(ctx context.Context, params *ec2.ModifyManagedPrefixListInput, optFns ...func(*ec2.Options)) (*ec2.ModifyManagedPrefixListOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return client.ModifyManagedPrefixList(ctx, params, optFns...)
 }
 
+func (e *EC2Client) DeleteManagedPrefixList(ctx context.Context, params *ec2.DeleteManagedPrefixListInput, optFns ...func(*ec2.Options)) (*ec2.DeleteManagedPrefixListOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteManagedPrefixList(ctx, params, optFns...)
+}
+
+func (e *EC2Client) GetManagedPrefixListAssociations(ctx context.Context, params *ec2.GetManagedPrefixListAssociationsInput, optFns ...func(*ec2.Options)) (*ec2.GetManagedPrefixListAssociationsOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetManagedPrefixListAssociations(ctx, params, optFns...)
+}
+
 func (e *EC2Client) GetManagedPrefixListEntries(ctx context.Context, params *ec2.GetManagedPrefixListEntriesInput, optFns ...func(*ec2.Options)) (*ec2.GetManagedPrefixListEntriesOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -166,9 +284,25 @@ func (e *EC2Client) GetManagedPrefixListEntries(ctx context.Context, params *ec2
 }
 
 func (e *EC2Client) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
-	client, err := e.client()
+	client, err := e.client(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return client.DescribeSubnets(ctx, params, optFns...)
 }
+
+func (e *EC2Client) DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.DescribeVpcs(ctx, params, optFns...)
+}
+
+func (e *EC2Client) ModifyTransitGatewayVpcAttachment(ctx context.Context, params *ec2.ModifyTransitGatewayVpcAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.ModifyTransitGatewayVpcAttachmentOutput, error) {
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.ModifyTransitGatewayVpcAttachment(ctx, params, optFns...)
+}
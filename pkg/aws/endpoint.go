@@ -0,0 +1,128 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// RegionPlaceholder is replaced with the region of the client in endpoint
+// URLs, e.g. https://ec2.{region}.vpce.example.com.
+const RegionPlaceholder = "{region}"
+
+// EndpointOptions configures the endpoints the AWS API clients connect to.
+type EndpointOptions struct {
+	// URL replaces the endpoints of all AWS services without a ServiceURLs
+	// entry, e.g. with a local fake. The default endpoints are used if empty.
+	URL string
+
+	// ServiceURLs replaces the endpoints of single AWS services, e.g. with VPC
+	// endpoints, keyed by the lower case service ID like ec2, ram, sns or sts.
+	ServiceURLs map[string]string
+
+	// UseFIPS resolves the FIPS endpoints of the AWS services.
+	UseFIPS bool
+}
+
+// AddEndpoint adds an endpoint in the format of the --aws-endpoint flag,
+// either a URL replacing the endpoints of all services or service=URL
+// replacing the endpoint of a single service. The URL may contain
+// RegionPlaceholder.
+func (o *EndpointOptions) AddEndpoint(value string) error {
+	service, endpoint, ok := strings.Cut(value, "=")
+	if !ok || strings.Contains(service, "/") || strings.Contains(service, ":") {
+		service, endpoint = "", value
+	}
+
+	endpointURL, err := url.Parse(strings.ReplaceAll(endpoint, RegionPlaceholder, "region"))
+	if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+		return fmt.Errorf("expected URL or service=URL, got %q", value)
+	}
+
+	if service == "" {
+		o.URL = endpoint
+		return nil
+	}
+	if o.ServiceURLs == nil {
+		o.ServiceURLs = map[string]string{}
+	}
+	o.ServiceURLs[strings.ToLower(service)] = endpoint
+	return nil
+}
+
+// endpointURL returns the URL replacing the endpoint of the service in the
+// region. ok is false if the default endpoint is used.
+func (o EndpointOptions) endpointURL(service, region string) (string, bool) {
+	endpoint, ok := o.ServiceURLs[strings.ToLower(service)]
+	if !ok {
+		endpoint = o.URL
+	}
+	if endpoint == "" {
+		return "", false
+	}
+	return strings.ReplaceAll(endpoint, RegionPlaceholder, region), true
+}
+
+func (o EndpointOptions) isSet() bool {
+	return o.URL != "" || len(o.ServiceURLs) > 0
+}
+
+// loadConfig loads the AWS SDK config for the region with the endpoint
+// options applied. The region of the environment is used if region is empty.
+func loadConfig(ctx context.Context, region string, endpointOptions EndpointOptions, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+	if region != "" {
+		optFns = append(optFns, config.WithRegion(region))
+	}
+	if endpointOptions.UseFIPS {
+		optFns = append(optFns, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+	if endpointOptions.isSet() {
+		// Services without a replaced endpoint fall back to their default
+		// endpoint on EndpointNotFoundError
+		optFns = append(optFns, config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+				endpointURL, ok := endpointOptions.endpointURL(service, region)
+				if !ok {
+					return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+				}
+				return aws.Endpoint{
+					URL:           endpointURL,
+					SigningRegion: region,
+					Source:        aws.EndpointSourceCustom,
+				}, nil
+			},
+		)))
+	}
+
+	return config.LoadDefaultConfig(ctx, optFns...)
+}
+
+// NewSession creates an AWS SDK v1 session with the endpoint options applied,
+// as used by the RAM client.
+func NewSession(endpointOptions EndpointOptions) (*session.Session, error) {
+	cfg := &awssdk.Config{}
+	if endpointOptions.UseFIPS {
+		cfg.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+	if endpointOptions.isSet() {
+		cfg.EndpointResolver = endpoints.ResolverFunc(func(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+			endpointURL, ok := endpointOptions.endpointURL(service, region)
+			if !ok {
+				return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
+			}
+			return endpoints.ResolvedEndpoint{
+				URL:           endpointURL,
+				SigningRegion: region,
+			}, nil
+		})
+	}
+
+	return session.NewSession(cfg)
+}
@@ -0,0 +1,137 @@
+package aws_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+var _ = Describe("EndpointOptions", func() {
+	const (
+		region  = "eu-central-1"
+		account = "111111111111"
+	)
+
+	var (
+		ctx     context.Context
+		cluster types.NamespacedName
+	)
+
+	newProvider := func(endpointOptions aws.EndpointOptions) *aws.ClientProvider {
+		scheme := runtime.NewScheme()
+		Expect(capa.AddToScheme(scheme)).To(Succeed())
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: cluster.Namespace},
+			Spec:       capa.AWSClusterSpec{Region: region},
+		}).Build()
+
+		factory := aws.NewCredentialsFactory(k8sClient, "giantswarm", endpointOptions)
+		return aws.NewClientProvider(factory, time.Hour)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		cluster = types.NamespacedName{Name: "mc", Namespace: "test"}
+
+		Expect(os.Setenv("AWS_ACCESS_KEY_ID", fakeaws.AccessKey(account))).To(Succeed())
+		Expect(os.Setenv("AWS_SECRET_ACCESS_KEY", "fake-secret-access-key")).To(Succeed())
+		Expect(os.Setenv("AWS_EC2_METADATA_DISABLED", "true")).To(Succeed())
+	})
+
+	Describe("AddEndpoint", func() {
+		It("replaces the endpoints of all services with a URL", func() {
+			options := aws.EndpointOptions{}
+			Expect(options.AddEndpoint("https://vpce.example.com")).To(Succeed())
+			Expect(options.URL).To(Equal("https://vpce.example.com"))
+			Expect(options.ServiceURLs).To(BeEmpty())
+		})
+
+		It("replaces the endpoint of a single service with service=URL", func() {
+			options := aws.EndpointOptions{}
+			Expect(options.AddEndpoint("EC2=https://ec2.{region}.vpce.example.com")).To(Succeed())
+			Expect(options.AddEndpoint("ram=https://ram.vpce.example.com/?a=b")).To(Succeed())
+			Expect(options.URL).To(BeEmpty())
+			Expect(options.ServiceURLs).To(Equal(map[string]string{
+				"ec2": "https://ec2.{region}.vpce.example.com",
+				"ram": "https://ram.vpce.example.com/?a=b",
+			}))
+		})
+
+		It("rejects values without a URL", func() {
+			options := aws.EndpointOptions{}
+			Expect(options.AddEndpoint("ec2")).NotTo(Succeed())
+			Expect(options.AddEndpoint("ec2=vpce.example.com")).NotTo(Succeed())
+		})
+	})
+
+	When("the endpoint of a single service is replaced", func() {
+		var (
+			ec2Server     *fakeaws.Server
+			defaultServer *fakeaws.Server
+			provider      *aws.ClientProvider
+		)
+
+		BeforeEach(func() {
+			ec2Server = fakeaws.NewServer(region)
+			DeferCleanup(ec2Server.Close)
+			defaultServer = fakeaws.NewServer(region)
+			DeferCleanup(defaultServer.Close)
+
+			provider = newProvider(aws.EndpointOptions{
+				URL:         defaultServer.URL(),
+				ServiceURLs: map[string]string{"ec2": ec2Server.URL()},
+			})
+		})
+
+		It("uses the endpoint for the service", func() {
+			_, err := aws.NewEC2Client(provider, cluster).DescribeVpcs(ctx, &ec2.DescribeVpcsInput{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ec2Server.RequestCount("ec2", "DescribeVpcs")).To(Equal(1))
+			Expect(defaultServer.RequestCount("ec2", "DescribeVpcs")).To(Equal(0))
+		})
+
+		It("uses the endpoint of all services for other services", func() {
+			_, err := aws.NewClusterRAMClient(provider, cluster).DeleteResourceSharesOf(ctx, "arn:aws:ec2:eu-central-1:111111111111:prefix-list/pl-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(defaultServer.RequestCount("ram", "getresourceshareassociations")).To(Equal(1))
+			Expect(ec2Server.RequestCount("ram", "getresourceshareassociations")).To(Equal(0))
+		})
+	})
+
+	It("replaces the region placeholder with the region of the client", func() {
+		paths := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.Path
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		DeferCleanup(server.Close)
+
+		provider := newProvider(aws.EndpointOptions{
+			ServiceURLs: map[string]string{"ec2": server.URL + "/" + aws.RegionPlaceholder},
+		})
+		client, err := provider.EC2(ctx, cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{}, func(o *ec2.Options) {
+			o.RetryMaxAttempts = 1
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(<-paths).To(Equal("/" + region + "/"))
+	})
+})
@@ -6,10 +6,14 @@ import (
 )
 
 const (
-	ErrAssociationNotFound = "InvalidAssociationID.NotFound"
-	ErrRouteTableNotFound  = "InvalidRouteTableID.NotFound"
-	ErrSubnetNotFound      = "InvalidSubnetID.NotFound"
-	ErrVPCNotFound         = "InvalidVpcID.NotFound"
+	ErrAssociationNotFound       = "InvalidAssociationID.NotFound"
+	ErrIncorrectState            = "IncorrectState"
+	ErrPrefixListNotFound        = "InvalidPrefixListID.NotFound"
+	ErrPrefixListVersionMismatch = "PrefixListVersionMismatch"
+	ErrRouteTableNotFound        = "InvalidRouteTableID.NotFound"
+	ErrSubnetNotFound            = "InvalidSubnetID.NotFound"
+	ErrTransitGatewayNotFound    = "InvalidTransitGatewayID.NotFound"
+	ErrVPCNotFound               = "InvalidVpcID.NotFound"
 )
 
 func HasErrorCode(err error, code string) bool {
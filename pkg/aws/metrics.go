@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/metrics"
+)
+
+// withMetrics returns the AWS SDK v2 API option recording the duration and errors of all
+// API calls made with the credentials of the account.
+func withMetrics(account string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OperatorMetrics", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			start := time.Now()
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			metrics.ObserveAWSCall(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), account, time.Since(start), err)
+			return out, metadata, err
+		}), middleware.After)
+	}
+}
+
+// metricsHandler records the duration and errors of AWS SDK v1 API calls made with the
+// credentials of the account.
+func metricsHandler(account string) func(*request.Request) {
+	return func(r *request.Request) {
+		metrics.ObserveAWSCall(r.ClientInfo.ServiceID, r.Operation.Name, account, time.Since(r.Time), r.Error)
+	}
+}
+
+func getAccountID(roleARN string) string {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil {
+		return "unknown"
+	}
+	return parsed.AccountID
+}
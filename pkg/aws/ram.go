@@ -5,11 +5,11 @@ import (
 	"fmt"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ram"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -21,31 +21,73 @@ type ResourceShare struct {
 	ExternalAccountID string
 }
 
+//counterfeiter:generate . ResourceShareClient
+type ResourceShareClient interface {
+	DeleteResourceSharesOf(ctx context.Context, resourceARN string) ([]string, error)
+}
+
+// RAMClient manages resource shares either with a fixed RAM client or with the
+// RAM client of a cluster from the client provider. It is safe for concurrent
+// use.
 type RAMClient struct {
 	ramClient *ram.RAM
+
+	clientProvider *ClientProvider
+	cluster        types.NamespacedName
 }
 
 func NewRAMClient(ramClient *ram.RAM) *RAMClient {
-	return &RAMClient{ramClient}
+	return &RAMClient{ramClient: ramClient}
+}
+
+// NewClusterRAMClient creates a RAMClient using the identity of the cluster.
+// The RAM client is requested from the client provider on every call, so
+// changed identities are picked up.
+func NewClusterRAMClient(clientProvider *ClientProvider, cluster types.NamespacedName) *RAMClient {
+	return &RAMClient{
+		clientProvider: clientProvider,
+		cluster:        cluster,
+	}
+}
+
+// client returns the RAM client. Failures aren't cached, so the client is
+// requested again on the next call.
+func (c *RAMClient) client(ctx context.Context) (*ram.RAM, error) {
+	if c.clientProvider == nil {
+		return c.ramClient, nil
+	}
+
+	client, err := c.clientProvider.RAM(ctx, c.cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get RAM client of cluster %s", c.cluster)
+	}
+	return client, nil
 }
 
-func (c *RAMClient) ApplyResourceShare(ctx context.Context, share ResourceShare) error {
+// ApplyResourceShare creates the resource share if it doesn't exist yet and
+// returns its ARN
+func (c *RAMClient) ApplyResourceShare(ctx context.Context, share ResourceShare) (string, error) {
 	logger := c.getLogger(ctx)
 	logger = logger.WithValues("resource-share-name", share.Name, "resource-arns", share.ResourceArns)
 
 	resourceShare, err := c.getResourceShare(ctx, share.Name)
 	if err != nil {
 		logger.Error(err, "failed to get resource share")
-		return errors.WithStack(err)
+		return "", errors.WithStack(err)
 	}
 
 	if resourceShare != nil {
 		logger.Info("resource share already exists")
-		return nil
+		return awssdk.StringValue(resourceShare.ResourceShareArn), nil
+	}
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return "", err
 	}
 
 	logger.Info("creating resource share")
-	_, err = c.ramClient.CreateResourceShare(&ram.CreateResourceShareInput{
+	output, err := client.CreateResourceShare(&ram.CreateResourceShareInput{
 		AllowExternalPrincipals: awssdk.Bool(true),
 		Name:                    awssdk.String(share.Name),
 		Principals:              []*string{awssdk.String(share.ExternalAccountID)},
@@ -53,10 +95,10 @@ func (c *RAMClient) ApplyResourceShare(ctx context.Context, share ResourceShare)
 	})
 	if err != nil {
 		logger.Error(err, "failed to create resource share")
-		return err
+		return "", err
 	}
 
-	return nil
+	return awssdk.StringValue(output.ResourceShare.ResourceShareArn), nil
 }
 
 func (c *RAMClient) DeleteResourceShare(ctx context.Context, name string) error {
@@ -74,17 +116,78 @@ func (c *RAMClient) DeleteResourceShare(ctx context.Context, name string) error
 		return nil
 	}
 
-	_, err = c.ramClient.DeleteResourceShare(&ram.DeleteResourceShareInput{
+	client, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteResourceShare(&ram.DeleteResourceShareInput{
 		ResourceShareArn: resourceShare.ResourceShareArn,
 	})
 	return err
 }
 
+// DeleteResourceSharesOf deletes all resource shares owned by the account the
+// resource is associated with and returns their names
+func (c *RAMClient) DeleteResourceSharesOf(ctx context.Context, resourceARN string) ([]string, error) {
+	logger := c.getLogger(ctx)
+	logger = logger.WithValues("resource-arn", resourceARN)
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	associations := []*ram.ResourceShareAssociation{}
+	input := &ram.GetResourceShareAssociationsInput{
+		AssociationType: awssdk.String(ram.ResourceShareAssociationTypeResource),
+		ResourceArn:     awssdk.String(resourceARN),
+	}
+	for {
+		output, err := client.GetResourceShareAssociations(input)
+		if err != nil {
+			logger.Error(err, "failed to get resource share associations")
+			return nil, errors.WithStack(err)
+		}
+
+		associations = append(associations, output.ResourceShareAssociations...)
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	deleted := []string{}
+	for _, association := range associations {
+		if !isResourceShareAssociated(association) {
+			continue
+		}
+
+		logger.Info("deleting resource share", "resource-share-name", awssdk.StringValue(association.ResourceShareName))
+		_, err := client.DeleteResourceShare(&ram.DeleteResourceShareInput{
+			ResourceShareArn: association.ResourceShareArn,
+		})
+		if err != nil {
+			logger.Error(err, "failed to delete resource share", "resource-share-name", awssdk.StringValue(association.ResourceShareName))
+			return deleted, err
+		}
+		deleted = append(deleted, awssdk.StringValue(association.ResourceShareName))
+	}
+
+	return deleted, nil
+}
+
 func (c *RAMClient) getResourceShare(ctx context.Context, name string) (*ram.ResourceShare, error) {
 	logger := c.getLogger(ctx)
 	logger = logger.WithValues("resource-share-name", name)
 
-	resourceShareOutput, err := c.ramClient.GetResourceShares(&ram.GetResourceSharesInput{
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceShareOutput, err := client.GetResourceShares(&ram.GetResourceSharesInput{
 		Name:          awssdk.String(name),
 		ResourceOwner: awssdk.String(ResourceOwnerSelf),
 	})
@@ -115,19 +218,15 @@ func (c *RAMClient) getLogger(ctx context.Context) logr.Logger {
 	return logger
 }
 
-func AwsRamClientFromARN(sess *session.Session, roleARN, externalID string) *ram.RAM {
-	return ram.New(sess, &awssdk.Config{Credentials: stscreds.NewCredentials(sess, roleARN, configureExternalId(roleARN, externalID))})
-}
-
-func configureExternalId(roleArn, externalId string) func(provider *stscreds.AssumeRoleProvider) {
-	return func(assumeRoleProvider *stscreds.AssumeRoleProvider) {
-		if roleArn != "" {
-			assumeRoleProvider.RoleARN = roleArn
-		}
-		if externalId != "" {
-			assumeRoleProvider.ExternalID = awssdk.String(externalId)
-		}
+func AwsRamClientFromIdentity(sess *session.Session, identity *Identity) *ram.RAM {
+	cfg := &awssdk.Config{Credentials: identity.V1Credentials()}
+	if identity.Region != "" {
+		cfg.Region = awssdk.String(identity.Region)
 	}
+
+	client := ram.New(sess, cfg)
+	client.Handlers.Complete.PushBack(metricsHandler(identity.AccountID))
+	return client
 }
 
 func filterDeletedResourceShares(resourceShares []*ram.ResourceShare) []*ram.ResourceShare {
@@ -141,6 +240,15 @@ func filterDeletedResourceShares(resourceShares []*ram.ResourceShare) []*ram.Res
 	return filtered
 }
 
+func isResourceShareAssociated(association *ram.ResourceShareAssociation) bool {
+	if association.Status == nil {
+		return true
+	}
+
+	status := *association.Status
+	return status == ram.ResourceShareAssociationStatusAssociated || status == ram.ResourceShareAssociationStatusAssociating
+}
+
 func isResourceShareDeleted(resourceShare *ram.ResourceShare) bool {
 	if resourceShare.Status == nil {
 		return false
@@ -3,68 +3,43 @@ package aws
 import (
 	"context"
 	"fmt"
-	"os"
+	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
-	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/log"
-
-	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
 )
 
+// SNSClient publishes messages to the SNS topic with the identity of the
+// management cluster. It is safe for concurrent use.
 type SNSClient struct {
-	ctx               context.Context
-	snsClient         *sns.Client
 	snsTopic          string
-	k8sClient         *k8sclient.Cluster
+	clientProvider    *ClientProvider
 	managementCluster types.NamespacedName
 }
 
-func NewSNSClient(ctx context.Context, snsTopic string, k8sClient *k8sclient.Cluster, managementCluster types.NamespacedName) *SNSClient {
+// IsFIFOTopic returns true if the SNS topic with the given ARN is a FIFO topic.
+func IsFIFOTopic(topicARN string) bool {
+	return strings.HasSuffix(topicARN, ".fifo")
+}
+
+func NewSNSClient(snsTopic string, clientProvider *ClientProvider, managementCluster types.NamespacedName) *SNSClient {
 	return &SNSClient{
-		ctx:               ctx,
-		snsClient:         nil,
 		snsTopic:          snsTopic,
-		k8sClient:         k8sClient,
+		clientProvider:    clientProvider,
 		managementCluster: managementCluster,
 	}
 }
 
-func (s *SNSClient) client() *sns.Client {
-	if s.snsClient == nil {
-		logger := log.FromContext(s.ctx)
-
-		logger.Info("assuming ClusterRoleIdentity role of management cluster")
-
-		identity, err := s.k8sClient.GetAWSClusterRoleIdentity(s.ctx, s.managementCluster)
-		if err != nil {
-			logger.Error(err, "failed to get ClusterRoleIdentity of management cluster")
-			os.Exit(1)
-		}
-		roleARN := identity.Spec.RoleArn
-
-		cfg, err := config.LoadDefaultConfig(context.TODO())
-		if err != nil {
-			logger.Error(err, "unable to load AWS SDK config")
-			os.Exit(1)
-		}
-
-		creds := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN)
-
-		cfg, err = config.LoadDefaultConfig(context.TODO(), config.WithCredentialsProvider(aws.NewCredentialsCache(creds)))
-		if err != nil {
-			logger.Error(err, "unable to assume IAM role")
-			os.Exit(1)
-		}
-
-		s.snsClient = sns.NewFromConfig(cfg)
+// client returns the SNS client of the management cluster from the client
+// provider. Failures aren't cached, so the client is requested again on the
+// next call.
+func (s *SNSClient) client(ctx context.Context) (*sns.Client, error) {
+	client, err := s.clientProvider.SNS(ctx, s.managementCluster)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get SNS client of management cluster")
 	}
-
-	return s.snsClient
+	return client, nil
 }
 
 func (s *SNSClient) PublishSNSMessage(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
@@ -73,5 +48,16 @@ func (s *SNSClient) PublishSNSMessage(ctx context.Context, params *sns.PublishIn
 		return nil, fmt.Errorf("no SNS topic provided")
 	}
 
-	return s.client().Publish(ctx, params, optFns...)
+	// Standard topics reject the message group and deduplication IDs of
+	// FIFO topics
+	if !IsFIFOTopic(s.snsTopic) {
+		params.MessageGroupId = nil
+		params.MessageDeduplicationId = nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Publish(ctx, params, optFns...)
 }
@@ -0,0 +1,80 @@
+package aws_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+var _ = Describe("SNSClient", func() {
+	const (
+		region  = "eu-central-1"
+		account = "111111111111"
+	)
+
+	var (
+		ctx context.Context
+
+		server            *fakeaws.Server
+		provider          *aws.ClientProvider
+		managementCluster types.NamespacedName
+	)
+
+	publish := func(topic string) error {
+		client := aws.NewSNSClient(topic, provider, managementCluster)
+		_, err := client.PublishSNSMessage(ctx, &sns.PublishInput{
+			Message:                awssdk.String("hello"),
+			MessageGroupId:         awssdk.String("group"),
+			MessageDeduplicationId: awssdk.String("deduplication"),
+		})
+		return err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		server = fakeaws.NewServer(region)
+		DeferCleanup(server.Close)
+
+		Expect(os.Setenv("AWS_ACCESS_KEY_ID", fakeaws.AccessKey(account))).To(Succeed())
+		Expect(os.Setenv("AWS_SECRET_ACCESS_KEY", "fake-secret-access-key")).To(Succeed())
+		Expect(os.Setenv("AWS_EC2_METADATA_DISABLED", "true")).To(Succeed())
+
+		scheme := runtime.NewScheme()
+		Expect(capa.AddToScheme(scheme)).To(Succeed())
+		managementCluster = types.NamespacedName{Name: "mc", Namespace: "test"}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: managementCluster.Name, Namespace: managementCluster.Namespace},
+			Spec:       capa.AWSClusterSpec{Region: region},
+		}).Build()
+
+		factory := aws.NewCredentialsFactory(k8sClient, "giantswarm", aws.EndpointOptions{URL: server.URL()})
+		provider = aws.NewClientProvider(factory, time.Hour)
+	})
+
+	It("drops the FIFO parameters for standard topics", func() {
+		Expect(publish("arn:aws:sns:eu-central-1:111111111111:requests")).To(Succeed())
+		Expect(server.Messages()).To(ConsistOf(HaveField("DeduplicationID", "")))
+	})
+
+	It("keeps the FIFO parameters for FIFO topics", func() {
+		Expect(publish("arn:aws:sns:eu-central-1:111111111111:requests.fifo")).To(Succeed())
+		Expect(server.Messages()).To(ConsistOf(And(
+			HaveField("MessageGroupID", "group"),
+			HaveField("DeduplicationID", "deduplication"),
+		)))
+	})
+})
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/tracing"
+)
+
+// withTracing returns the AWS SDK v2 API option starting a span for every API call made
+// for the cluster with the credentials of the account. The span includes all retries of
+// the call.
+func withTracing(clusterName, account string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("OperatorTracing", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			service := awsmiddleware.GetServiceID(ctx)
+			operation := awsmiddleware.GetOperationName(ctx)
+
+			ctx, span := tracing.Tracer().Start(ctx, service+"."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					tracing.ClusterName.String(clusterName),
+					tracing.AWSAccount.String(account),
+					tracing.AWSService.String(service),
+					tracing.AWSOperation.String(operation),
+				),
+			)
+
+			out, metadata, err := next.HandleInitialize(ctx, in)
+
+			if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+				span.SetAttributes(tracing.AWSRequestID.String(requestID))
+			}
+			if attempts, ok := retry.GetAttemptResults(metadata); ok {
+				span.SetAttributes(tracing.AWSAttempts.Int(len(attempts.Results)))
+			}
+			tracing.End(span, err)
+
+			return out, metadata, err
+		}), middleware.Before)
+	}
+}
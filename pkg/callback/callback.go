@@ -0,0 +1,243 @@
+package callback
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+)
+
+const (
+	// AttachmentResponsePath is the path responses to attachment requests are
+	// posted to.
+	AttachmentResponsePath = "/v1/attachments"
+
+	// TimestampHeader carries the time the response was signed at in seconds
+	// since the epoch.
+	TimestampHeader = "X-Timestamp"
+
+	// SignatureHeader carries the hex encoded HMAC-SHA256 of
+	// "<timestamp>.<body>" prefixed with "sha256=".
+	SignatureHeader = "X-Signature-256"
+
+	// MaxClockSkew is the maximum age of a signed response. Older responses
+	// are rejected to prevent replays.
+	MaxClockSkew = 5 * time.Minute
+
+	signaturePrefix = "sha256="
+	maxBodySize     = 64 << 10
+)
+
+// AttachmentResponse is the JSON body of a response of the owner of the
+// transit gateway to an attachment request.
+type AttachmentResponse struct {
+	Cluster      registrar.AttachmentRequestCluster    `json:"cluster"`
+	AttachmentID string                                `json:"attachmentID"`
+	Decision     v1alpha1.AttachmentAcceptanceDecision `json:"decision"`
+	Reason       string                                `json:"reason,omitempty"`
+	RouteTableID string                                `json:"routeTableID,omitempty"`
+}
+
+// Sign returns the SignatureHeader value of the body signed at the timestamp.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%d.", timestamp.Unix())
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Handler records the responses to attachment requests in the status of the
+// NetworkTopology of the cluster. Responses need to be signed with the shared
+// secret, see Sign.
+type Handler struct {
+	client client.Client
+	secret []byte
+	logger logr.Logger
+	now    func() time.Time
+}
+
+func NewHandler(client client.Client, secret []byte) *Handler {
+	return &Handler{
+		client: client,
+		secret: secret,
+		logger: ctrl.Log.WithName("callback"),
+		now:    time.Now,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(r.Header, body); err != nil {
+		h.logger.Info("Rejected unauthenticated attachment response", "reason", err.Error())
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	response := AttachmentResponse{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		http.Error(w, "invalid attachment response", http.StatusBadRequest)
+		return
+	}
+	if err := validate(response); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.record(r.Context(), response)
+	if err != nil {
+		h.logger.Error(err, "Failed to record attachment response", "cluster", response.Cluster.Name, "namespace", response.Cluster.Namespace, "attachmentID", response.AttachmentID)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	h.logger.Info("Recorded attachment response", "cluster", response.Cluster.Name, "namespace", response.Cluster.Namespace, "attachmentID", response.AttachmentID, "decision", response.Decision)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify checks the timestamp and the signature of the body.
+func (h *Handler) verify(header http.Header, body []byte) error {
+	seconds, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return errors.Errorf("missing or invalid %s header", TimestampHeader)
+	}
+	timestamp := time.Unix(seconds, 0)
+
+	skew := h.now().Sub(timestamp)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > MaxClockSkew {
+		return errors.Errorf("timestamp %s is more than %s off", timestamp, MaxClockSkew)
+	}
+
+	signature := header.Get(SignatureHeader)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errors.Errorf("missing or invalid %s header", SignatureHeader)
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(h.secret, timestamp, body))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// record sets the response on the attachment status of the NetworkTopology.
+// The returned HTTP status code describes the failure if an error is
+// returned.
+func (h *Handler) record(ctx context.Context, response AttachmentResponse) (int, error) {
+	topology := &v1alpha1.NetworkTopology{}
+	name := types.NamespacedName{Name: response.Cluster.Name, Namespace: response.Cluster.Namespace}
+	if err := h.client.Get(ctx, name, topology); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return http.StatusNotFound, err
+		}
+		return http.StatusInternalServerError, errors.Wrapf(err, "failed to get NetworkTopology %s", name)
+	}
+
+	attachment := topology.Status.TransitGatewayAttachment
+	if attachment == nil || attachment.ID != response.AttachmentID {
+		// The response is outdated, e.g. the attachment was recreated
+		return http.StatusConflict, errors.Errorf("transit gateway attachment %s isn't the attachment of the cluster", response.AttachmentID)
+	}
+
+	base := topology.DeepCopy()
+	if attachment.AcceptanceRequest == nil {
+		attachment.AcceptanceRequest = &v1alpha1.AttachmentAcceptanceRequestStatus{}
+	}
+	attachment.AcceptanceRequest.Response = &v1alpha1.AttachmentAcceptanceResponse{
+		Decision:     response.Decision,
+		Reason:       response.Reason,
+		RouteTableID: response.RouteTableID,
+		Time:         metav1.NewTime(h.now()),
+	}
+
+	if err := h.client.Status().Patch(ctx, topology, client.MergeFrom(base)); err != nil {
+		return http.StatusInternalServerError, errors.Wrapf(err, "failed to patch NetworkTopology %s", name)
+	}
+	return http.StatusNoContent, nil
+}
+
+func validate(response AttachmentResponse) error {
+	if response.Cluster.Name == "" || response.Cluster.Namespace == "" {
+		return errors.New("cluster name and namespace are required")
+	}
+	if response.AttachmentID == "" {
+		return errors.New("attachmentID is required")
+	}
+	if response.Decision != v1alpha1.AttachmentAccepted && response.Decision != v1alpha1.AttachmentRejected {
+		return errors.Errorf("decision must be %s or %s", v1alpha1.AttachmentAccepted, v1alpha1.AttachmentRejected)
+	}
+	return nil
+}
+
+// Server serves the Handler. It runs on every replica of the manager, not
+// only the leader.
+type Server struct {
+	bindAddress string
+	handler     http.Handler
+}
+
+func NewServer(bindAddress string, handler *Handler) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(AttachmentResponsePath, handler)
+
+	return &Server{
+		bindAddress: bindAddress,
+		handler:     mux,
+	}
+}
+
+// Start serves the handler until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.bindAddress,
+		Handler:           s.handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return errors.Wrap(err, "failed to serve approval callback")
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,13 @@
+package callback_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCallback(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Callback Suite")
+}
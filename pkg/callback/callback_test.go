@@ -0,0 +1,168 @@
+package callback_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/callback"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+)
+
+var _ = Describe("Handler", func() {
+	const attachmentID = "tgw-attach-123"
+
+	var (
+		ctx context.Context
+
+		secret    []byte
+		k8sClient client.Client
+		handler   *callback.Handler
+		response  callback.AttachmentResponse
+		timestamp time.Time
+		signature string
+		recorder  *httptest.ResponseRecorder
+	)
+
+	name := types.NamespacedName{Name: "test", Namespace: "org-test"}
+
+	getAcceptanceRequest := func() *v1alpha1.AttachmentAcceptanceRequestStatus {
+		topology := &v1alpha1.NetworkTopology{}
+		Expect(k8sClient.Get(ctx, name, topology)).To(Succeed())
+		return topology.Status.TransitGatewayAttachment.AcceptanceRequest
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		secret = []byte("secret")
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&v1alpha1.NetworkTopology{
+			ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+			Status: v1alpha1.NetworkTopologyStatus{
+				TransitGatewayAttachment: &v1alpha1.TransitGatewayAttachmentStatus{
+					ID:    attachmentID,
+					State: "pendingAcceptance",
+					AcceptanceRequest: &v1alpha1.AttachmentAcceptanceRequestStatus{
+						Notifications: 1,
+					},
+				},
+			},
+		}).Build()
+		handler = callback.NewHandler(k8sClient, secret)
+
+		response = callback.AttachmentResponse{
+			Cluster:      registrar.AttachmentRequestCluster{Name: name.Name, Namespace: name.Namespace},
+			AttachmentID: attachmentID,
+			Decision:     v1alpha1.AttachmentRejected,
+			Reason:       "CIDR overlaps with on-premises network",
+		}
+		timestamp = time.Now()
+		signature = ""
+	})
+
+	JustBeforeEach(func() {
+		body, err := json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+		if signature == "" {
+			signature = callback.Sign(secret, timestamp, body)
+		}
+
+		request := httptest.NewRequest(http.MethodPost, callback.AttachmentResponsePath, bytes.NewReader(body))
+		request.Header.Set(callback.TimestampHeader, strconv.FormatInt(timestamp.Unix(), 10))
+		request.Header.Set(callback.SignatureHeader, signature)
+
+		recorder = httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+	})
+
+	It("records the response in the network topology", func() {
+		Expect(recorder.Code).To(Equal(http.StatusNoContent))
+
+		acceptanceRequest := getAcceptanceRequest()
+		Expect(acceptanceRequest.Notifications).To(Equal(int32(1)))
+		Expect(acceptanceRequest.Response).NotTo(BeNil())
+		Expect(acceptanceRequest.Response.Decision).To(Equal(v1alpha1.AttachmentRejected))
+		Expect(acceptanceRequest.Response.Reason).To(Equal("CIDR overlaps with on-premises network"))
+	})
+
+	When("the attachment was accepted with a route table", func() {
+		BeforeEach(func() {
+			response.Decision = v1alpha1.AttachmentAccepted
+			response.Reason = ""
+			response.RouteTableID = "tgw-rtb-123"
+		})
+
+		It("records the route table", func() {
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+			Expect(getAcceptanceRequest().Response.RouteTableID).To(Equal("tgw-rtb-123"))
+		})
+	})
+
+	When("the signature doesn't match", func() {
+		BeforeEach(func() {
+			signature = callback.Sign([]byte("other"), timestamp, []byte("{}"))
+		})
+
+		It("rejects the response", func() {
+			Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+			Expect(getAcceptanceRequest().Response).To(BeNil())
+		})
+	})
+
+	When("the response is too old", func() {
+		BeforeEach(func() {
+			timestamp = time.Now().Add(-2 * callback.MaxClockSkew)
+		})
+
+		It("rejects the response", func() {
+			Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+			Expect(getAcceptanceRequest().Response).To(BeNil())
+		})
+	})
+
+	When("the decision is unknown", func() {
+		BeforeEach(func() {
+			response.Decision = "Maybe"
+		})
+
+		It("rejects the response", func() {
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	When("the attachment isn't the attachment of the cluster", func() {
+		BeforeEach(func() {
+			response.AttachmentID = "tgw-attach-456"
+		})
+
+		It("rejects the response", func() {
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+			Expect(getAcceptanceRequest().Response).To(BeNil())
+		})
+	})
+
+	When("the cluster doesn't exist", func() {
+		BeforeEach(func() {
+			response.Cluster.Name = "unknown"
+		})
+
+		It("returns not found", func() {
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package k8sclient
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+)
+
+// GetNetworkTopology retrieves a NetworkTopology based on the given namespace/name
+func (g *Cluster) GetNetworkTopology(ctx context.Context, namespacedName types.NamespacedName) (*v1alpha1.NetworkTopology, error) {
+	topology := &v1alpha1.NetworkTopology{}
+	err := g.Client.Get(ctx, namespacedName, topology)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	return topology, nil
+}
+
+// CreateNetworkTopology creates the given NetworkTopology
+func (g *Cluster) CreateNetworkTopology(ctx context.Context, topology *v1alpha1.NetworkTopology) error {
+	return microerror.Mask(g.Client.Create(ctx, topology))
+}
+
+// PatchNetworkTopology patches both the spec and the status of the NetworkTopology
+// with the changes made since base
+func (g *Cluster) PatchNetworkTopology(ctx context.Context, topology *v1alpha1.NetworkTopology, base *v1alpha1.NetworkTopology) error {
+	// Patching the main resource overwrites the in-memory status with the stored one
+	status := topology.Status.DeepCopy()
+	if err := g.Client.Patch(ctx, topology, client.MergeFrom(base)); err != nil {
+		return microerror.Mask(err)
+	}
+
+	topology.Status = *status
+	if err := g.Client.Status().Patch(ctx, topology, client.MergeFrom(base)); err != nil {
+		return microerror.Mask(err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "aws_network_topology_operator"
+
+	// ReasonReady is used as reconcile outcome when the network topology of a cluster is ready.
+	ReasonReady = "Ready"
+	// ReasonError is used as reconcile outcome when the reconciliation failed with an unexpected error.
+	ReasonError = "Error"
+
+	// CacheHit and CacheMiss are the results of credentials cache lookups.
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reconcile_total",
+			Help:      "Number of network topology reconciliations by outcome, which is the reason of the NetworkTopologyReady condition.",
+		},
+		[]string{"reason"},
+	)
+
+	attachmentState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "transit_gateway_attachment_state",
+			Help:      "State of the transit gateway VPC attachment of a cluster. The gauge is 1 for the current state.",
+		},
+		[]string{"cluster_namespace", "cluster_name", "state"},
+	)
+
+	prefixListEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "prefix_list_entries",
+			Help:      "Number of entries used in the prefix list.",
+		},
+		[]string{"prefix_list_id"},
+	)
+
+	prefixListMaxEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "prefix_list_max_entries",
+			Help:      "Maximum number of entries of the prefix list.",
+		},
+		[]string{"prefix_list_id"},
+	)
+
+	resourceShared = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "resource_share_shared",
+			Help:      "Whether a resource of a cluster is shared through RAM with the account of the cluster. 1 if shared, 0 if no share is needed.",
+		},
+		[]string{"cluster_namespace", "cluster_name", "resource"},
+	)
+
+	awsCallDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "aws_api_call_duration_seconds",
+			Help:      "Duration of AWS API calls.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"service", "operation", "account"},
+	)
+
+	awsCallErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "aws_api_call_errors_total",
+			Help:      "Number of failed AWS API calls by error code.",
+		},
+		[]string{"service", "operation", "account", "code"},
+	)
+
+	credentialsCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "aws_credentials_cache_requests_total",
+			Help:      "Number of AWS credentials cache lookups by result, either hit or miss.",
+		},
+		[]string{"result"},
+	)
+
+	credentialsCacheEvictions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "aws_credentials_cache_evictions_total",
+			Help:      "Number of AWS credentials evicted from the cache because their AWSClusterRoleIdentity changed.",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileTotal,
+		attachmentState,
+		prefixListEntries,
+		prefixListMaxEntries,
+		resourceShared,
+		awsCallDuration,
+		awsCallErrors,
+		credentialsCacheRequests,
+		credentialsCacheEvictions,
+	)
+}
+
+// RecordReconcile counts a reconciliation with the given outcome.
+func RecordReconcile(reason string) {
+	reconcileTotal.WithLabelValues(reason).Inc()
+}
+
+// SetAttachmentState sets the state of the transit gateway attachment of the cluster. An
+// empty state removes the attachment state of the cluster.
+func SetAttachmentState(clusterNamespace, clusterName, state string) {
+	for _, s := range types.TransitGatewayAttachmentState("").Values() {
+		attachmentState.DeleteLabelValues(clusterNamespace, clusterName, string(s))
+	}
+
+	if state != "" {
+		attachmentState.WithLabelValues(clusterNamespace, clusterName, state).Set(1)
+	}
+}
+
+// SetPrefixListEntries records the number of used and maximum entries of the prefix list.
+func SetPrefixListEntries(prefixListID string, entries int, maxEntries *int32) {
+	prefixListEntries.WithLabelValues(prefixListID).Set(float64(entries))
+	if maxEntries != nil {
+		prefixListMaxEntries.WithLabelValues(prefixListID).Set(float64(*maxEntries))
+	}
+}
+
+// DeletePrefixListEntries removes the entries of the deleted prefix list.
+func DeletePrefixListEntries(prefixListID string) {
+	prefixListEntries.DeleteLabelValues(prefixListID)
+	prefixListMaxEntries.DeleteLabelValues(prefixListID)
+}
+
+// SetResourceShared records whether the resource of the cluster is shared through RAM.
+func SetResourceShared(clusterNamespace, clusterName, resource string, shared bool) {
+	value := 0.0
+	if shared {
+		value = 1
+	}
+	resourceShared.WithLabelValues(clusterNamespace, clusterName, resource).Set(value)
+}
+
+// DeleteResourceShared removes the RAM share state of the resource of the cluster.
+func DeleteResourceShared(clusterNamespace, clusterName, resource string) {
+	resourceShared.DeleteLabelValues(clusterNamespace, clusterName, resource)
+}
+
+// ObserveAWSCall records the duration and the error code, if any, of an AWS API call.
+func ObserveAWSCall(service, operation, account string, duration time.Duration, err error) {
+	awsCallDuration.WithLabelValues(service, operation, account).Observe(duration.Seconds())
+
+	if err != nil {
+		awsCallErrors.WithLabelValues(service, operation, account, errorCode(err)).Inc()
+	}
+}
+
+// RecordCredentialsCacheRequest counts a credentials cache lookup with the given result,
+// CacheHit or CacheMiss.
+func RecordCredentialsCacheRequest(result string) {
+	credentialsCacheRequests.WithLabelValues(result).Inc()
+}
+
+// RecordCredentialsCacheEvictions counts credentials evicted from the cache.
+func RecordCredentialsCacheEvictions(count int) {
+	credentialsCacheEvictions.Add(float64(count))
+}
+
+func errorCode(err error) string {
+	var apiError smithy.APIError
+	if errors.As(err, &apiError) {
+		return apiError.ErrorCode()
+	}
+
+	var codeError interface{ Code() string }
+	if errors.As(err, &codeError) {
+		return codeError.Code()
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,116 @@
+package metrics_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/metrics"
+)
+
+var _ = Describe("Metrics", func() {
+	Describe("SetAttachmentState", func() {
+		AfterEach(func() {
+			metrics.SetAttachmentState("org-test", "attachment-cluster", "")
+		})
+
+		It("only reports the current state", func() {
+			metrics.SetAttachmentState("org-test", "attachment-cluster", "pending")
+			metrics.SetAttachmentState("org-test", "attachment-cluster", "available")
+
+			expected := `
+# HELP aws_network_topology_operator_transit_gateway_attachment_state State of the transit gateway VPC attachment of a cluster. The gauge is 1 for the current state.
+# TYPE aws_network_topology_operator_transit_gateway_attachment_state gauge
+aws_network_topology_operator_transit_gateway_attachment_state{cluster_name="attachment-cluster",cluster_namespace="org-test",state="available"} 1
+`
+			Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "aws_network_topology_operator_transit_gateway_attachment_state")).To(Succeed())
+		})
+
+		It("removes the state", func() {
+			metrics.SetAttachmentState("org-test", "attachment-cluster", "available")
+			metrics.SetAttachmentState("org-test", "attachment-cluster", "")
+
+			count, err := testutil.GatherAndCount(ctrlmetrics.Registry, "aws_network_topology_operator_transit_gateway_attachment_state")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+		})
+	})
+
+	Describe("SetPrefixListEntries", func() {
+		AfterEach(func() {
+			metrics.DeletePrefixListEntries("pl-0123")
+		})
+
+		It("reports the used and maximum entries", func() {
+			maxEntries := int32(45)
+			metrics.SetPrefixListEntries("pl-0123", 12, &maxEntries)
+
+			expected := `
+# HELP aws_network_topology_operator_prefix_list_entries Number of entries used in the prefix list.
+# TYPE aws_network_topology_operator_prefix_list_entries gauge
+aws_network_topology_operator_prefix_list_entries{prefix_list_id="pl-0123"} 12
+# HELP aws_network_topology_operator_prefix_list_max_entries Maximum number of entries of the prefix list.
+# TYPE aws_network_topology_operator_prefix_list_max_entries gauge
+aws_network_topology_operator_prefix_list_max_entries{prefix_list_id="pl-0123"} 45
+`
+			Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected),
+				"aws_network_topology_operator_prefix_list_entries",
+				"aws_network_topology_operator_prefix_list_max_entries",
+			)).To(Succeed())
+		})
+
+		It("removes the entries of a deleted prefix list", func() {
+			maxEntries := int32(45)
+			metrics.SetPrefixListEntries("pl-0123", 12, &maxEntries)
+			metrics.DeletePrefixListEntries("pl-0123")
+
+			count, err := testutil.GatherAndCount(ctrlmetrics.Registry,
+				"aws_network_topology_operator_prefix_list_entries",
+				"aws_network_topology_operator_prefix_list_max_entries",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+		})
+	})
+
+	Describe("ObserveAWSCall", func() {
+		It("counts errors by error code", func() {
+			metrics.ObserveAWSCall("EC2", "ModifyManagedPrefixList", "123456789012", time.Second, &smithy.GenericAPIError{Code: "PrefixListVersionMismatch"})
+			metrics.ObserveAWSCall("EC2", "ModifyManagedPrefixList", "123456789012", time.Second, errors.New("boom"))
+			metrics.ObserveAWSCall("EC2", "ModifyManagedPrefixList", "123456789012", time.Second, nil)
+
+			expected := `
+# HELP aws_network_topology_operator_aws_api_call_errors_total Number of failed AWS API calls by error code.
+# TYPE aws_network_topology_operator_aws_api_call_errors_total counter
+aws_network_topology_operator_aws_api_call_errors_total{account="123456789012",code="PrefixListVersionMismatch",operation="ModifyManagedPrefixList",service="EC2"} 1
+aws_network_topology_operator_aws_api_call_errors_total{account="123456789012",code="Unknown",operation="ModifyManagedPrefixList",service="EC2"} 1
+`
+			Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "aws_network_topology_operator_aws_api_call_errors_total")).To(Succeed())
+
+			count, err := testutil.GatherAndCount(ctrlmetrics.Registry, "aws_network_topology_operator_aws_api_call_duration_seconds")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+	})
+	Describe("RecordCredentialsCacheRequest", func() {
+		It("counts the lookups by result", func() {
+			metrics.RecordCredentialsCacheRequest(metrics.CacheHit)
+			metrics.RecordCredentialsCacheRequest(metrics.CacheHit)
+			metrics.RecordCredentialsCacheRequest(metrics.CacheMiss)
+
+			expected := `
+# HELP aws_network_topology_operator_aws_credentials_cache_requests_total Number of AWS credentials cache lookups by result, either hit or miss.
+# TYPE aws_network_topology_operator_aws_credentials_cache_requests_total counter
+aws_network_topology_operator_aws_credentials_cache_requests_total{result="hit"} 2
+aws_network_topology_operator_aws_credentials_cache_requests_total{result="miss"} 1
+`
+			Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "aws_network_topology_operator_aws_credentials_cache_requests_total")).To(Succeed())
+		})
+	})
+})
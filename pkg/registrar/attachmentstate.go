@@ -0,0 +1,172 @@
+package registrar
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+)
+
+// DefaultAttachmentRecreationLimit is the number of times a failed or deleted
+// transit gateway attachment is recreated before giving up.
+const DefaultAttachmentRecreationLimit = 3
+
+// isRecreatableAttachmentState returns true if an attachment in the state
+// won't become available anymore and needs to be replaced.
+func isRecreatableAttachmentState(state types.TransitGatewayAttachmentState) bool {
+	return state == types.TransitGatewayAttachmentStateFailed || state == types.TransitGatewayAttachmentStateDeleted
+}
+
+func isNewerAttachment(a, b *types.TransitGatewayVpcAttachment) bool {
+	if a.CreationTime == nil || b.CreationTime == nil {
+		return a.CreationTime != nil
+	}
+	return a.CreationTime.After(*b.CreationTime)
+}
+
+// recreateFailedAttachment records the attachment in the status and replaces a
+// failed or deleted attachment with a new attachment as long as the recreation
+// limit isn't reached. The attachment is returned unchanged otherwise. A failed
+// attachment blocks new attachments of the VPC, so it is deleted first and
+// only replaced once it is deleted.
+func (r *TransitGateway) recreateFailedAttachment(ctx context.Context, topology *v1alpha1.NetworkTopology, gatewayID *string, awsCluster *capa.AWSCluster, ipv6Support bool, attachment *types.TransitGatewayVpcAttachment) (*types.TransitGatewayVpcAttachment, error) {
+	previous := topology.Status.TransitGatewayAttachment
+	setTransitGatewayAttachmentStatus(topology, attachment)
+	if attachment == nil || !isRecreatableAttachmentState(attachment.State) {
+		return attachment, nil
+	}
+
+	var recreations int32
+	if topology.Status.TransitGatewayAttachment != nil {
+		recreations = topology.Status.TransitGatewayAttachment.Recreations
+	}
+
+	// The recreation of a failed attachment is counted once it is deleted
+	attachmentID := awssdk.StringValue(attachment.TransitGatewayAttachmentId)
+	deletedForRecreation := attachment.State == types.TransitGatewayAttachmentStateDeleted &&
+		previous != nil && previous.ID == attachmentID && previous.State == string(types.TransitGatewayAttachmentStateDeleting)
+	if !deletedForRecreation {
+		if recreations >= r.options.AttachmentRecreationLimit {
+			return attachment, nil
+		}
+
+		recreations++
+		r.getLogger(ctx).Info("Recreating transit gateway attachment", "attachmentID", attachmentID, "state", attachment.State, "recreations", recreations)
+		r.recordWarning(ctx, EventReasonAttachmentRecreated, "Recreating transit gateway attachment %s in state %s (%d/%d)", attachmentID, attachment.State, recreations, r.options.AttachmentRecreationLimit)
+	}
+
+	if attachment.State == types.TransitGatewayAttachmentStateFailed {
+		deleting, err := r.deleteFailedAttachment(ctx, awsCluster, attachment)
+		if err != nil {
+			return nil, err
+		}
+
+		setTransitGatewayAttachmentStatus(topology, deleting)
+		topology.Status.TransitGatewayAttachment.Recreations = recreations
+		return deleting, nil
+	}
+
+	recreated, err := r.attachTransitGateway(ctx, gatewayID, awsCluster, topology.Spec.AttachmentSubnetSelector, ipv6Support, true)
+	if err != nil {
+		return nil, err
+	}
+
+	setTransitGatewayAttachmentStatus(topology, recreated)
+	if topology.Status.TransitGatewayAttachment != nil {
+		topology.Status.TransitGatewayAttachment.Recreations = recreations
+	}
+	return recreated, nil
+}
+
+// deleteFailedAttachment deletes the failed attachment and returns it in the
+// deleting state.
+func (r *TransitGateway) deleteFailedAttachment(ctx context.Context, awsCluster *capa.AWSCluster, attachment *types.TransitGatewayVpcAttachment) (*types.TransitGatewayVpcAttachment, error) {
+	logger := r.getLogger(ctx)
+	attachmentID := awssdk.StringValue(attachment.TransitGatewayAttachmentId)
+
+	// The attachment is owned by the AWS account of the workload cluster
+	transitGatewayAttachmentClient := r.getTransitGatewayClientForWorkloadCluster(k8stypes.NamespacedName{
+		Name:      awsCluster.ObjectMeta.Name,
+		Namespace: awsCluster.ObjectMeta.Namespace,
+	})
+
+	logger.Info("Deleting failed transit gateway attachment before recreating it", "attachmentID", attachmentID)
+	output, err := transitGatewayAttachmentClient.DeleteTransitGatewayVpcAttachment(ctx, &ec2.DeleteTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to delete failed transit gateway attachment", "attachmentID", attachmentID)
+		r.recordWarning(ctx, EventReasonAttachmentDeletionFailed, "Failed to delete failed transit gateway attachment %s: %v", attachmentID, err)
+		return nil, err
+	}
+	r.recordNormal(ctx, EventReasonAttachmentDeleted, "Deleted failed transit gateway attachment %s to recreate it", attachmentID)
+
+	if output != nil && output.TransitGatewayVpcAttachment != nil {
+		return output.TransitGatewayVpcAttachment, nil
+	}
+	deleting := *attachment
+	deleting.State = types.TransitGatewayAttachmentStateDeleting
+	return &deleting, nil
+}
+
+// attachmentFailure returns an AttachmentRejectedError if the attachment was
+// rejected in AWS, or through the approval callback while it is still pending
+// acceptance, and an AttachmentFailedError if the attachment failed or was
+// deleted.
+func attachmentFailure(topology *v1alpha1.NetworkTopology, attachment *types.TransitGatewayVpcAttachment) error {
+	if attachment == nil {
+		return nil
+	}
+
+	attachmentID := awssdk.StringValue(attachment.TransitGatewayAttachmentId)
+	switch attachment.State {
+	case types.TransitGatewayAttachmentStateRejected,
+		types.TransitGatewayAttachmentStateRejecting:
+		return &AttachmentRejectedError{AttachmentID: attachmentID}
+
+	case types.TransitGatewayAttachmentStatePendingAcceptance:
+		response := acceptanceResponse(topology.Status.TransitGatewayAttachment)
+		if response != nil && response.Decision == v1alpha1.AttachmentRejected {
+			return &AttachmentRejectedError{AttachmentID: attachmentID, Reason: response.Reason}
+		}
+
+	case types.TransitGatewayAttachmentStateFailed,
+		types.TransitGatewayAttachmentStateFailing,
+		types.TransitGatewayAttachmentStateRollingBack,
+		types.TransitGatewayAttachmentStateDeleted,
+		types.TransitGatewayAttachmentStateDeleting:
+		err := &AttachmentFailedError{
+			AttachmentID: attachmentID,
+			State:        string(attachment.State),
+			// Failed and deleted attachments are only left in place once
+			// the recreation limit is reached
+			Exhausted: isRecreatableAttachmentState(attachment.State),
+		}
+		if topology.Status.TransitGatewayAttachment != nil {
+			err.Recreations = topology.Status.TransitGatewayAttachment.Recreations
+		}
+		return err
+	}
+
+	return nil
+}
+
+// attachmentPending returns an AttachmentPendingError unless the attachment is
+// available.
+func attachmentPending(attachment *types.TransitGatewayVpcAttachment) error {
+	if attachment == nil {
+		return &AttachmentPendingError{}
+	}
+	if attachment.State != types.TransitGatewayAttachmentStateAvailable {
+		return &AttachmentPendingError{
+			AttachmentID: awssdk.StringValue(attachment.TransitGatewayAttachmentId),
+			State:        string(attachment.State),
+		}
+	}
+	return nil
+}
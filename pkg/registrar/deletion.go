@@ -0,0 +1,331 @@
+package registrar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/metrics"
+)
+
+const (
+	deletionBackoffBase = 15 * time.Second
+	deletionBackoffMax  = 5 * time.Minute
+)
+
+// deleteTransitGateway deletes the transit gateway of a management cluster in
+// stages. The transit gateway is only deleted once all of its attachments,
+// including the attachments of other clusters, and the route tables created
+// by the operator are gone. A TransitGatewayDeletionBlockedError is returned
+// while a stage is waiting for them. A transit gateway that is already deleting
+// or deleted counts as deleted, e.g. when the status of an earlier pass was lost.
+func (r *TransitGateway) deleteTransitGateway(ctx context.Context, gatewayID *string, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) error {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("transitGatewayID", gatewayID)
+
+	logger.Info("Deleting transit gateway")
+	defer logger.Info("Done deleting transit gateway")
+
+	if !r.clusterClient.IsManagementCluster(ctx, cluster) {
+		logger.Info("Cluster is a workload cluster. Skipping transit gateway deletion")
+		return nil
+	}
+
+	tgw, err := r.getTransitGateway(ctx, *gatewayID)
+	if awsclient.HasErrorCode(err, awsclient.ErrTransitGatewayNotFound) || (err == nil && isTransitGatewayDeleted(tgw)) {
+		logger.Info("Transit gateway already deleted")
+		topology.Status.TransitGatewayDeletion = nil
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	attachments, err := r.getBlockingAttachments(ctx, *gatewayID)
+	if err != nil {
+		return err
+	}
+	if len(attachments) > 0 {
+		return r.transitGatewayDeletionBlocked(ctx, topology, *gatewayID, v1alpha1.TransitGatewayDeletionWaitingForAttachments, attachments)
+	}
+
+	routeTables, err := r.routeTables.deleteRouteTables(ctx, *gatewayID)
+	if err != nil {
+		return err
+	}
+	if len(routeTables) > 0 {
+		return r.transitGatewayDeletionBlocked(ctx, topology, *gatewayID, v1alpha1.TransitGatewayDeletionDeletingRouteTables, routeTables)
+	}
+
+	_, err = r.transitGatewayClient.DeleteTransitGateway(ctx, &ec2.DeleteTransitGatewayInput{
+		TransitGatewayId: gatewayID,
+	})
+	if awsclient.HasErrorCode(err, awsclient.ErrTransitGatewayNotFound) {
+		logger.Info("Transit gateway already deleted")
+		topology.Status.TransitGatewayDeletion = nil
+		return nil
+	} else if awsclient.HasErrorCode(err, awsclient.ErrIncorrectState) {
+		// Attachments or route tables were created since they were listed
+		logger.Info("Transit gateway not yet deletable", "reason", err.Error())
+		return r.transitGatewayDeletionBlocked(ctx, topology, *gatewayID, v1alpha1.TransitGatewayDeletionDeletingTransitGateway, nil)
+	} else if err != nil {
+		logger.Error(err, "failed to delete transit gateway")
+		r.recordWarning(ctx, EventReasonTransitGatewayDeletionFailed, "Failed to delete transit gateway %s: %v", *gatewayID, err)
+		return err
+	}
+
+	topology.Status.TransitGatewayDeletion = nil
+	r.recordNormal(ctx, EventReasonTransitGatewayDeleted, "Deleted transit gateway %s", *gatewayID)
+	return nil
+}
+
+// deletePrefixLists deletes the prefix lists of a management cluster once they
+// are empty and no longer referenced by route tables or security groups,
+// together with the RAM resource shares they are associated with. A
+// TransitGatewayDeletionBlockedError is returned while a prefix list is still
+// in use.
+func (r *TransitGateway) deletePrefixLists(ctx context.Context, gatewayID string, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) error {
+	logger := r.getLogger(ctx)
+
+	if !r.clusterClient.IsManagementCluster(ctx, cluster) {
+		return nil
+	}
+
+	blocking := []string{}
+	for _, ref := range []*v1alpha1.AWSResourceReference{topology.Spec.PrefixList, topology.Spec.IPv6PrefixList} {
+		prefixListID, err := getResourceID(logger, ref, "prefix list")
+		if err != nil {
+			return err
+		}
+		if prefixListID == "" {
+			continue
+		}
+
+		prefixList, err := r.getPrefixList(ctx, prefixListID)
+		if awsclient.HasErrorCode(err, awsclient.ErrPrefixListNotFound) || (err == nil && isPrefixListDeleted(prefixList)) {
+			logger.Info("Prefix list already deleted", "prefixListID", prefixListID)
+			metrics.DeletePrefixListEntries(prefixListID)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		references, err := r.getPrefixListReferences(ctx, prefixList)
+		if err != nil {
+			return err
+		}
+		if len(references) > 0 {
+			blocking = append(blocking, references...)
+			continue
+		}
+
+		if err := r.deletePrefixListShares(ctx, prefixList); err != nil {
+			return err
+		}
+
+		_, err = r.transitGatewayClient.DeleteManagedPrefixList(ctx, &ec2.DeleteManagedPrefixListInput{
+			PrefixListId: &prefixListID,
+		})
+		if awsclient.HasErrorCode(err, awsclient.ErrPrefixListNotFound) {
+			logger.Info("Prefix list already deleted", "prefixListID", prefixListID)
+			metrics.DeletePrefixListEntries(prefixListID)
+			continue
+		} else if err != nil {
+			logger.Error(err, "Failed to delete prefix list", "prefixListID", prefixListID)
+			r.recordWarning(ctx, EventReasonPrefixListDeletionFailed, "Failed to delete prefix list %s: %v", prefixListID, err)
+			return err
+		}
+
+		logger.Info("Deleted prefix list", "prefixListID", prefixListID)
+		metrics.DeletePrefixListEntries(prefixListID)
+		r.recordNormal(ctx, EventReasonPrefixListDeleted, "Deleted prefix list %s", prefixListID)
+	}
+
+	if len(blocking) > 0 {
+		return r.transitGatewayDeletionBlocked(ctx, topology, gatewayID, v1alpha1.TransitGatewayDeletionDeletingPrefixLists, blocking)
+	}
+
+	topology.Status.TransitGatewayDeletion = nil
+	return nil
+}
+
+// getPrefixListReferences returns the entries of the prefix list and the
+// resources referencing it, formatted as "<id> (entry <cidr>)" and
+// "<id> (<resource ID>)".
+func (r *TransitGateway) getPrefixListReferences(ctx context.Context, prefixList *types.ManagedPrefixList) ([]string, error) {
+	logger := r.getLogger(ctx)
+
+	prefixListID := awssdk.StringValue(prefixList.PrefixListId)
+	references := []string{}
+
+	entries, err := r.getPrefixListEntries(ctx, prefixList)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		references = append(references, fmt.Sprintf("%s (entry %s)", prefixListID, awssdk.StringValue(entry.Cidr)))
+	}
+
+	input := &ec2.GetManagedPrefixListAssociationsInput{
+		PrefixListId: prefixList.PrefixListId,
+	}
+	for {
+		output, err := r.transitGatewayClient.GetManagedPrefixListAssociations(ctx, input)
+		if err != nil {
+			logger.Error(err, "Failed to get prefix list associations", "prefixListID", prefixListID)
+			return nil, err
+		}
+		if output == nil {
+			break
+		}
+
+		for _, association := range output.PrefixListAssociations {
+			references = append(references, fmt.Sprintf("%s (%s)", prefixListID, awssdk.StringValue(association.ResourceId)))
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return references, nil
+}
+
+// deletePrefixListShares deletes the RAM resource shares the prefix list is
+// associated with.
+func (r *TransitGateway) deletePrefixListShares(ctx context.Context, prefixList *types.ManagedPrefixList) error {
+	logger := r.getLogger(ctx)
+
+	prefixListARN := awssdk.StringValue(prefixList.PrefixListArn)
+	if prefixListARN == "" || r.options.ResourceShareClient == nil {
+		return nil
+	}
+
+	deleted, err := r.options.ResourceShareClient.DeleteResourceSharesOf(ctx, prefixListARN)
+	for _, shareName := range deleted {
+		r.recordNormal(ctx, EventReasonResourceShareDeleted, "Deleted RAM resource share %s of prefix list %s", shareName, awssdk.StringValue(prefixList.PrefixListId))
+	}
+	if err != nil {
+		logger.Error(err, "Failed to delete resource shares of prefix list", "prefixListARN", prefixListARN)
+		r.recordWarning(ctx, EventReasonResourceShareDeletionFailed, "Failed to delete RAM resource shares of prefix list %s: %v", awssdk.StringValue(prefixList.PrefixListId), err)
+		return err
+	}
+
+	return nil
+}
+
+// getBlockingAttachments returns the attachments of the transit gateway which
+// prevent its deletion, formatted as "<id> (<resource type> <resource ID>, <state>)".
+func (r *TransitGateway) getBlockingAttachments(ctx context.Context, gatewayID string) ([]string, error) {
+	logger := r.getLogger(ctx)
+
+	blocking := []string{}
+	input := &ec2.DescribeTransitGatewayAttachmentsInput{
+		Filters: []types.Filter{
+			{
+				Name:   awssdk.String("transit-gateway-id"),
+				Values: []string{gatewayID},
+			},
+		},
+	}
+	for {
+		output, err := r.transitGatewayClient.DescribeTransitGatewayAttachments(ctx, input)
+		if err != nil {
+			logger.Error(err, "Failed to describe transit gateway attachments", "transitGatewayID", gatewayID)
+			return nil, err
+		}
+		if output == nil {
+			break
+		}
+
+		for _, attachment := range output.TransitGatewayAttachments {
+			if !isBlockingAttachmentState(attachment.State) {
+				continue
+			}
+			blocking = append(blocking, fmt.Sprintf("%s (%s %s, %s)",
+				awssdk.StringValue(attachment.TransitGatewayAttachmentId),
+				attachment.ResourceType,
+				awssdk.StringValue(attachment.ResourceId),
+				attachment.State,
+			))
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return blocking, nil
+}
+
+// transitGatewayDeletionBlocked records the stage the deletion is blocked in on
+// the NetworkTopology and returns a TransitGatewayDeletionBlockedError with a
+// backoff growing with the attempts in the stage.
+func (r *TransitGateway) transitGatewayDeletionBlocked(ctx context.Context, topology *v1alpha1.NetworkTopology, gatewayID string, stage v1alpha1.TransitGatewayDeletionStage, blocking []string) error {
+	status := topology.Status.TransitGatewayDeletion
+	if status == nil || status.Stage != stage {
+		status = &v1alpha1.TransitGatewayDeletionStatus{Stage: stage}
+	}
+	now := metav1.Now()
+	status.Blocking = blocking
+	status.Attempts++
+	status.LastAttemptTime = &now
+	topology.Status.TransitGatewayDeletion = status
+
+	r.getLogger(ctx).Info("Transit gateway deletion blocked", "transitGatewayID", gatewayID, "stage", stage, "blocking", blocking, "attempts", status.Attempts)
+	return &TransitGatewayDeletionBlockedError{
+		TransitGatewayID: gatewayID,
+		Stage:            string(stage),
+		Blocking:         blocking,
+		RetryAfter:       deletionBackoff(status.Attempts),
+	}
+}
+
+// isDeletingPrefixLists returns true if the transit gateway is deleted and the
+// deletion waits for the prefix lists.
+func isDeletingPrefixLists(topology *v1alpha1.NetworkTopology) bool {
+	status := topology.Status.TransitGatewayDeletion
+	return status != nil && status.Stage == v1alpha1.TransitGatewayDeletionDeletingPrefixLists
+}
+
+// isTransitGatewayDeleted returns true if the transit gateway is deleting, deleted
+// or no longer described at all.
+func isTransitGatewayDeleted(tgw *types.TransitGateway) bool {
+	return tgw == nil || tgw.State == types.TransitGatewayStateDeleting || tgw.State == types.TransitGatewayStateDeleted
+}
+
+func isPrefixListDeleted(prefixList *types.ManagedPrefixList) bool {
+	return prefixList.State == types.PrefixListStateDeleteInProgress || prefixList.State == types.PrefixListStateDeleteComplete
+}
+
+// isBlockingAttachmentState returns true if an attachment in the state
+// prevents the deletion of its transit gateway.
+func isBlockingAttachmentState(state types.TransitGatewayAttachmentState) bool {
+	switch state {
+	case types.TransitGatewayAttachmentStateDeleted,
+		types.TransitGatewayAttachmentStateFailed,
+		types.TransitGatewayAttachmentStateRejected:
+		return false
+	}
+	return true
+}
+
+func deletionBackoff(attempts int32) time.Duration {
+	backoff := deletionBackoffBase
+	for i := int32(1); i < attempts && backoff < deletionBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > deletionBackoffMax {
+		return deletionBackoffMax
+	}
+	return backoff
+}
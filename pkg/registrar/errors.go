@@ -3,6 +3,8 @@ package registrar
 import (
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 )
 
 type ModeNotSupportedError struct {
@@ -40,15 +42,212 @@ func (e *VPCNotReadyError) Is(target error) bool {
 	return reflect.TypeOf(target) == reflect.TypeOf(e)
 }
 
+type RouteTableNotReadyError struct {
+}
+
+func (e *RouteTableNotReadyError) Error() string {
+	return "transit gateway route table not ready"
+}
+
+func (e *RouteTableNotReadyError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// NoAttachmentSubnetsError is returned when the attachment subnet selector of a cluster
+// doesn't match any subnet of its VPC. The subnets of an existing attachment are kept.
+type NoAttachmentSubnetsError struct {
+	VPCID    string
+	Selector map[string]string
+}
+
+func (e *NoAttachmentSubnetsError) Error() string {
+	return fmt.Sprintf("no subnets of VPC %s match the attachment subnet selector %v", e.VPCID, e.Selector)
+}
+
+func (e *NoAttachmentSubnetsError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+type TransitGatewayPeeringNotReadyError struct {
+}
+
+func (e *TransitGatewayPeeringNotReadyError) Error() string {
+	return "transit gateway peering not ready"
+}
+
+func (e *TransitGatewayPeeringNotReadyError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+type PrefixListFullError struct {
+	PrefixListID string
+	MaxEntries   int32
+}
+
+func (e *PrefixListFullError) Error() string {
+	return fmt.Sprintf("prefix list %s reached the maximum of %d entries", e.PrefixListID, e.MaxEntries)
+}
+
+func (e *PrefixListFullError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// SNSUnavailableError is returned when the SNS message requesting the acceptance
+// of a transit gateway attachment couldn't be published.
+type SNSUnavailableError struct {
+	AttachmentID string
+	Err          error
+}
+
+func (e *SNSUnavailableError) Error() string {
+	return fmt.Sprintf("failed to publish SNS message for transit gateway attachment %s: %v", e.AttachmentID, e.Err)
+}
+
+func (e *SNSUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func (e *SNSUnavailableError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// AttachmentRejectedError is returned when a transit gateway attachment was
+// rejected, either in AWS or through the approval callback.
+type AttachmentRejectedError struct {
+	AttachmentID string
+	Reason       string
+}
+
+func (e *AttachmentRejectedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("transit gateway attachment %s was rejected", e.AttachmentID)
+	}
+	return fmt.Sprintf("transit gateway attachment %s was rejected: %s", e.AttachmentID, e.Reason)
+}
+
+func (e *AttachmentRejectedError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// AttachmentFailedError is returned when a transit gateway attachment failed or
+// was deleted. Exhausted is set once the attachment won't be recreated anymore.
+type AttachmentFailedError struct {
+	AttachmentID string
+	State        string
+	Recreations  int32
+	Exhausted    bool
+}
+
+func (e *AttachmentFailedError) Error() string {
+	if e.Exhausted {
+		return fmt.Sprintf("transit gateway attachment %s is %s after %d recreations", e.AttachmentID, e.State, e.Recreations)
+	}
+	return fmt.Sprintf("transit gateway attachment %s is %s", e.AttachmentID, e.State)
+}
+
+func (e *AttachmentFailedError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// AttachmentPendingError is returned while a transit gateway attachment isn't
+// available yet.
+type AttachmentPendingError struct {
+	AttachmentID string
+	State        string
+}
+
+func (e *AttachmentPendingError) Error() string {
+	if e.AttachmentID == "" {
+		return "transit gateway attachment not found"
+	}
+	return fmt.Sprintf("transit gateway attachment %s is %s", e.AttachmentID, e.State)
+}
+
+func (e *AttachmentPendingError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// TransitGatewayDeletionBlockedError is returned while the transit gateway can't
+// be deleted yet because attachments or route tables still exist.
+type TransitGatewayDeletionBlockedError struct {
+	TransitGatewayID string
+	Stage            string
+	Blocking         []string
+	RetryAfter       time.Duration
+}
+
+func (e *TransitGatewayDeletionBlockedError) Error() string {
+	if len(e.Blocking) == 0 {
+		return fmt.Sprintf("deletion of transit gateway %s blocked in stage %s", e.TransitGatewayID, e.Stage)
+	}
+	return fmt.Sprintf("deletion of transit gateway %s blocked in stage %s by %s", e.TransitGatewayID, e.Stage, strings.Join(e.Blocking, ", "))
+}
+
+func (e *TransitGatewayDeletionBlockedError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// PeerPrefixListNotSharedError is returned when the prefix list of a peer can't be
+// found because it isn't shared with the account of the management cluster.
+type PeerPrefixListNotSharedError struct {
+	error
+	PrefixListARN string
+}
+
+func (e *PeerPrefixListNotSharedError) Error() string {
+	return fmt.Sprintf("peer prefix list %s not found, it needs to be shared with the account of the management cluster: %s", e.PrefixListARN, e.error.Error())
+}
+
+func (e *PeerPrefixListNotSharedError) Unwrap() error {
+	return e.error
+}
+
+func (e *PeerPrefixListNotSharedError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+// TooManyTransitGatewayRoutesError is returned when the static routes of a peering
+// attachment don't fit into a single SearchTransitGatewayRoutes response.
+type TooManyTransitGatewayRoutesError struct {
+	RouteTableID string
+	AttachmentID string
+}
+
+func (e *TooManyTransitGatewayRoutesError) Error() string {
+	return fmt.Sprintf("route table %s has more static routes through attachment %s than a single search returns", e.RouteTableID, e.AttachmentID)
+}
+
+func (e *TooManyTransitGatewayRoutesError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
+type PrefixListNotReadyError struct {
+}
+
+func (e *PrefixListNotReadyError) Error() string {
+	return "prefix list not ready"
+}
+
+func (e *PrefixListNotReadyError) Is(target error) bool {
+	return reflect.TypeOf(target) == reflect.TypeOf(e)
+}
+
 type IDNotProvidedError struct {
 	error
 	ID string
 }
 
 func (e *IDNotProvidedError) Error() string {
+	if e.error == nil {
+		return fmt.Sprintf("%s ID not provided", e.ID)
+	}
 	return fmt.Sprintf("%s ID not provided: %s", e.ID, e.error.Error())
 }
 
+func (e *IDNotProvidedError) Unwrap() error {
+	return e.error
+}
+
 func (e *IDNotProvidedError) Is(target error) bool {
 	return reflect.TypeOf(target) == reflect.TypeOf(e)
 }
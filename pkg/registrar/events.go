@@ -0,0 +1,64 @@
+package registrar
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// Reasons of the events recorded on the Cluster.
+const (
+	EventReasonTransitGatewayCreated        = "TransitGatewayCreated"
+	EventReasonTransitGatewayCreationFailed = "TransitGatewayCreationFailed"
+	EventReasonTransitGatewayDeleted        = "TransitGatewayDeleted"
+	EventReasonTransitGatewayDeletionFailed = "TransitGatewayDeletionFailed"
+	EventReasonAttachmentCreated            = "TransitGatewayAttachmentCreated"
+	EventReasonAttachmentCreationFailed     = "TransitGatewayAttachmentCreationFailed"
+	EventReasonAttachmentModified           = "TransitGatewayAttachmentModified"
+	EventReasonAttachmentModificationFailed = "TransitGatewayAttachmentModificationFailed"
+	EventReasonAttachmentSubnetsDrift       = "AttachmentSubnetsDrift"
+	EventReasonAttachmentDeleted            = "TransitGatewayAttachmentDeleted"
+	EventReasonAttachmentDeletionFailed     = "TransitGatewayAttachmentDeletionFailed"
+	EventReasonAttachmentRecreated          = "TransitGatewayAttachmentRecreated"
+	EventReasonPrefixListEntriesAdded       = "PrefixListEntriesAdded"
+	EventReasonPrefixListEntriesRemoved     = "PrefixListEntriesRemoved"
+	EventReasonPrefixListModificationFailed = "PrefixListModificationFailed"
+	EventReasonPrefixListDeleted            = "PrefixListDeleted"
+	EventReasonPrefixListDeletionFailed     = "PrefixListDeletionFailed"
+	EventReasonResourceShareDeleted         = "ResourceShareDeleted"
+	EventReasonResourceShareDeletionFailed  = "ResourceShareDeletionFailed"
+	EventReasonResourcesRetained            = "ResourcesRetained"
+	EventReasonResourcesOrphaned            = "ResourcesOrphaned"
+	EventReasonSNSMessagePublished          = "SNSMessagePublished"
+	EventReasonSNSMessagePublicationFailed  = "SNSMessagePublicationFailed"
+)
+
+var clusterContextKey contextKey = "cluster"
+
+// withCluster returns a context carrying the cluster events are recorded on.
+func withCluster(ctx context.Context, cluster *capi.Cluster) context.Context {
+	ctx = context.WithValue(ctx, clusterNameContextKey, cluster.ObjectMeta.Name)
+	return context.WithValue(ctx, clusterContextKey, cluster)
+}
+
+// recordEvent records an event on the cluster of the context. Nothing is recorded if the
+// context carries no cluster or the registrar has no event recorder.
+func (r *TransitGateway) recordEvent(ctx context.Context, eventType, reason, messageFmt string, args ...interface{}) {
+	cluster, ok := ctx.Value(clusterContextKey).(*capi.Cluster)
+	if !ok || r.recorder == nil {
+		return
+	}
+
+	r.recorder.Eventf(cluster, eventType, reason, messageFmt, args...)
+}
+
+// recordWarning records a warning event on the cluster of the context.
+func (r *TransitGateway) recordWarning(ctx context.Context, reason, messageFmt string, args ...interface{}) {
+	r.recordEvent(ctx, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// recordNormal records a normal event on the cluster of the context.
+func (r *TransitGateway) recordNormal(ctx context.Context, reason, messageFmt string, args ...interface{}) {
+	r.recordEvent(ctx, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
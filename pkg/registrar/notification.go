@@ -0,0 +1,156 @@
+package registrar
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+)
+
+const (
+	// AttachmentRequestSchemaVersion is the version of the schema of the
+	// AttachmentRequest published to SNS. It is increased on incompatible
+	// changes of the message.
+	AttachmentRequestSchemaVersion = "v1"
+
+	// DefaultContactAddress is the contact address of attachment requests if
+	// none is configured.
+	DefaultContactAddress = "support@giantswarm.io"
+
+	// AcceptAttachmentAction is the EC2 API action accepting a transit gateway
+	// VPC attachment.
+	AcceptAttachmentAction = "AcceptTransitGatewayVpcAttachment"
+)
+
+// NotificationOptions configures the SNS messages requesting the acceptance of
+// transit gateway attachments in UserManaged mode.
+type NotificationOptions struct {
+	// ContactAddress is sent as contact of the request. DefaultContactAddress
+	// is used if empty.
+	ContactAddress string
+
+	// Attributes are added to the SNS message attributes of every request.
+	Attributes map[string]string
+
+	// CallbackURL is sent as hint where to report the acceptance of the
+	// attachment. It is omitted if empty.
+	CallbackURL string
+
+	// ReminderInterval is the time after which the request is sent again while
+	// the attachment is still pending acceptance. No reminders are sent if it
+	// is zero.
+	ReminderInterval time.Duration
+}
+
+// AttachmentRequest is the JSON body of the SNS message requesting the
+// acceptance of a transit gateway VPC attachment.
+type AttachmentRequest struct {
+	SchemaVersion     string                    `json:"schemaVersion"`
+	Cluster           AttachmentRequestCluster  `json:"cluster"`
+	ManagementCluster string                    `json:"managementCluster"`
+	AccountID         string                    `json:"accountID"`
+	Region            string                    `json:"region"`
+	VPCID             string                    `json:"vpcID"`
+	TransitGatewayID  string                    `json:"transitGatewayID"`
+	AttachmentID      string                    `json:"attachmentID"`
+	CIDRs             []string                  `json:"cidrs"`
+	IPv6CIDRs         []string                  `json:"ipv6CIDRs,omitempty"`
+	Contact           string                    `json:"contact"`
+	Callback          AttachmentRequestCallback `json:"callback"`
+}
+
+// AttachmentRequestCluster is the cluster requesting the attachment.
+type AttachmentRequestCluster struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// AttachmentRequestCallback hints how to accept the attachment.
+type AttachmentRequestCallback struct {
+	// Action is the EC2 API action accepting the attachment in the account of
+	// the transit gateway.
+	Action string `json:"action"`
+
+	// URL to report the acceptance to, if configured.
+	URL string `json:"url,omitempty"`
+}
+
+// notificationDue returns true if the acceptance of the attachment wasn't
+// requested yet or a reminder is due. No reminders are sent once the owner of
+// the transit gateway responded.
+func (o NotificationOptions) notificationDue(status *v1alpha1.AttachmentAcceptanceRequestStatus, now time.Time) bool {
+	if status == nil || status.LastNotificationTime == nil {
+		return true
+	}
+	if o.ReminderInterval <= 0 || status.Response != nil {
+		return false
+	}
+	return !now.Before(status.LastNotificationTime.Add(o.ReminderInterval))
+}
+
+func (o NotificationOptions) withDefaults() NotificationOptions {
+	if o.ContactAddress == "" {
+		o.ContactAddress = DefaultContactAddress
+	}
+	return o
+}
+
+// newAttachmentRequest returns the AttachmentRequest of the options with the
+// contact and callback set.
+func (o NotificationOptions) newAttachmentRequest() AttachmentRequest {
+	return AttachmentRequest{
+		SchemaVersion: AttachmentRequestSchemaVersion,
+		Contact:       o.ContactAddress,
+		Callback: AttachmentRequestCallback{
+			Action: AcceptAttachmentAction,
+			URL:    o.CallbackURL,
+		},
+	}
+}
+
+// publishInput returns the SNS message of the request, the notification is the
+// number of the message for the attachment. Besides the JSON body the message
+// keeps the attributes of the previous plain text message for existing
+// subscribers.
+func (o NotificationOptions) publishInput(request AttachmentRequest, notification int32) (*sns.PublishInput, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode attachment request")
+	}
+
+	attributes := map[string]snstypes.MessageAttributeValue{}
+	setAttribute := func(key, value string) {
+		// SNS rejects empty attribute values
+		if value != "" {
+			attributes[key] = snstypes.MessageAttributeValue{DataType: awssdk.String("String"), StringValue: awssdk.String(value)}
+		}
+	}
+
+	for key, value := range o.Attributes {
+		setAttribute(key, value)
+	}
+
+	setAttribute("Schema_Version", request.SchemaVersion)
+	setAttribute("Postfach", request.Contact)
+	setAttribute("Account_ID", request.AccountID)
+	setAttribute("Attachment_ID", request.AttachmentID)
+	if len(request.CIDRs) > 0 {
+		setAttribute("CIDR", request.CIDRs[0])
+	}
+	setAttribute("Name", request.Cluster.Name)
+
+	return &sns.PublishInput{
+		Message:           awssdk.String(string(body)),
+		MessageAttributes: attributes,
+		// Only used by FIFO topics. Every notification of an attachment has its
+		// own deduplication ID, so reminders aren't dropped but retries are.
+		MessageGroupId:         awssdk.String(request.AttachmentID),
+		MessageDeduplicationId: awssdk.String(fmt.Sprintf("%s-%d", request.AttachmentID, notification)),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package registrar
+
+import (
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+)
+
+// TransitGatewayOptions configures the TransitGateway registrar.
+type TransitGatewayOptions struct {
+	// PrefixListMaxEntriesCeiling is the maximum number of entries a full
+	// prefix list is grown to. PREFIX_LIST_MAX_ENTRIES_CEILING is used if zero.
+	PrefixListMaxEntriesCeiling int32
+
+	// AttachmentRecreationLimit is the number of times a failed or deleted
+	// attachment is recreated. Attachments aren't recreated if it is zero.
+	AttachmentRecreationLimit int32
+
+	// DeletionPolicy is used for clusters whose NetworkTopology doesn't set
+	// one. DeletionPolicyDelete is used if empty.
+	DeletionPolicy v1alpha1.DeletionPolicy
+
+	// ResourceShareClient deletes the RAM resource shares of the prefix lists
+	// of a deleted management cluster. The resource shares are kept if nil.
+	ResourceShareClient awsclient.ResourceShareClient
+
+	// Notification configures the SNS attachment requests in UserManaged
+	// mode.
+	Notification NotificationOptions
+}
+
+func (o TransitGatewayOptions) withDefaults() TransitGatewayOptions {
+	if o.PrefixListMaxEntriesCeiling == 0 {
+		o.PrefixListMaxEntriesCeiling = PREFIX_LIST_MAX_ENTRIES_CEILING
+	}
+	if o.DeletionPolicy == "" {
+		o.DeletionPolicy = v1alpha1.DeletionPolicyDelete
+	}
+	o.Notification = o.Notification.withDefaults()
+	return o
+}
@@ -0,0 +1,574 @@
+package registrar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/go-logr/logr"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+)
+
+// TransitGatewayPeering peers the transit gateway of a management cluster with the transit
+// gateways of other management clusters or regions and routes the peer CIDRs through the
+// peering attachments.
+//
+// When both sides of a peering are managed by the operator, the side with the lower transit
+// gateway ID requests the peering and the other side accepts it. If both transit gateways
+// are owned by the same account the requester accepts the peering itself.
+//
+// The peerings are only deleted with the management cluster if its deletion policy is
+// Delete, so the other side keeps its routes while the management cluster is rebuilt.
+type TransitGatewayPeering struct {
+	transitGatewayClient awsclient.TransitGatewayClient
+	clusterClient        ClusterClient
+	deletionPolicy       v1alpha1.DeletionPolicy
+	routeTables          *routeTables
+}
+
+func NewTransitGatewayPeering(transitGatewayClient awsclient.TransitGatewayClient, clusterClient ClusterClient, deletionPolicy v1alpha1.DeletionPolicy) *TransitGatewayPeering {
+	if deletionPolicy == "" {
+		deletionPolicy = v1alpha1.DeletionPolicyDelete
+	}
+	return &TransitGatewayPeering{
+		transitGatewayClient: transitGatewayClient,
+		clusterClient:        clusterClient,
+		deletionPolicy:       deletionPolicy,
+		routeTables: &routeTables{
+			transitGatewayClient: transitGatewayClient,
+		},
+	}
+}
+
+func (r *TransitGatewayPeering) Register(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) error {
+	ctx = context.WithValue(ctx, clusterNameContextKey, cluster.ObjectMeta.Name)
+	logger := r.getLogger(ctx)
+
+	if !r.isPeeringSupported(ctx, cluster, topology) {
+		return nil
+	}
+	if len(topology.Spec.Peerings) == 0 && len(topology.Status.Peerings) == 0 {
+		return nil
+	}
+
+	tgw, err := r.getTransitGateway(ctx, topology)
+	if err != nil {
+		return err
+	}
+	if tgw == nil || tgw.State != types.TransitGatewayStateAvailable {
+		logger.Info("transit gateway not available, skipping peering for now")
+		return &TransitGatewayNotAvailableError{}
+	}
+
+	attachments, err := r.getPeeringAttachments(ctx, *tgw.TransitGatewayId)
+	if err != nil {
+		return err
+	}
+
+	ready := true
+	desired := map[string]bool{}
+	statuses := []v1alpha1.TransitGatewayPeeringStatus{}
+	for _, peering := range topology.Spec.Peerings {
+		peerID, err := aws.GetARNResourceID(peering.TransitGatewayARN)
+		if err != nil {
+			logger.Error(err, "Failed to parse peer transit gateway ARN", "peerTransitGatewayARN", peering.TransitGatewayARN)
+			return err
+		}
+		desired[peerID] = true
+
+		status, err := r.reconcilePeering(ctx, tgw, peering, attachments[peerID])
+		if errors.Is(err, &TransitGatewayPeeringNotReadyError{}) {
+			ready = false
+		} else if err != nil {
+			return err
+		}
+		statuses = append(statuses, *status)
+	}
+
+	for _, status := range topology.Status.Peerings {
+		peerID, err := aws.GetARNResourceID(status.TransitGatewayARN)
+		if err != nil || desired[peerID] {
+			continue
+		}
+
+		if err := r.deletePeering(ctx, status, attachments[peerID]); err != nil {
+			return err
+		}
+	}
+
+	topology.Status.Peerings = statuses
+
+	if !ready {
+		return &TransitGatewayPeeringNotReadyError{}
+	}
+
+	logger.Info("Done registering TransitGatewayPeerings")
+	return nil
+}
+
+func (r *TransitGatewayPeering) Unregister(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) error {
+	logger := r.getLogger(ctx)
+
+	if !r.isPeeringSupported(ctx, cluster, topology) {
+		return nil
+	}
+	if len(topology.Spec.Peerings) == 0 && len(topology.Status.Peerings) == 0 {
+		return nil
+	}
+
+	if deletionPolicy := topology.GetDeletionPolicy(r.deletionPolicy); deletionPolicy != v1alpha1.DeletionPolicyDelete {
+		logger.Info("Keeping transit gateway peerings", "deletionPolicy", deletionPolicy)
+		return nil
+	}
+
+	tgw, err := r.getTransitGateway(ctx, topology)
+	if err != nil {
+		return err
+	}
+	if tgw == nil {
+		logger.Info("Transit gateway already deleted, skipping peering deletion")
+		topology.Status.Peerings = nil
+		return nil
+	}
+
+	attachments, err := r.getPeeringAttachments(ctx, *tgw.TransitGatewayId)
+	if err != nil {
+		return err
+	}
+
+	statuses := map[string]v1alpha1.TransitGatewayPeeringStatus{}
+	for _, status := range topology.Status.Peerings {
+		statuses[status.TransitGatewayARN] = status
+	}
+	for _, peering := range topology.Spec.Peerings {
+		if _, ok := statuses[peering.TransitGatewayARN]; !ok {
+			statuses[peering.TransitGatewayARN] = v1alpha1.TransitGatewayPeeringStatus{TransitGatewayARN: peering.TransitGatewayARN}
+		}
+	}
+
+	for _, status := range statuses {
+		peerID, err := aws.GetARNResourceID(status.TransitGatewayARN)
+		if err != nil {
+			continue
+		}
+
+		if err := r.deletePeering(ctx, status, attachments[peerID]); err != nil {
+			return err
+		}
+	}
+	topology.Status.Peerings = nil
+
+	logger.Info("Done unregistering TransitGatewayPeerings")
+	return nil
+}
+
+func (r *TransitGatewayPeering) getLogger(ctx context.Context) logr.Logger {
+	logger := log.FromContext(ctx)
+	return logger.WithName("transitgatewaypeering-registrar")
+}
+
+// isPeeringSupported returns true for management clusters owning their transit gateway
+func (r *TransitGatewayPeering) isPeeringSupported(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) bool {
+	return topology.Spec.Mode == v1alpha1.NetworkTopologyModeGiantSwarmManaged && r.clusterClient.IsManagementCluster(ctx, cluster)
+}
+
+func (r *TransitGatewayPeering) getTransitGateway(ctx context.Context, topology *v1alpha1.NetworkTopology) (*types.TransitGateway, error) {
+	logger := r.getLogger(ctx)
+
+	gatewayID, err := getTransitGatewayID(logger, topology)
+	if err != nil {
+		return nil, err
+	}
+	if gatewayID == "" {
+		return nil, nil
+	}
+
+	output, err := r.transitGatewayClient.DescribeTransitGateways(ctx, &ec2.DescribeTransitGatewaysInput{
+		TransitGatewayIds: []string{gatewayID},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to describe TransitGateways", "transitGatewayID", gatewayID)
+		return nil, err
+	}
+	if len(output.TransitGateways) != 1 {
+		return nil, nil
+	}
+
+	return &output.TransitGateways[0], nil
+}
+
+// getPeeringAttachments returns the active peering attachments of the transit gateway by
+// the ID of the peer transit gateway, regardless of which side requested the peering.
+func (r *TransitGatewayPeering) getPeeringAttachments(ctx context.Context, gatewayID string) (map[string]*types.TransitGatewayPeeringAttachment, error) {
+	logger := r.getLogger(ctx)
+
+	output, err := r.transitGatewayClient.DescribeTransitGatewayPeeringAttachments(ctx, &ec2.DescribeTransitGatewayPeeringAttachmentsInput{
+		Filters: []types.Filter{
+			{
+				Name:   awssdk.String("transit-gateway-id"),
+				Values: []string{gatewayID},
+			},
+		},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to describe transit gateway peering attachments", "transitGatewayID", gatewayID)
+		return nil, err
+	}
+
+	attachments := map[string]*types.TransitGatewayPeeringAttachment{}
+	for i := range output.TransitGatewayPeeringAttachments {
+		attachment := &output.TransitGatewayPeeringAttachments[i]
+		switch attachment.State {
+		case types.TransitGatewayAttachmentStateDeleting,
+			types.TransitGatewayAttachmentStateDeleted,
+			types.TransitGatewayAttachmentStateFailing,
+			types.TransitGatewayAttachmentStateFailed,
+			types.TransitGatewayAttachmentStateRejecting,
+			types.TransitGatewayAttachmentStateRejected:
+			continue
+		}
+
+		if attachment.RequesterTgwInfo == nil || attachment.AccepterTgwInfo == nil {
+			continue
+		}
+
+		peerInfo := attachment.AccepterTgwInfo
+		if awssdk.StringValue(attachment.AccepterTgwInfo.TransitGatewayId) == gatewayID {
+			peerInfo = attachment.RequesterTgwInfo
+		}
+		attachments[awssdk.StringValue(peerInfo.TransitGatewayId)] = attachment
+	}
+
+	return attachments, nil
+}
+
+func (r *TransitGatewayPeering) reconcilePeering(ctx context.Context, tgw *types.TransitGateway, peering v1alpha1.TransitGatewayPeeringSpec, attachment *types.TransitGatewayPeeringAttachment) (*v1alpha1.TransitGatewayPeeringStatus, error) {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("transitGatewayID", tgw.TransitGatewayId, "peerTransitGatewayARN", peering.TransitGatewayARN)
+
+	status := &v1alpha1.TransitGatewayPeeringStatus{
+		TransitGatewayARN: peering.TransitGatewayARN,
+	}
+
+	localARN, err := arn.Parse(awssdk.StringValue(tgw.TransitGatewayArn))
+	if err != nil {
+		logger.Error(err, "Failed to parse transit gateway ARN")
+		return nil, err
+	}
+	peerARN, err := arn.Parse(peering.TransitGatewayARN)
+	if err != nil {
+		logger.Error(err, "Failed to parse peer transit gateway ARN")
+		return nil, err
+	}
+	peerID, err := aws.GetARNResourceID(peering.TransitGatewayARN)
+	if err != nil {
+		logger.Error(err, "Failed to parse peer transit gateway ARN")
+		return nil, err
+	}
+
+	if attachment == nil {
+		if !isPeeringRequester(*tgw.TransitGatewayId, peerID) {
+			logger.Info("Waiting for the peer to request the transit gateway peering")
+			return status, &TransitGatewayPeeringNotReadyError{}
+		}
+
+		output, err := r.transitGatewayClient.CreateTransitGatewayPeeringAttachment(ctx, &ec2.CreateTransitGatewayPeeringAttachmentInput{
+			TransitGatewayId:     tgw.TransitGatewayId,
+			PeerTransitGatewayId: &peerID,
+			PeerAccountId:        &peerARN.AccountID,
+			PeerRegion:           &peerARN.Region,
+			TagSpecifications: []types.TagSpecification{
+				{
+					ResourceType: types.ResourceTypeTransitGatewayAttachment,
+					Tags: []types.Tag{
+						{
+							Key:   awssdk.String("Name"),
+							Value: awssdk.String(fmt.Sprintf("%s-%s", ctx.Value(clusterNameContextKey), peerID)),
+						},
+						{
+							Key:   awssdk.String(fmt.Sprintf("kubernetes.io/cluster/%s", ctx.Value(clusterNameContextKey))),
+							Value: awssdk.String("owned"),
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			logger.Error(err, "Failed to create transit gateway peering attachment")
+			return nil, err
+		}
+
+		attachment = output.TransitGatewayPeeringAttachment
+		logger.Info("Requested transit gateway peering", "transitGatewayAttachmentID", attachment.TransitGatewayAttachmentId)
+	}
+
+	status.AttachmentID = awssdk.StringValue(attachment.TransitGatewayAttachmentId)
+	status.State = string(attachment.State)
+
+	switch attachment.State {
+	case types.TransitGatewayAttachmentStatePendingAcceptance:
+		return status, r.acceptPeering(ctx, tgw, localARN, peerARN, attachment)
+
+	case types.TransitGatewayAttachmentStateAvailable:
+
+	default:
+		logger.Info("Transit gateway peering attachment not yet available", "state", attachment.State)
+		return status, &TransitGatewayPeeringNotReadyError{}
+	}
+
+	routeTableID, err := r.getPeeringRouteTableID(ctx, tgw, peering)
+	if err != nil {
+		return status, err
+	}
+
+	err = r.routeTables.associateRouteTable(ctx, status.AttachmentID, routeTableID)
+	if errors.Is(err, &RouteTableNotReadyError{}) {
+		return status, &TransitGatewayPeeringNotReadyError{}
+	} else if err != nil {
+		return status, err
+	}
+	status.RouteTableID = routeTableID
+
+	cidrs, err := r.getPeerCIDRs(ctx, peering.PrefixListARN)
+	if err != nil {
+		return status, err
+	}
+
+	if err := r.reconcileStaticRoutes(ctx, routeTableID, status.AttachmentID, cidrs); err != nil {
+		return status, err
+	}
+	status.Routes = cidrs
+
+	return status, nil
+}
+
+// acceptPeering accepts the peering attachment if this side of the peering is allowed to do
+// so, which is the case if the local transit gateway is the accepter or if both transit
+// gateways are owned by the same account.
+func (r *TransitGatewayPeering) acceptPeering(ctx context.Context, tgw *types.TransitGateway, localARN, peerARN arn.ARN, attachment *types.TransitGatewayPeeringAttachment) error {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("transitGatewayAttachmentID", attachment.TransitGatewayAttachmentId)
+
+	var accepterID string
+	if attachment.AccepterTgwInfo != nil {
+		accepterID = awssdk.StringValue(attachment.AccepterTgwInfo.TransitGatewayId)
+	}
+
+	var optFns []func(*ec2.Options)
+	if accepterID != *tgw.TransitGatewayId {
+		if localARN.AccountID != peerARN.AccountID {
+			logger.Info("Waiting for the peer to accept the transit gateway peering")
+			return &TransitGatewayPeeringNotReadyError{}
+		}
+
+		// The peering needs to be accepted in the region of the peer
+		optFns = append(optFns, withRegion(peerARN.Region))
+	}
+
+	_, err := r.transitGatewayClient.AcceptTransitGatewayPeeringAttachment(ctx, &ec2.AcceptTransitGatewayPeeringAttachmentInput{
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+	}, optFns...)
+	if err != nil {
+		logger.Error(err, "Failed to accept transit gateway peering attachment")
+		return err
+	}
+
+	logger.Info("Accepted transit gateway peering")
+	return &TransitGatewayPeeringNotReadyError{}
+}
+
+// getPeeringRouteTableID returns the route table containing the routes to the peer. If the
+// route tables of the transit gateway aren't managed by the operator the default association
+// route table is used.
+func (r *TransitGatewayPeering) getPeeringRouteTableID(ctx context.Context, tgw *types.TransitGateway, peering v1alpha1.TransitGatewayPeeringSpec) (string, error) {
+	group := peering.RouteTableGroup
+	if group == "" {
+		if tgw.Options != nil && tgw.Options.DefaultRouteTableAssociation == types.DefaultRouteTableAssociationValueEnable && tgw.Options.AssociationDefaultRouteTableId != nil {
+			return *tgw.Options.AssociationDefaultRouteTableId, nil
+		}
+		group = DefaultRouteTableGroup
+	}
+
+	routeTable, err := r.routeTables.getOrCreateRouteTable(ctx, *tgw.TransitGatewayId, group)
+	if errors.Is(err, &RouteTableNotReadyError{}) {
+		return "", &TransitGatewayPeeringNotReadyError{}
+	} else if err != nil {
+		return "", err
+	}
+
+	return *routeTable.TransitGatewayRouteTableId, nil
+}
+
+// getPeerCIDRs returns the CIDRs of the peer prefix list, which is read in the region of the peer.
+// The prefix list is read with the credentials of the management cluster, so a prefix list owned
+// by another account needs to be shared with this account through RAM.
+func (r *TransitGatewayPeering) getPeerCIDRs(ctx context.Context, prefixListARN string) ([]string, error) {
+	logger := r.getLogger(ctx)
+
+	if prefixListARN == "" {
+		return nil, nil
+	}
+
+	parsedARN, err := arn.Parse(prefixListARN)
+	if err != nil {
+		logger.Error(err, "Failed to parse peer prefix list ARN", "prefixListARN", prefixListARN)
+		return nil, err
+	}
+	prefixListID, err := aws.GetARNResourceID(prefixListARN)
+	if err != nil {
+		logger.Error(err, "Failed to parse peer prefix list ARN", "prefixListARN", prefixListARN)
+		return nil, err
+	}
+
+	cidrs := []string{}
+	input := &ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId: &prefixListID,
+		MaxResults:   awssdk.Int32(100),
+	}
+	for {
+		output, err := r.transitGatewayClient.GetManagedPrefixListEntries(ctx, input, withRegion(parsedARN.Region))
+		if awsclient.HasErrorCode(err, awsclient.ErrPrefixListNotFound) {
+			err = &PeerPrefixListNotSharedError{PrefixListARN: prefixListARN, error: err}
+			logger.Error(err, "Peer prefix list not found, it needs to be shared with the account of the management cluster", "prefixListARN", prefixListARN)
+			return nil, err
+		}
+		if err != nil {
+			logger.Error(err, "Failed to get peer prefix list entries", "prefixListARN", prefixListARN)
+			return nil, err
+		}
+
+		for _, entry := range output.Entries {
+			cidrs = append(cidrs, *entry.Cidr)
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return cidrs, nil
+}
+
+// reconcileStaticRoutes ensures the route table contains exactly one static route through the
+// peering attachment for each of the given CIDRs. SearchTransitGatewayRoutes doesn't paginate,
+// so nothing is created or deleted if AWS reports more routes than a single search returns.
+func (r *TransitGatewayPeering) reconcileStaticRoutes(ctx context.Context, routeTableID, attachmentID string, cidrs []string) error {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("routeTableID", routeTableID, "transitGatewayAttachmentID", attachmentID)
+
+	output, err := r.transitGatewayClient.SearchTransitGatewayRoutes(ctx, &ec2.SearchTransitGatewayRoutesInput{
+		TransitGatewayRouteTableId: &routeTableID,
+		Filters: []types.Filter{
+			{
+				Name:   awssdk.String("attachment.transit-gateway-attachment-id"),
+				Values: []string{attachmentID},
+			},
+			{
+				Name:   awssdk.String("type"),
+				Values: []string{string(types.TransitGatewayRouteTypeStatic)},
+			},
+		},
+		MaxResults: awssdk.Int32(1000),
+	})
+	if err != nil {
+		logger.Error(err, "Failed to search transit gateway routes")
+		return err
+	}
+	if output.AdditionalRoutesAvailable != nil && *output.AdditionalRoutesAvailable {
+		err = &TooManyTransitGatewayRoutesError{RouteTableID: routeTableID, AttachmentID: attachmentID}
+		logger.Error(err, "Too many transit gateway routes to reconcile")
+		return err
+	}
+
+	current := map[string]bool{}
+	for _, route := range output.Routes {
+		if route.DestinationCidrBlock != nil {
+			current[*route.DestinationCidrBlock] = true
+		}
+	}
+
+	desired := map[string]bool{}
+	for _, cidr := range cidrs {
+		desired[cidr] = true
+		if current[cidr] {
+			continue
+		}
+
+		_, err := r.transitGatewayClient.CreateTransitGatewayRoute(ctx, &ec2.CreateTransitGatewayRouteInput{
+			DestinationCidrBlock:       awssdk.String(cidr),
+			TransitGatewayRouteTableId: &routeTableID,
+			TransitGatewayAttachmentId: &attachmentID,
+		})
+		if err != nil {
+			logger.Error(err, "Failed to create transit gateway route", "cidr", cidr)
+			return err
+		}
+		logger.Info("Created transit gateway route", "cidr", cidr)
+	}
+
+	for cidr := range current {
+		if desired[cidr] {
+			continue
+		}
+
+		_, err := r.transitGatewayClient.DeleteTransitGatewayRoute(ctx, &ec2.DeleteTransitGatewayRouteInput{
+			DestinationCidrBlock:       awssdk.String(cidr),
+			TransitGatewayRouteTableId: &routeTableID,
+		})
+		if err != nil {
+			logger.Error(err, "Failed to delete transit gateway route", "cidr", cidr)
+			return err
+		}
+		logger.Info("Deleted transit gateway route", "cidr", cidr)
+	}
+
+	return nil
+}
+
+func (r *TransitGatewayPeering) deletePeering(ctx context.Context, status v1alpha1.TransitGatewayPeeringStatus, attachment *types.TransitGatewayPeeringAttachment) error {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("peerTransitGatewayARN", status.TransitGatewayARN)
+
+	if status.RouteTableID != "" && status.AttachmentID != "" {
+		if err := r.reconcileStaticRoutes(ctx, status.RouteTableID, status.AttachmentID, nil); err != nil {
+			return err
+		}
+	}
+
+	if attachment == nil {
+		return nil
+	}
+
+	_, err := r.transitGatewayClient.DeleteTransitGatewayPeeringAttachment(ctx, &ec2.DeleteTransitGatewayPeeringAttachmentInput{
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to delete transit gateway peering attachment", "transitGatewayAttachmentID", attachment.TransitGatewayAttachmentId)
+		return err
+	}
+
+	logger.Info("Deleted transit gateway peering attachment", "transitGatewayAttachmentID", attachment.TransitGatewayAttachmentId)
+	return nil
+}
+
+// isPeeringRequester decides which side of a peering creates the peering attachment so that
+// two operators peering with each other don't create duplicate attachments.
+func isPeeringRequester(gatewayID, peerGatewayID string) bool {
+	return gatewayID < peerGatewayID
+}
+
+func withRegion(region string) func(*ec2.Options) {
+	return func(o *ec2.Options) {
+		o.Region = region
+	}
+}
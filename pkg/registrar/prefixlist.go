@@ -0,0 +1,105 @@
+package registrar
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+)
+
+// prefixListConflictBackoff is used to retry prefix list modifications that failed because
+// the prefix list was modified concurrently.
+var prefixListConflictBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 500 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// prefixListLocks serializes the modifications of a prefix list within the process.
+var prefixListLocks = &keyedMutex{}
+
+// keyedMutex provides a mutex per key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// lock acquires the mutex of the key and returns the function releasing it.
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = map[string]*sync.Mutex{}
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		k.locks[key] = l
+	}
+	k.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// isPrefixListConflict returns true if the prefix list was modified since its version was read
+// or a previous modification is still in progress.
+func isPrefixListConflict(err error) bool {
+	return awsclient.HasErrorCode(err, awsclient.ErrPrefixListVersionMismatch) ||
+		awsclient.HasErrorCode(err, awsclient.ErrIncorrectState)
+}
+
+// modifyPrefixList calls modify while holding the lock of the prefix list. If modify fails
+// because of a version conflict the prefix list is read again and modify is retried with
+// the new version.
+func (r *TransitGateway) modifyPrefixList(ctx context.Context, prefixList *types.ManagedPrefixList, modify func(prefixList *types.ManagedPrefixList) error) error {
+	logger := r.getLogger(ctx)
+
+	prefixListID := *prefixList.PrefixListId
+	unlock := prefixListLocks.lock(prefixListID)
+	defer unlock()
+
+	current := prefixList
+	return retry.OnError(prefixListConflictBackoff, isPrefixListConflict, func() error {
+		if current == nil {
+			refreshed, err := r.getPrefixList(ctx, prefixListID)
+			if err != nil {
+				return err
+			}
+			current = refreshed
+		}
+
+		err := modify(current)
+		if isPrefixListConflict(err) {
+			logger.Info("Prefix list was modified concurrently, retrying", "prefixListID", prefixListID, "version", current.Version)
+			current = nil
+		}
+		return err
+	})
+}
+
+// getPrefixList returns the current state of the prefix list with the given ID.
+func (r *TransitGateway) getPrefixList(ctx context.Context, prefixListID string) (*types.ManagedPrefixList, error) {
+	logger := r.getLogger(ctx)
+
+	result, err := r.transitGatewayClient.DescribeManagedPrefixLists(ctx, &ec2.DescribeManagedPrefixListsInput{
+		PrefixListIds: []string{prefixListID},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to get prefix list", "prefixListID", prefixListID)
+		return nil, err
+	}
+
+	if result == nil || len(result.PrefixLists) != 1 {
+		return nil, fmt.Errorf("prefix list %s not found", prefixListID)
+	}
+
+	return &result.PrefixLists[0], nil
+}
@@ -0,0 +1,13 @@
+package registrar_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRegistrar(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Registrar Suite")
+}
@@ -0,0 +1,424 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package registrarfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	v1beta1a "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type FakeClusterClient struct {
+	GetAWSClusterStub        func(context.Context, types.NamespacedName) (*v1beta1.AWSCluster, error)
+	getAWSClusterMutex       sync.RWMutex
+	getAWSClusterArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}
+	getAWSClusterReturns struct {
+		result1 *v1beta1.AWSCluster
+		result2 error
+	}
+	getAWSClusterReturnsOnCall map[int]struct {
+		result1 *v1beta1.AWSCluster
+		result2 error
+	}
+	GetManagementClusterStub        func(context.Context) (*v1beta1a.Cluster, error)
+	getManagementClusterMutex       sync.RWMutex
+	getManagementClusterArgsForCall []struct {
+		arg1 context.Context
+	}
+	getManagementClusterReturns struct {
+		result1 *v1beta1a.Cluster
+		result2 error
+	}
+	getManagementClusterReturnsOnCall map[int]struct {
+		result1 *v1beta1a.Cluster
+		result2 error
+	}
+	GetManagementClusterNamespacedNameStub        func() types.NamespacedName
+	getManagementClusterNamespacedNameMutex       sync.RWMutex
+	getManagementClusterNamespacedNameArgsForCall []struct {
+	}
+	getManagementClusterNamespacedNameReturns struct {
+		result1 types.NamespacedName
+	}
+	getManagementClusterNamespacedNameReturnsOnCall map[int]struct {
+		result1 types.NamespacedName
+	}
+	GetNetworkTopologyStub        func(context.Context, types.NamespacedName) (*v1alpha1.NetworkTopology, error)
+	getNetworkTopologyMutex       sync.RWMutex
+	getNetworkTopologyArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}
+	getNetworkTopologyReturns struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}
+	getNetworkTopologyReturnsOnCall map[int]struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}
+	IsManagementClusterStub        func(context.Context, *v1beta1a.Cluster) bool
+	isManagementClusterMutex       sync.RWMutex
+	isManagementClusterArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1beta1a.Cluster
+	}
+	isManagementClusterReturns struct {
+		result1 bool
+	}
+	isManagementClusterReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClusterClient) GetAWSCluster(arg1 context.Context, arg2 types.NamespacedName) (*v1beta1.AWSCluster, error) {
+	fake.getAWSClusterMutex.Lock()
+	ret, specificReturn := fake.getAWSClusterReturnsOnCall[len(fake.getAWSClusterArgsForCall)]
+	fake.getAWSClusterArgsForCall = append(fake.getAWSClusterArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}{arg1, arg2})
+	stub := fake.GetAWSClusterStub
+	fakeReturns := fake.getAWSClusterReturns
+	fake.recordInvocation("GetAWSCluster", []interface{}{arg1, arg2})
+	fake.getAWSClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterClient) GetAWSClusterCallCount() int {
+	fake.getAWSClusterMutex.RLock()
+	defer fake.getAWSClusterMutex.RUnlock()
+	return len(fake.getAWSClusterArgsForCall)
+}
+
+func (fake *FakeClusterClient) GetAWSClusterCalls(stub func(context.Context, types.NamespacedName) (*v1beta1.AWSCluster, error)) {
+	fake.getAWSClusterMutex.Lock()
+	defer fake.getAWSClusterMutex.Unlock()
+	fake.GetAWSClusterStub = stub
+}
+
+func (fake *FakeClusterClient) GetAWSClusterArgsForCall(i int) (context.Context, types.NamespacedName) {
+	fake.getAWSClusterMutex.RLock()
+	defer fake.getAWSClusterMutex.RUnlock()
+	argsForCall := fake.getAWSClusterArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterClient) GetAWSClusterReturns(result1 *v1beta1.AWSCluster, result2 error) {
+	fake.getAWSClusterMutex.Lock()
+	defer fake.getAWSClusterMutex.Unlock()
+	fake.GetAWSClusterStub = nil
+	fake.getAWSClusterReturns = struct {
+		result1 *v1beta1.AWSCluster
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) GetAWSClusterReturnsOnCall(i int, result1 *v1beta1.AWSCluster, result2 error) {
+	fake.getAWSClusterMutex.Lock()
+	defer fake.getAWSClusterMutex.Unlock()
+	fake.GetAWSClusterStub = nil
+	if fake.getAWSClusterReturnsOnCall == nil {
+		fake.getAWSClusterReturnsOnCall = make(map[int]struct {
+			result1 *v1beta1.AWSCluster
+			result2 error
+		})
+	}
+	fake.getAWSClusterReturnsOnCall[i] = struct {
+		result1 *v1beta1.AWSCluster
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) GetManagementCluster(arg1 context.Context) (*v1beta1a.Cluster, error) {
+	fake.getManagementClusterMutex.Lock()
+	ret, specificReturn := fake.getManagementClusterReturnsOnCall[len(fake.getManagementClusterArgsForCall)]
+	fake.getManagementClusterArgsForCall = append(fake.getManagementClusterArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetManagementClusterStub
+	fakeReturns := fake.getManagementClusterReturns
+	fake.recordInvocation("GetManagementCluster", []interface{}{arg1})
+	fake.getManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterClient) GetManagementClusterCallCount() int {
+	fake.getManagementClusterMutex.RLock()
+	defer fake.getManagementClusterMutex.RUnlock()
+	return len(fake.getManagementClusterArgsForCall)
+}
+
+func (fake *FakeClusterClient) GetManagementClusterCalls(stub func(context.Context) (*v1beta1a.Cluster, error)) {
+	fake.getManagementClusterMutex.Lock()
+	defer fake.getManagementClusterMutex.Unlock()
+	fake.GetManagementClusterStub = stub
+}
+
+func (fake *FakeClusterClient) GetManagementClusterArgsForCall(i int) context.Context {
+	fake.getManagementClusterMutex.RLock()
+	defer fake.getManagementClusterMutex.RUnlock()
+	argsForCall := fake.getManagementClusterArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClusterClient) GetManagementClusterReturns(result1 *v1beta1a.Cluster, result2 error) {
+	fake.getManagementClusterMutex.Lock()
+	defer fake.getManagementClusterMutex.Unlock()
+	fake.GetManagementClusterStub = nil
+	fake.getManagementClusterReturns = struct {
+		result1 *v1beta1a.Cluster
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) GetManagementClusterReturnsOnCall(i int, result1 *v1beta1a.Cluster, result2 error) {
+	fake.getManagementClusterMutex.Lock()
+	defer fake.getManagementClusterMutex.Unlock()
+	fake.GetManagementClusterStub = nil
+	if fake.getManagementClusterReturnsOnCall == nil {
+		fake.getManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 *v1beta1a.Cluster
+			result2 error
+		})
+	}
+	fake.getManagementClusterReturnsOnCall[i] = struct {
+		result1 *v1beta1a.Cluster
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) GetManagementClusterNamespacedName() types.NamespacedName {
+	fake.getManagementClusterNamespacedNameMutex.Lock()
+	ret, specificReturn := fake.getManagementClusterNamespacedNameReturnsOnCall[len(fake.getManagementClusterNamespacedNameArgsForCall)]
+	fake.getManagementClusterNamespacedNameArgsForCall = append(fake.getManagementClusterNamespacedNameArgsForCall, struct {
+	}{})
+	stub := fake.GetManagementClusterNamespacedNameStub
+	fakeReturns := fake.getManagementClusterNamespacedNameReturns
+	fake.recordInvocation("GetManagementClusterNamespacedName", []interface{}{})
+	fake.getManagementClusterNamespacedNameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterClient) GetManagementClusterNamespacedNameCallCount() int {
+	fake.getManagementClusterNamespacedNameMutex.RLock()
+	defer fake.getManagementClusterNamespacedNameMutex.RUnlock()
+	return len(fake.getManagementClusterNamespacedNameArgsForCall)
+}
+
+func (fake *FakeClusterClient) GetManagementClusterNamespacedNameCalls(stub func() types.NamespacedName) {
+	fake.getManagementClusterNamespacedNameMutex.Lock()
+	defer fake.getManagementClusterNamespacedNameMutex.Unlock()
+	fake.GetManagementClusterNamespacedNameStub = stub
+}
+
+func (fake *FakeClusterClient) GetManagementClusterNamespacedNameReturns(result1 types.NamespacedName) {
+	fake.getManagementClusterNamespacedNameMutex.Lock()
+	defer fake.getManagementClusterNamespacedNameMutex.Unlock()
+	fake.GetManagementClusterNamespacedNameStub = nil
+	fake.getManagementClusterNamespacedNameReturns = struct {
+		result1 types.NamespacedName
+	}{result1}
+}
+
+func (fake *FakeClusterClient) GetManagementClusterNamespacedNameReturnsOnCall(i int, result1 types.NamespacedName) {
+	fake.getManagementClusterNamespacedNameMutex.Lock()
+	defer fake.getManagementClusterNamespacedNameMutex.Unlock()
+	fake.GetManagementClusterNamespacedNameStub = nil
+	if fake.getManagementClusterNamespacedNameReturnsOnCall == nil {
+		fake.getManagementClusterNamespacedNameReturnsOnCall = make(map[int]struct {
+			result1 types.NamespacedName
+		})
+	}
+	fake.getManagementClusterNamespacedNameReturnsOnCall[i] = struct {
+		result1 types.NamespacedName
+	}{result1}
+}
+
+func (fake *FakeClusterClient) GetNetworkTopology(arg1 context.Context, arg2 types.NamespacedName) (*v1alpha1.NetworkTopology, error) {
+	fake.getNetworkTopologyMutex.Lock()
+	ret, specificReturn := fake.getNetworkTopologyReturnsOnCall[len(fake.getNetworkTopologyArgsForCall)]
+	fake.getNetworkTopologyArgsForCall = append(fake.getNetworkTopologyArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.NamespacedName
+	}{arg1, arg2})
+	stub := fake.GetNetworkTopologyStub
+	fakeReturns := fake.getNetworkTopologyReturns
+	fake.recordInvocation("GetNetworkTopology", []interface{}{arg1, arg2})
+	fake.getNetworkTopologyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyCallCount() int {
+	fake.getNetworkTopologyMutex.RLock()
+	defer fake.getNetworkTopologyMutex.RUnlock()
+	return len(fake.getNetworkTopologyArgsForCall)
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyCalls(stub func(context.Context, types.NamespacedName) (*v1alpha1.NetworkTopology, error)) {
+	fake.getNetworkTopologyMutex.Lock()
+	defer fake.getNetworkTopologyMutex.Unlock()
+	fake.GetNetworkTopologyStub = stub
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyArgsForCall(i int) (context.Context, types.NamespacedName) {
+	fake.getNetworkTopologyMutex.RLock()
+	defer fake.getNetworkTopologyMutex.RUnlock()
+	argsForCall := fake.getNetworkTopologyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyReturns(result1 *v1alpha1.NetworkTopology, result2 error) {
+	fake.getNetworkTopologyMutex.Lock()
+	defer fake.getNetworkTopologyMutex.Unlock()
+	fake.GetNetworkTopologyStub = nil
+	fake.getNetworkTopologyReturns = struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) GetNetworkTopologyReturnsOnCall(i int, result1 *v1alpha1.NetworkTopology, result2 error) {
+	fake.getNetworkTopologyMutex.Lock()
+	defer fake.getNetworkTopologyMutex.Unlock()
+	fake.GetNetworkTopologyStub = nil
+	if fake.getNetworkTopologyReturnsOnCall == nil {
+		fake.getNetworkTopologyReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1.NetworkTopology
+			result2 error
+		})
+	}
+	fake.getNetworkTopologyReturnsOnCall[i] = struct {
+		result1 *v1alpha1.NetworkTopology
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterClient) IsManagementCluster(arg1 context.Context, arg2 *v1beta1a.Cluster) bool {
+	fake.isManagementClusterMutex.Lock()
+	ret, specificReturn := fake.isManagementClusterReturnsOnCall[len(fake.isManagementClusterArgsForCall)]
+	fake.isManagementClusterArgsForCall = append(fake.isManagementClusterArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1beta1a.Cluster
+	}{arg1, arg2})
+	stub := fake.IsManagementClusterStub
+	fakeReturns := fake.isManagementClusterReturns
+	fake.recordInvocation("IsManagementCluster", []interface{}{arg1, arg2})
+	fake.isManagementClusterMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClusterClient) IsManagementClusterCallCount() int {
+	fake.isManagementClusterMutex.RLock()
+	defer fake.isManagementClusterMutex.RUnlock()
+	return len(fake.isManagementClusterArgsForCall)
+}
+
+func (fake *FakeClusterClient) IsManagementClusterCalls(stub func(context.Context, *v1beta1a.Cluster) bool) {
+	fake.isManagementClusterMutex.Lock()
+	defer fake.isManagementClusterMutex.Unlock()
+	fake.IsManagementClusterStub = stub
+}
+
+func (fake *FakeClusterClient) IsManagementClusterArgsForCall(i int) (context.Context, *v1beta1a.Cluster) {
+	fake.isManagementClusterMutex.RLock()
+	defer fake.isManagementClusterMutex.RUnlock()
+	argsForCall := fake.isManagementClusterArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClusterClient) IsManagementClusterReturns(result1 bool) {
+	fake.isManagementClusterMutex.Lock()
+	defer fake.isManagementClusterMutex.Unlock()
+	fake.IsManagementClusterStub = nil
+	fake.isManagementClusterReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeClusterClient) IsManagementClusterReturnsOnCall(i int, result1 bool) {
+	fake.isManagementClusterMutex.Lock()
+	defer fake.isManagementClusterMutex.Unlock()
+	fake.IsManagementClusterStub = nil
+	if fake.isManagementClusterReturnsOnCall == nil {
+		fake.isManagementClusterReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isManagementClusterReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeClusterClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getAWSClusterMutex.RLock()
+	defer fake.getAWSClusterMutex.RUnlock()
+	fake.getManagementClusterMutex.RLock()
+	defer fake.getManagementClusterMutex.RUnlock()
+	fake.getManagementClusterNamespacedNameMutex.RLock()
+	defer fake.getManagementClusterNamespacedNameMutex.RUnlock()
+	fake.getNetworkTopologyMutex.RLock()
+	defer fake.getNetworkTopologyMutex.RUnlock()
+	fake.isManagementClusterMutex.RLock()
+	defer fake.isManagementClusterMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClusterClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ registrar.ClusterClient = new(FakeClusterClient)
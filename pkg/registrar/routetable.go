@@ -0,0 +1,344 @@
+package registrar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
+)
+
+const (
+	// DefaultRouteTableGroup is used for clusters without a route table group which are
+	// attached to a transit gateway without default route table association.
+	DefaultRouteTableGroup = "default"
+
+	// RouteTableGroupTag is set on the transit gateway route tables managed by the operator.
+	RouteTableGroupTag = annotations.NetworkTopologyRouteTableGroupAnnotation
+)
+
+// routeTables manages the transit gateway route tables owned by the operator.
+type routeTables struct {
+	transitGatewayClient awsclient.TransitGatewayClient
+}
+
+func (r *routeTables) getLogger(ctx context.Context) logr.Logger {
+	logger := log.FromContext(ctx)
+	return logger.WithName("transitgateway-routetables")
+}
+
+// getRouteTableGroup returns the route table group the cluster should be associated with or
+// an empty string if the route tables of the transit gateway aren't managed by the operator.
+func getRouteTableGroup(topology *v1alpha1.NetworkTopology, tgw *types.TransitGateway) string {
+	if topology.Spec.RouteTableGroup != "" {
+		return topology.Spec.RouteTableGroup
+	}
+
+	if tgw.Options != nil && tgw.Options.DefaultRouteTableAssociation == types.DefaultRouteTableAssociationValueDisable {
+		return DefaultRouteTableGroup
+	}
+
+	return ""
+}
+
+// reconcileRouteTables associates the attachment with the route table of its group and
+// ensures its routes are only propagated to the route tables of the configured groups.
+func (r *TransitGateway) reconcileRouteTables(ctx context.Context, tgw *types.TransitGateway, topology *v1alpha1.NetworkTopology, attachment *types.TransitGatewayVpcAttachment) error {
+	logger := r.getLogger(ctx)
+
+	group := getRouteTableGroup(topology, tgw)
+	if group == "" {
+		return nil
+	}
+
+	if attachment == nil || attachment.TransitGatewayAttachmentId == nil {
+		return nil
+	}
+
+	if attachment.State != types.TransitGatewayAttachmentStateAvailable {
+		logger.Info("Transit gateway attachment not yet available, skipping route table association", "transitGatewayAttachmentID", attachment.TransitGatewayAttachmentId, "state", attachment.State)
+		return nil
+	}
+
+	routeTable, err := r.routeTables.getOrCreateRouteTable(ctx, *tgw.TransitGatewayId, group)
+	if err != nil {
+		return err
+	}
+
+	if err := r.routeTables.associateRouteTable(ctx, *attachment.TransitGatewayAttachmentId, *routeTable.TransitGatewayRouteTableId); err != nil {
+		return err
+	}
+	if topology.Status.TransitGatewayAttachment != nil {
+		topology.Status.TransitGatewayAttachment.RouteTableID = *routeTable.TransitGatewayRouteTableId
+	}
+
+	propagationRouteTableIDs := []string{*routeTable.TransitGatewayRouteTableId}
+	for _, propagationGroup := range topology.Spec.PropagateToRouteTableGroups {
+		if propagationGroup == group {
+			continue
+		}
+
+		propagationRouteTable, err := r.routeTables.getOrCreateRouteTable(ctx, *tgw.TransitGatewayId, propagationGroup)
+		if err != nil {
+			return err
+		}
+		propagationRouteTableIDs = append(propagationRouteTableIDs, *propagationRouteTable.TransitGatewayRouteTableId)
+	}
+
+	return r.routeTables.reconcilePropagations(ctx, *attachment.TransitGatewayAttachmentId, propagationRouteTableIDs)
+}
+
+func (r *routeTables) getOrCreateRouteTable(ctx context.Context, gatewayID, group string) (*types.TransitGatewayRouteTable, error) {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("transitGatewayID", gatewayID, "routeTableGroup", group)
+
+	output, err := r.transitGatewayClient.DescribeTransitGatewayRouteTables(ctx, &ec2.DescribeTransitGatewayRouteTablesInput{
+		Filters: []types.Filter{
+			{
+				Name:   awssdk.String("transit-gateway-id"),
+				Values: []string{gatewayID},
+			},
+			{
+				Name:   awssdk.String(tagKey + RouteTableGroupTag),
+				Values: []string{group},
+			},
+			{
+				Name: awssdk.String("state"),
+				Values: []string{
+					string(types.TransitGatewayRouteTableStatePending),
+					string(types.TransitGatewayRouteTableStateAvailable),
+				},
+			},
+		},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to describe transit gateway route tables")
+		return nil, err
+	}
+
+	var routeTable *types.TransitGatewayRouteTable
+	if len(output.TransitGatewayRouteTables) > 1 {
+		err = fmt.Errorf("multiple transit gateway route tables found for group, expected at most one")
+		logger.Error(err, "Too many transit gateway route tables found for group")
+		return nil, err
+	} else if len(output.TransitGatewayRouteTables) == 1 {
+		routeTable = &output.TransitGatewayRouteTables[0]
+	} else {
+		createOutput, err := r.transitGatewayClient.CreateTransitGatewayRouteTable(ctx, &ec2.CreateTransitGatewayRouteTableInput{
+			TransitGatewayId: &gatewayID,
+			TagSpecifications: []types.TagSpecification{
+				{
+					ResourceType: types.ResourceTypeTransitGatewayRouteTable,
+					Tags: []types.Tag{
+						{
+							Key:   awssdk.String("Name"),
+							Value: awssdk.String(fmt.Sprintf("%s-%s", gatewayID, group)),
+						},
+						{
+							Key:   awssdk.String(RouteTableGroupTag),
+							Value: awssdk.String(group),
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			logger.Error(err, "Failed to create transit gateway route table")
+			return nil, err
+		}
+
+		routeTable = createOutput.TransitGatewayRouteTable
+		logger.Info("Created transit gateway route table", "routeTableID", routeTable.TransitGatewayRouteTableId)
+	}
+
+	if routeTable.State != types.TransitGatewayRouteTableStateAvailable {
+		logger.Info("Transit gateway route table not yet available", "routeTableID", routeTable.TransitGatewayRouteTableId, "state", routeTable.State)
+		return nil, &RouteTableNotReadyError{}
+	}
+
+	return routeTable, nil
+}
+
+// associateRouteTable associates the attachment with the route table. An attachment can
+// only be associated with a single route table, so an existing association with another
+// route table is removed first.
+func (r *routeTables) associateRouteTable(ctx context.Context, attachmentID, routeTableID string) error {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("transitGatewayAttachmentID", attachmentID, "routeTableID", routeTableID)
+
+	output, err := r.transitGatewayClient.DescribeTransitGatewayAttachments(ctx, &ec2.DescribeTransitGatewayAttachmentsInput{
+		TransitGatewayAttachmentIds: []string{attachmentID},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to describe transit gateway attachment")
+		return err
+	}
+	if len(output.TransitGatewayAttachments) != 1 {
+		err = fmt.Errorf("expected exactly one transit gateway attachment, got %d", len(output.TransitGatewayAttachments))
+		logger.Error(err, "Failed to find transit gateway attachment")
+		return err
+	}
+
+	association := output.TransitGatewayAttachments[0].Association
+	if association != nil && association.TransitGatewayRouteTableId != nil && association.State != types.TransitGatewayAssociationStateDisassociated {
+		if *association.TransitGatewayRouteTableId == routeTableID {
+			if association.State != types.TransitGatewayAssociationStateAssociated {
+				logger.Info("Transit gateway route table association in progress", "state", association.State)
+				return &RouteTableNotReadyError{}
+			}
+			return nil
+		}
+
+		if association.State != types.TransitGatewayAssociationStateDisassociating {
+			_, err = r.transitGatewayClient.DisassociateTransitGatewayRouteTable(ctx, &ec2.DisassociateTransitGatewayRouteTableInput{
+				TransitGatewayAttachmentId: &attachmentID,
+				TransitGatewayRouteTableId: association.TransitGatewayRouteTableId,
+			})
+			if err != nil {
+				logger.Error(err, "Failed to disassociate transit gateway route table", "currentRouteTableID", association.TransitGatewayRouteTableId)
+				return err
+			}
+			logger.Info("Disassociated transit gateway route table", "currentRouteTableID", association.TransitGatewayRouteTableId)
+		}
+
+		return &RouteTableNotReadyError{}
+	}
+
+	_, err = r.transitGatewayClient.AssociateTransitGatewayRouteTable(ctx, &ec2.AssociateTransitGatewayRouteTableInput{
+		TransitGatewayAttachmentId: &attachmentID,
+		TransitGatewayRouteTableId: &routeTableID,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to associate transit gateway route table")
+		return err
+	}
+
+	logger.Info("Associated transit gateway route table")
+	return nil
+}
+
+// reconcilePropagations enables the propagation of the attachment routes to the given route
+// tables and disables it for all other route tables of the transit gateway.
+func (r *routeTables) reconcilePropagations(ctx context.Context, attachmentID string, routeTableIDs []string) error {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("transitGatewayAttachmentID", attachmentID)
+
+	current := map[string]bool{}
+	input := &ec2.GetTransitGatewayAttachmentPropagationsInput{
+		TransitGatewayAttachmentId: &attachmentID,
+	}
+	for {
+		output, err := r.transitGatewayClient.GetTransitGatewayAttachmentPropagations(ctx, input)
+		if err != nil {
+			logger.Error(err, "Failed to get transit gateway attachment propagations")
+			return err
+		}
+
+		for _, propagation := range output.TransitGatewayAttachmentPropagations {
+			if propagation.State == types.TransitGatewayPropagationStateEnabled || propagation.State == types.TransitGatewayPropagationStateEnabling {
+				current[*propagation.TransitGatewayRouteTableId] = true
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	desired := map[string]bool{}
+	for _, routeTableID := range routeTableIDs {
+		desired[routeTableID] = true
+		if current[routeTableID] {
+			continue
+		}
+
+		_, err := r.transitGatewayClient.EnableTransitGatewayRouteTablePropagation(ctx, &ec2.EnableTransitGatewayRouteTablePropagationInput{
+			TransitGatewayAttachmentId: &attachmentID,
+			TransitGatewayRouteTableId: awssdk.String(routeTableID),
+		})
+		if err != nil {
+			logger.Error(err, "Failed to enable transit gateway route table propagation", "routeTableID", routeTableID)
+			return err
+		}
+		logger.Info("Enabled transit gateway route table propagation", "routeTableID", routeTableID)
+	}
+
+	for routeTableID := range current {
+		if desired[routeTableID] {
+			continue
+		}
+
+		_, err := r.transitGatewayClient.DisableTransitGatewayRouteTablePropagation(ctx, &ec2.DisableTransitGatewayRouteTablePropagationInput{
+			TransitGatewayAttachmentId: &attachmentID,
+			TransitGatewayRouteTableId: awssdk.String(routeTableID),
+		})
+		if err != nil {
+			logger.Error(err, "Failed to disable transit gateway route table propagation", "routeTableID", routeTableID)
+			return err
+		}
+		logger.Info("Disabled transit gateway route table propagation", "routeTableID", routeTableID)
+	}
+
+	return nil
+}
+
+// deleteRouteTables deletes all route tables of the transit gateway managed by the operator
+// and returns the IDs of the route tables which aren't deleted yet.
+func (r *routeTables) deleteRouteTables(ctx context.Context, gatewayID string) ([]string, error) {
+	logger := r.getLogger(ctx)
+	logger = logger.WithValues("transitGatewayID", gatewayID)
+
+	output, err := r.transitGatewayClient.DescribeTransitGatewayRouteTables(ctx, &ec2.DescribeTransitGatewayRouteTablesInput{
+		Filters: []types.Filter{
+			{
+				Name:   awssdk.String("transit-gateway-id"),
+				Values: []string{gatewayID},
+			},
+			{
+				Name:   awssdk.String("tag-key"),
+				Values: []string{RouteTableGroupTag},
+			},
+		},
+	})
+	if err != nil {
+		logger.Error(err, "Failed to describe transit gateway route tables")
+		return nil, err
+	}
+	if output == nil {
+		return nil, nil
+	}
+
+	remaining := []string{}
+	for _, routeTable := range output.TransitGatewayRouteTables {
+		switch routeTable.State {
+		case types.TransitGatewayRouteTableStateDeleted:
+			continue
+		case types.TransitGatewayRouteTableStateDeleting:
+			remaining = append(remaining, *routeTable.TransitGatewayRouteTableId)
+			continue
+		}
+
+		deleted, err := r.transitGatewayClient.DeleteTransitGatewayRouteTable(ctx, &ec2.DeleteTransitGatewayRouteTableInput{
+			TransitGatewayRouteTableId: routeTable.TransitGatewayRouteTableId,
+		})
+		if err != nil {
+			logger.Error(err, "Failed to delete transit gateway route table", "routeTableID", routeTable.TransitGatewayRouteTableId)
+			return nil, err
+		}
+		logger.Info("Deleted transit gateway route table", "routeTableID", routeTable.TransitGatewayRouteTableId)
+
+		if deleted == nil || deleted.TransitGatewayRouteTable == nil || deleted.TransitGatewayRouteTable.State != types.TransitGatewayRouteTableStateDeleted {
+			remaining = append(remaining, *routeTable.TransitGatewayRouteTableId)
+		}
+	}
+
+	return remaining, nil
+}
@@ -3,23 +3,25 @@ package registrar
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
-	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
 	awssdk "github.com/aws/aws-sdk-go/aws"
-	"github.com/giantswarm/k8smetadata/pkg/annotation"
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
 	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/metrics"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
 )
 
@@ -35,68 +37,76 @@ const (
 	// default "Routes per route table" quota of 50.
 	PREFIX_LIST_MAX_ENTRIES = 45
 
+	// PREFIX_LIST_MAX_ENTRIES_INCREMENT is the number of entries a full prefix list is grown by,
+	// up to the configured ceiling.
+	PREFIX_LIST_MAX_ENTRIES_INCREMENT = 10
+
+	// PREFIX_LIST_MAX_ENTRIES_CEILING is the default maximum number of entries a full prefix list
+	// is grown to. It stays below the default "Routes per route table" quota for the same reason
+	// as PREFIX_LIST_MAX_ENTRIES, it should only be raised once the quotas have been raised too.
+	PREFIX_LIST_MAX_ENTRIES_CEILING = PREFIX_LIST_MAX_ENTRIES
+
 	SubnetTGWAttachementsLabel = "subnet.giantswarm.io/tgw"
 	SubnetRoleLabel            = "github.com/giantswarm/aws-vpc-operator/role"
 
 	ErrRouteNotFound = "InvalidRoute.NotFound"
+
+	AddressFamilyIPv4 = "IPv4"
+	AddressFamilyIPv6 = "IPv6"
 )
 
 //counterfeiter:generate . ClusterClient
 type ClusterClient interface {
-	Patch(ctx context.Context, cluster *capi.Cluster, patch client.Patch) (*capi.Cluster, error)
 	GetManagementCluster(ctx context.Context) (*capi.Cluster, error)
 	GetManagementClusterNamespacedName() k8stypes.NamespacedName
 	GetAWSCluster(ctx context.Context, namespacedName k8stypes.NamespacedName) (*capa.AWSCluster, error)
 	IsManagementCluster(ctx context.Context, cluster *capi.Cluster) bool
+	GetNetworkTopology(ctx context.Context, namespacedName k8stypes.NamespacedName) (*v1alpha1.NetworkTopology, error)
 }
 
 type TransitGateway struct {
 	transitGatewayClient                      awsclient.TransitGatewayClient
 	clusterClient                             ClusterClient
 	getTransitGatewayClientForWorkloadCluster func(workloadCluster k8stypes.NamespacedName) awsclient.TransitGatewayClient
+	routeTables                               *routeTables
+	options                                   TransitGatewayOptions
+	recorder                                  record.EventRecorder
 }
 
-func NewTransitGateway(transitGatewayClient awsclient.TransitGatewayClient, clusterClient ClusterClient, getTransitGatewayClientForWorkloadCluster func(workloadCluster k8stypes.NamespacedName) awsclient.TransitGatewayClient) *TransitGateway {
+func NewTransitGateway(transitGatewayClient awsclient.TransitGatewayClient, clusterClient ClusterClient, getTransitGatewayClientForWorkloadCluster func(workloadCluster k8stypes.NamespacedName) awsclient.TransitGatewayClient, options TransitGatewayOptions, recorder record.EventRecorder) *TransitGateway {
 	return &TransitGateway{
 		transitGatewayClient: transitGatewayClient,
 		clusterClient:        clusterClient,
 		getTransitGatewayClientForWorkloadCluster: getTransitGatewayClientForWorkloadCluster,
+		options:  options.withDefaults(),
+		recorder: recorder,
+		routeTables: &routeTables{
+			transitGatewayClient: transitGatewayClient,
+		},
 	}
 }
 
-func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) error {
-	ctx = context.WithValue(ctx, clusterNameContextKey, cluster.ObjectMeta.Name)
+func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) error {
+	ctx = withCluster(ctx, cluster)
 	logger := r.getLogger(ctx)
 
-	gatewayID, err := getTransitGatewayID(logger, cluster)
+	gatewayID, err := getTransitGatewayID(logger, topology)
 	if err != nil {
 		return err
 	}
 
-	switch val := annotations.GetAnnotation(cluster, annotation.NetworkTopologyModeAnnotation); val {
-	case "":
-		// If no value currently set, we'll set to the default of 'None"
-		logger.Info("NetworkTopologyMode is currently unset, setting to the default of 'None'")
-
-		baseCluster := cluster.DeepCopy()
-		annotations.AddAnnotations(cluster, map[string]string{
-			annotation.NetworkTopologyModeAnnotation: annotation.NetworkTopologyModeNone,
-		})
-		if _, err := r.clusterClient.Patch(ctx, cluster, client.MergeFrom(baseCluster)); err != nil {
-			logger.Error(err, "Failed to save cluster resource")
-			return err
-		}
-		fallthrough
-
-	case annotation.NetworkTopologyModeNone:
-		logger.Info("Mode currently not handled", "mode", annotation.NetworkTopologyModeNone)
-		return &ModeNotSupportedError{Mode: val}
+	switch val := topology.Spec.Mode; val {
+	// The API server defaults the mode to None, it is only unset for
+	// NetworkTopologies that weren't persisted, e.g. in tests
+	case "", v1alpha1.NetworkTopologyModeNone:
+		logger.Info("Mode currently not handled", "mode", v1alpha1.NetworkTopologyModeNone)
+		return &ModeNotSupportedError{Mode: string(topology.Spec.Mode)}
 
-	case annotation.NetworkTopologyModeUserManaged:
+	case v1alpha1.NetworkTopologyModeUserManaged:
 		var err error
 		var tgw *types.TransitGateway
 
-		prefixListID, err := getPrefixListID(logger, cluster)
+		prefixListID, err := getPrefixListID(logger, topology)
 		if err != nil {
 			return err
 		}
@@ -117,13 +127,12 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 		} else {
 			if gatewayID == "" {
 				// No TGW ID specified so we'll use the one associated with the MC
-				mc, err := r.clusterClient.GetManagementCluster(ctx)
+				mcTopology, err := r.getManagementClusterNetworkTopology(ctx)
 				if err != nil {
-					logger.Error(err, "Failed to get management cluster")
 					return err
 				}
 
-				gatewayID, err = getTransitGatewayID(logger, mc)
+				gatewayID, err = getTransitGatewayID(logger, mcTopology)
 				if err != nil {
 					return err
 				}
@@ -140,17 +149,15 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 			}
 			if tgw == nil {
 				err = fmt.Errorf("failed to find TransitGateway for provided ID")
-				logger.Error(err, "No TransitGateway found for ID provided on annotations", "transitGatewayID", gatewayID)
+				logger.Error(err, "No TransitGateway found for ID provided on NetworkTopology", "transitGatewayID", gatewayID)
 				return err
 			}
 		}
 
-		// Ensure TGW ID is saved back to the current cluster
-		baseCluster := cluster.DeepCopy()
-		annotations.SetNetworkTopologyTransitGateway(cluster, *tgw.TransitGatewayArn)
-		if cluster, err = r.clusterClient.Patch(ctx, cluster, client.MergeFrom(baseCluster)); err != nil {
-			logger.Error(err, "Failed to patch cluster resource with TGW ID")
-			return err
+		// Ensure the TGW is saved back to the NetworkTopology
+		topology.Spec.TransitGateway = &v1alpha1.AWSResourceReference{
+			ID:  *tgw.TransitGatewayId,
+			ARN: *tgw.TransitGatewayArn,
 		}
 
 		awsCluster, err := r.getAWSCluster(ctx, cluster)
@@ -164,8 +171,9 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 			logger.Info("vpc not yet ready, skipping attachment for now", "transitGatewayID", tgw.TransitGatewayId)
 			return &VPCNotReadyError{}
 		} else if tgw.State == types.TransitGatewayStateAvailable {
-			tgwAttachment, err = r.attachTransitGateway(ctx, tgw.TransitGatewayId, awsCluster)
+			tgwAttachment, err = r.attachTransitGateway(ctx, tgw.TransitGatewayId, awsCluster, topology.Spec.AttachmentSubnetSelector, false, false)
 			if err != nil {
+				setTransitGatewayAttachmentStatus(topology, tgwAttachment)
 				return err
 			}
 		} else {
@@ -173,26 +181,27 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 			return &TransitGatewayNotAvailableError{}
 		}
 
-		if tgwAttachment.State == types.TransitGatewayAttachmentStatePendingAcceptance {
-			logger.Info("Sending SNS message")
+		tgwAttachment, err = r.recreateFailedAttachment(ctx, topology, tgw.TransitGatewayId, awsCluster, false, tgwAttachment)
+		if err != nil {
+			return err
+		}
+
+		if err := attachmentFailure(topology, tgwAttachment); err != nil {
+			logger.Info("Transit gateway attachment failed", "reason", err.Error())
+			return err
+		}
 
-			_, err = r.transitGatewayClient.PublishSNSMessage(ctx, &sns.PublishInput{
-				Message: awssdk.String("Request TransitGatewayAttachment"),
-				MessageAttributes: map[string]snstypes.MessageAttributeValue{
-					"Postfach":      {DataType: awssdk.String("String"), StringValue: awssdk.String("support@giantswarm.io")},
-					"Account_ID":    {DataType: awssdk.String("String"), StringValue: tgwAttachment.VpcOwnerId},
-					"Attachment_ID": {DataType: awssdk.String("String"), StringValue: tgwAttachment.TransitGatewayAttachmentId},
-					"CIDR":          {DataType: awssdk.String("String"), StringValue: &awsCluster.Spec.NetworkSpec.VPC.CidrBlock},
-					"Name":          {DataType: awssdk.String("String"), StringValue: &cluster.ObjectMeta.Name},
-				},
-			})
-			if err != nil {
-				logger.Error(err, "Failed sending SNS message")
+		if tgwAttachment != nil && tgwAttachment.State == types.TransitGatewayAttachmentStatePendingAcceptance {
+			if err := r.requestAttachmentAcceptance(ctx, cluster, awsCluster, topology, tgwAttachment); err != nil {
 				return err
 			}
 		}
 
-	case annotation.NetworkTopologyModeGiantSwarmManaged:
+		if err := attachmentPending(tgwAttachment); err != nil {
+			return err
+		}
+
+	case v1alpha1.NetworkTopologyModeGiantSwarmManaged:
 		var err error
 		var tgw *types.TransitGateway
 
@@ -204,13 +213,12 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 		} else {
 			if gatewayID == "" {
 				// No TGW ID specified so we'll use the one associated with the MC
-				mc, err := r.clusterClient.GetManagementCluster(ctx)
+				mcTopology, err := r.getManagementClusterNetworkTopology(ctx)
 				if err != nil {
-					logger.Error(err, "Failed to get management cluster")
 					return err
 				}
 
-				gatewayID, err = getTransitGatewayID(logger, mc)
+				gatewayID, err = getTransitGatewayID(logger, mcTopology)
 				if err != nil {
 					return err
 				}
@@ -227,17 +235,15 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 			}
 			if tgw == nil {
 				err = fmt.Errorf("failed to find TransitGateway for provided ID")
-				logger.Error(err, "No TransitGateway found for ID provided on annotations", "transitGatewayID", gatewayID)
+				logger.Error(err, "No TransitGateway found for ID provided on NetworkTopology", "transitGatewayID", gatewayID)
 				return err
 			}
 		}
 
-		// Ensure TGW ID is saved back to the current cluster
-		baseCluster := cluster.DeepCopy()
-		annotations.SetNetworkTopologyTransitGateway(cluster, *tgw.TransitGatewayArn)
-		if cluster, err = r.clusterClient.Patch(ctx, cluster, client.MergeFrom(baseCluster)); err != nil {
-			logger.Error(err, "Failed to patch cluster resource with TGW ID")
-			return err
+		// Ensure the TGW is saved back to the NetworkTopology
+		topology.Spec.TransitGateway = &v1alpha1.AWSResourceReference{
+			ID:  *tgw.TransitGatewayId,
+			ARN: *tgw.TransitGatewayArn,
 		}
 
 		awsCluster, err := r.getAWSCluster(ctx, cluster)
@@ -249,8 +255,33 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 		if awsCluster.Spec.NetworkSpec.VPC.ID == "" {
 			logger.Info("vpc not yet ready, skipping attachment for now", "transitGatewayID", tgw.TransitGatewayId)
 			return &VPCNotReadyError{}
-		} else if tgw.State == types.TransitGatewayStateAvailable {
-			if _, err := r.attachTransitGateway(ctx, tgw.TransitGatewayId, awsCluster); err != nil {
+		}
+
+		ipv4CIDRs, ipv6CIDRs, err := r.getVPCCIDRBlocks(ctx, awsCluster)
+		if err != nil {
+			return err
+		}
+		dualStack := len(ipv6CIDRs) > 0
+
+		var tgwAttachment *types.TransitGatewayVpcAttachment
+		if tgw.State == types.TransitGatewayStateAvailable {
+			tgwAttachment, err = r.attachTransitGateway(ctx, tgw.TransitGatewayId, awsCluster, topology.Spec.AttachmentSubnetSelector, dualStack, false)
+			if err != nil {
+				setTransitGatewayAttachmentStatus(topology, tgwAttachment)
+				return err
+			}
+
+			tgwAttachment, err = r.recreateFailedAttachment(ctx, topology, tgw.TransitGatewayId, awsCluster, dualStack, tgwAttachment)
+			if err != nil {
+				return err
+			}
+
+			if err := attachmentFailure(topology, tgwAttachment); err != nil {
+				logger.Info("Transit gateway attachment failed", "reason", err.Error())
+				return err
+			}
+
+			if err := r.reconcileRouteTables(ctx, tgw, topology, tgwAttachment); err != nil {
 				return err
 			}
 		} else {
@@ -258,23 +289,56 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 			return &TransitGatewayNotAvailableError{}
 		}
 
-		prefixList, err := r.addToPrefixList(ctx, awsCluster)
+		prefixList, err := r.addToPrefixList(ctx, AddressFamilyIPv4, awsCluster, ipv4CIDRs)
 		if err != nil {
 			return err
 		}
 		prefixListID := *prefixList.PrefixListId
 
-		// Ensure PrefixListID is saved back to the current cluster
-		baseCluster = cluster.DeepCopy()
-		annotations.SetNetworkTopologyPrefixList(cluster, *prefixList.PrefixListArn)
-		if _, err = r.clusterClient.Patch(ctx, cluster, client.MergeFrom(baseCluster)); err != nil {
-			logger.Error(err, "Failed to patch cluster resource with prefix list ID", "prefixListID", prefixListID)
+		topology.Status.PrefixListEntry = &v1alpha1.PrefixListEntryStatus{
+			PrefixListID: prefixListID,
+			CIDR:         awsCluster.Spec.NetworkSpec.VPC.CidrBlock,
+			Description:  buildEntryDescription(awsCluster),
+		}
+		for _, cidr := range ipv4CIDRs {
+			if cidr != awsCluster.Spec.NetworkSpec.VPC.CidrBlock {
+				topology.Status.PrefixListEntry.SecondaryCIDRs = append(topology.Status.PrefixListEntry.SecondaryCIDRs, cidr)
+			}
+		}
+
+		// Ensure the prefix list is saved back to the NetworkTopology
+		topology.Spec.PrefixList = &v1alpha1.AWSResourceReference{
+			ID:  prefixListID,
+			ARN: *prefixList.PrefixListArn,
+		}
+
+		if dualStack {
+			ipv6PrefixList, err := r.addToPrefixList(ctx, AddressFamilyIPv6, awsCluster, ipv6CIDRs)
+			if err != nil {
+				return err
+			}
+
+			topology.Status.IPv6PrefixListEntry = &v1alpha1.PrefixListEntryStatus{
+				PrefixListID:   *ipv6PrefixList.PrefixListId,
+				CIDR:           ipv6CIDRs[0],
+				SecondaryCIDRs: ipv6CIDRs[1:],
+				Description:    buildEntryDescription(awsCluster),
+			}
+
+			// Ensure the IPv6 prefix list is saved back to the NetworkTopology
+			topology.Spec.IPv6PrefixList = &v1alpha1.AWSResourceReference{
+				ID:  *ipv6PrefixList.PrefixListId,
+				ARN: *ipv6PrefixList.PrefixListArn,
+			}
+		}
+
+		if err := attachmentPending(tgwAttachment); err != nil {
 			return err
 		}
 
 	default:
 		err := fmt.Errorf("invalid NetworkTopologyMode value")
-		logger.Error(err, "Unexpected NetworkTopologyMode annotation value found on cluster", "value", val)
+		logger.Error(err, "Unexpected NetworkTopologyMode value found on NetworkTopology", "value", val)
 		return err
 	}
 
@@ -282,22 +346,27 @@ func (r *TransitGateway) Register(ctx context.Context, cluster *capi.Cluster) er
 	return nil
 }
 
-func (r *TransitGateway) Unregister(ctx context.Context, cluster *capi.Cluster) error {
+func (r *TransitGateway) Unregister(ctx context.Context, cluster *capi.Cluster, topology *v1alpha1.NetworkTopology) error {
+	ctx = withCluster(ctx, cluster)
 	logger := r.getLogger(ctx)
 
-	gatewayID, err := getTransitGatewayID(logger, cluster)
+	gatewayID, err := getTransitGatewayID(logger, topology)
 	if err != nil {
 		return err
 	}
 
-	switch val := annotations.GetAnnotation(cluster, annotation.NetworkTopologyModeAnnotation); val {
-	case "":
-		fallthrough
+	deletionPolicy := topology.GetDeletionPolicy(r.options.DeletionPolicy)
+	if deletionPolicy == v1alpha1.DeletionPolicyOrphan && topology.Spec.Mode != v1alpha1.NetworkTopologyModeNone {
+		logger.Info("Leaving AWS resources untouched", "deletionPolicy", deletionPolicy)
+		r.recordNormal(ctx, EventReasonResourcesOrphaned, "Left the transit gateway attachment, prefix list entries and transit gateway untouched due to the %s deletion policy", deletionPolicy)
+		return nil
+	}
 
-	case annotation.NetworkTopologyModeNone:
-		logger.Info("Mode currently not handled", "mode", annotation.NetworkTopologyModeNone)
+	switch val := topology.Spec.Mode; val {
+	case "", v1alpha1.NetworkTopologyModeNone:
+		logger.Info("Mode currently not handled", "mode", v1alpha1.NetworkTopologyModeNone)
 
-	case annotation.NetworkTopologyModeUserManaged:
+	case v1alpha1.NetworkTopologyModeUserManaged:
 		awsCluster, err := r.getAWSCluster(ctx, cluster)
 		if k8sErrors.IsNotFound(err) {
 			logger.Info("AWSCluster is already deleted, skipping transit gateway deletion")
@@ -310,8 +379,9 @@ func (r *TransitGateway) Unregister(ctx context.Context, cluster *capi.Cluster)
 		if err := r.detachTransitGateway(ctx, &gatewayID, awsCluster); err != nil {
 			return err
 		}
+		topology.Status.TransitGatewayAttachment = nil
 
-	case annotation.NetworkTopologyModeGiantSwarmManaged:
+	case v1alpha1.NetworkTopologyModeGiantSwarmManaged:
 		awsCluster, err := r.getAWSCluster(ctx, cluster)
 		if k8sErrors.IsNotFound(err) {
 			logger.Info("AWSCluster is already deleted, skipping transit gateway deletion")
@@ -321,21 +391,50 @@ func (r *TransitGateway) Unregister(ctx context.Context, cluster *capi.Cluster)
 			return err
 		}
 
-		if err := r.removeFromPrefixList(ctx, awsCluster); err != nil {
-			return err
+		// The entries are already removed once the deletion waits for the
+		// prefix lists, which may already be deleted
+		if !isDeletingPrefixLists(topology) {
+			cidrs := append(entryStatusCIDRs(topology.Status.PrefixListEntry), awsCluster.Spec.NetworkSpec.VPC.CidrBlock)
+			if err := r.removeFromPrefixList(ctx, AddressFamilyIPv4, awsCluster, cidrs); err != nil {
+				return err
+			}
+
+			if !topology.Spec.IPv6PrefixList.IsZero() {
+				if err := r.removeFromPrefixList(ctx, AddressFamilyIPv6, awsCluster, entryStatusCIDRs(topology.Status.IPv6PrefixListEntry)); err != nil {
+					return err
+				}
+			}
 		}
+		topology.Status.PrefixListEntry = nil
+		topology.Status.IPv6PrefixListEntry = nil
 
 		if err := r.detachTransitGateway(ctx, &gatewayID, awsCluster); err != nil {
 			return err
 		}
+		topology.Status.TransitGatewayAttachment = nil
 
-		if err := r.deleteTransitGateway(ctx, &gatewayID, cluster); err != nil {
+		if deletionPolicy == v1alpha1.DeletionPolicyRetain {
+			if r.clusterClient.IsManagementCluster(ctx, cluster) {
+				logger.Info("Keeping transit gateway and prefix lists", "deletionPolicy", deletionPolicy, "transitGatewayID", gatewayID)
+				r.recordNormal(ctx, EventReasonResourcesRetained, "Kept transit gateway %s and prefix lists due to the %s deletion policy", gatewayID, deletionPolicy)
+			}
+			break
+		}
+
+		// The transit gateway is already deleted once the deletion waits for the prefix lists
+		if !isDeletingPrefixLists(topology) {
+			if err := r.deleteTransitGateway(ctx, &gatewayID, cluster, topology); err != nil {
+				return err
+			}
+		}
+
+		if err := r.deletePrefixLists(ctx, gatewayID, cluster, topology); err != nil {
 			return err
 		}
 
 	default:
 		err := fmt.Errorf("invalid NetworkTopologyMode value")
-		logger.Error(err, "Unexpected NetworkTopologyMode annotation value found on cluster", "value", val)
+		logger.Error(err, "Unexpected NetworkTopologyMode value found on NetworkTopology", "value", val)
 		return err
 	}
 
@@ -356,6 +455,30 @@ func (r *TransitGateway) getAWSCluster(ctx context.Context, cluster *capi.Cluste
 	return r.clusterClient.GetAWSCluster(ctx, clusterNamespaceName)
 }
 
+// getManagementClusterNetworkTopology returns the NetworkTopology of the
+// management cluster, falling back to its annotations if it doesn't exist yet
+func (r *TransitGateway) getManagementClusterNetworkTopology(ctx context.Context) (*v1alpha1.NetworkTopology, error) {
+	logger := r.getLogger(ctx)
+
+	topology, err := r.clusterClient.GetNetworkTopology(ctx, r.clusterClient.GetManagementClusterNamespacedName())
+	if err == nil {
+		return topology, nil
+	} else if !k8sErrors.IsNotFound(err) {
+		logger.Error(err, "Failed to get management cluster NetworkTopology")
+		return nil, err
+	}
+
+	mc, err := r.clusterClient.GetManagementCluster(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get management cluster")
+		return nil, err
+	}
+
+	topology = &v1alpha1.NetworkTopology{}
+	annotations.ImportNetworkTopology(mc, topology)
+	return topology, nil
+}
+
 func (r *TransitGateway) getTransitGateway(ctx context.Context, gatewayID string) (*types.TransitGateway, error) {
 	logger := r.getLogger(ctx)
 
@@ -398,6 +521,10 @@ func (r *TransitGateway) getOrCreateTransitGateway(ctx context.Context, gatewayI
 			Description: awssdk.String(fmt.Sprintf("Transit Gateway for cluster %s", ctx.Value(clusterNameContextKey))),
 			Options: &types.TransitGatewayRequestOptions{
 				AutoAcceptSharedAttachments: types.AutoAcceptSharedAttachmentsValueEnable,
+				// Attachments are associated with the route table of their group by the
+				// operator, see reconcileRouteTables
+				DefaultRouteTableAssociation: types.DefaultRouteTableAssociationValueDisable,
+				DefaultRouteTablePropagation: types.DefaultRouteTablePropagationValueDisable,
 			},
 			TagSpecifications: []types.TagSpecification{
 				{
@@ -413,18 +540,24 @@ func (r *TransitGateway) getOrCreateTransitGateway(ctx context.Context, gatewayI
 		})
 		if err != nil {
 			logger.Error(err, "Failed to create new Transit Gateway")
+			r.recordWarning(ctx, EventReasonTransitGatewayCreationFailed, "Failed to create transit gateway: %v", err)
 			return nil, err
 		}
 
 		tgw = output.TransitGateway
 
 		logger.Info("Created new TransitGateway", "transitGatewayID", tgw.TransitGatewayId)
+		r.recordNormal(ctx, EventReasonTransitGatewayCreated, "Created transit gateway %s", awssdk.StringValue(tgw.TransitGatewayId))
 	}
 
 	return tgw, nil
 }
 
-func (r *TransitGateway) attachTransitGateway(ctx context.Context, gatewayID *string, awsCluster *capa.AWSCluster) (*types.TransitGatewayVpcAttachment, error) {
+// attachTransitGateway returns the attachment of the VPC of the cluster to the
+// transit gateway and creates it if it doesn't exist. Failed and deleted
+// attachments are only replaced with a new attachment if recreate is true,
+// otherwise the most recent of them is returned.
+func (r *TransitGateway) attachTransitGateway(ctx context.Context, gatewayID *string, awsCluster *capa.AWSCluster, subnetSelector map[string]string, ipv6Support bool, recreate bool) (*types.TransitGatewayVpcAttachment, error) {
 	logger := r.getLogger(ctx)
 
 	// Attachments from VPC to the transit gateway need to be made from the AWS account
@@ -435,13 +568,7 @@ func (r *TransitGateway) attachTransitGateway(ctx context.Context, gatewayID *st
 	})
 
 	vpcID := awsCluster.Spec.NetworkSpec.VPC.ID
-	subnets, err := r.getTGWGAttachmentSubnetsOrDefault(ctx, transitGatewayAttachmentClient, awsCluster)
-	if err != nil {
-		logger.Error(err, "Failed to get subnets for transit gateway attachment", "transitGatewayID", gatewayID)
-		return nil, err
-	}
-
-	if vpcID == "" || len(subnets) == 0 {
+	if vpcID == "" {
 		err := fmt.Errorf("cluster network not yet available on AWSCluster resource")
 		logger.Error(err, "AWSCluster does not yet have network details available")
 		return nil, err
@@ -465,11 +592,60 @@ func (r *TransitGateway) attachTransitGateway(ctx context.Context, gatewayID *st
 		return nil, err
 	}
 
-	if attachments != nil && len(attachments.TransitGatewayVpcAttachments) == 0 {
+	var options *types.CreateTransitGatewayVpcAttachmentRequestOptions
+	if ipv6Support {
+		options = &types.CreateTransitGatewayVpcAttachmentRequestOptions{
+			Ipv6Support: types.Ipv6SupportValueEnable,
+		}
+	}
+
+	current := []types.TransitGatewayVpcAttachment{}
+	var failed *types.TransitGatewayVpcAttachment
+	if attachments != nil {
+		for i, attachment := range attachments.TransitGatewayVpcAttachments {
+			if !isRecreatableAttachmentState(attachment.State) {
+				current = append(current, attachment)
+			} else if failed == nil || isNewerAttachment(&attachment, failed) {
+				failed = &attachments.TransitGatewayVpcAttachments[i]
+			}
+		}
+	}
+
+	// Keep the subnets of the current attachment while they are still selected
+	var attachedSubnets []string
+	var currentAttachment *types.TransitGatewayVpcAttachment
+	if len(current) == 1 {
+		currentAttachment = &current[0]
+		attachedSubnets = currentAttachment.SubnetIds
+	}
+
+	subnets, err := r.getTGWGAttachmentSubnetsOrDefault(ctx, transitGatewayAttachmentClient, awsCluster, subnetSelector, attachedSubnets)
+	if err != nil {
+		logger.Error(err, "Failed to get subnets for transit gateway attachment", "transitGatewayID", gatewayID)
+		return nil, err
+	}
+
+	if len(subnets) == 0 && len(subnetSelector) > 0 {
+		logger.Info("No subnets match the attachment subnet selector, keeping the transit gateway attachment unchanged", "vpcID", vpcID, "selector", subnetSelector)
+		return currentAttachment, &NoAttachmentSubnetsError{VPCID: vpcID, Selector: subnetSelector}
+	} else if len(subnets) == 0 {
+		err := fmt.Errorf("cluster network not yet available on AWSCluster resource")
+		logger.Error(err, "AWSCluster does not yet have network details available")
+		return nil, err
+	}
+
+	if attachments == nil {
+		return nil, nil
+	}
+
+	if len(current) == 0 && failed != nil && !recreate {
+		return failed, nil
+	} else if len(current) == 0 {
 		output, err := transitGatewayAttachmentClient.CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
 			TransitGatewayId: gatewayID,
 			VpcId:            &vpcID,
 			SubnetIds:        subnets,
+			Options:          options,
 			TagSpecifications: []types.TagSpecification{
 				{
 					ResourceType: types.ResourceTypeTransitGatewayAttachment,
@@ -488,43 +664,167 @@ func (r *TransitGateway) attachTransitGateway(ctx context.Context, gatewayID *st
 		})
 		if err != nil {
 			logger.Error(err, "Failed to create transit gateway attachments", "transitGatewayID", gatewayID, "vpcID", vpcID)
+			r.recordWarning(ctx, EventReasonAttachmentCreationFailed, "Failed to attach VPC %s to transit gateway %s: %v", vpcID, *gatewayID, err)
 			return nil, err
 		}
 
 		logger.Info("TransitGateway attached to VPC", "vpcID", vpcID, "transitGatewayID", gatewayID, "transitGatewayAttachmentId", output.TransitGatewayVpcAttachment.TransitGatewayAttachmentId)
+		r.recordNormal(ctx, EventReasonAttachmentCreated, "Created transit gateway attachment %s of VPC %s to transit gateway %s", awssdk.StringValue(output.TransitGatewayVpcAttachment.TransitGatewayAttachmentId), vpcID, *gatewayID)
 		return output.TransitGatewayVpcAttachment, nil
-	} else if len(attachments.TransitGatewayVpcAttachments) == 1 {
-		return &attachments.TransitGatewayVpcAttachments[0], nil
+	} else if len(current) == 1 {
+		attachment := &current[0]
+		if attachment.State != types.TransitGatewayAttachmentStateAvailable {
+			return attachment, nil
+		}
+
+		return r.updateTransitGatewayAttachment(ctx, transitGatewayAttachmentClient, attachment, subnets, ipv6Support)
 	}
 
 	return nil, nil
 }
 
-func (r *TransitGateway) deleteTransitGateway(ctx context.Context, gatewayID *string, cluster *capi.Cluster) error {
+// updateTransitGatewayAttachment adds and removes subnets of the attachment until they match
+// the desired subnets and enables IPv6 support if required. A drift of the subnets is recorded
+// as an event, it doesn't stop the registration.
+func (r *TransitGateway) updateTransitGatewayAttachment(ctx context.Context, transitGatewayAttachmentClient awsclient.TransitGatewayClient, attachment *types.TransitGatewayVpcAttachment, subnets []string, ipv6Support bool) (*types.TransitGatewayVpcAttachment, error) {
 	logger := r.getLogger(ctx)
-	logger = logger.WithValues("transitGatewayID", gatewayID)
 
-	logger.Info("Deleting transit gateway")
-	defer logger.Info("Done deleting transit gateway")
+	if len(subnets) == 0 {
+		// An attachment needs a subnet, removing all of them would detach the VPC
+		return attachment, &NoAttachmentSubnetsError{VPCID: awssdk.StringValue(attachment.VpcId)}
+	}
 
-	if !r.clusterClient.IsManagementCluster(ctx, cluster) {
-		logger.Info("Cluster is a workload cluster. Skipping transit gateway deletion")
+	input := &ec2.ModifyTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+	}
+	for _, subnet := range subnets {
+		if !containsString(attachment.SubnetIds, subnet) {
+			input.AddSubnetIds = append(input.AddSubnetIds, subnet)
+		}
+	}
+	for _, subnet := range attachment.SubnetIds {
+		if !containsString(subnets, subnet) {
+			input.RemoveSubnetIds = append(input.RemoveSubnetIds, subnet)
+		}
+	}
+	if ipv6Support && !hasIPv6Support(attachment) {
+		input.Options = &types.ModifyTransitGatewayVpcAttachmentRequestOptions{
+			Ipv6Support: types.Ipv6SupportValueEnable,
+		}
+	}
+
+	subnetsChanged := len(input.AddSubnetIds) > 0 || len(input.RemoveSubnetIds) > 0
+	if !subnetsChanged && input.Options == nil {
+		return attachment, nil
+	}
+
+	output, err := transitGatewayAttachmentClient.ModifyTransitGatewayVpcAttachment(ctx, input)
+	if err != nil {
+		logger.Error(err, "Failed to modify transit gateway attachment", "transitGatewayAttachmentId", attachment.TransitGatewayAttachmentId, "addSubnetIds", input.AddSubnetIds, "removeSubnetIds", input.RemoveSubnetIds)
+		r.recordWarning(ctx, EventReasonAttachmentModificationFailed, "Failed to modify transit gateway attachment %s: %v", awssdk.StringValue(attachment.TransitGatewayAttachmentId), err)
+		return nil, err
+	}
+
+	logger.Info("Modified transit gateway attachment", "transitGatewayAttachmentId", attachment.TransitGatewayAttachmentId, "addSubnetIds", input.AddSubnetIds, "removeSubnetIds", input.RemoveSubnetIds, "ipv6Support", input.Options != nil)
+	r.recordNormal(ctx, EventReasonAttachmentModified, "Modified transit gateway attachment %s, added subnets %v, removed subnets %v, IPv6 support enabled: %t", awssdk.StringValue(attachment.TransitGatewayAttachmentId), input.AddSubnetIds, input.RemoveSubnetIds, input.Options != nil)
+	if output != nil && output.TransitGatewayVpcAttachment != nil {
+		attachment = output.TransitGatewayVpcAttachment
+	}
+
+	if subnetsChanged {
+		r.recordNormal(ctx, EventReasonAttachmentSubnetsDrift, "The subnets of transit gateway attachment %s drifted from the selected subnets, added %v and removed %v", awssdk.StringValue(attachment.TransitGatewayAttachmentId), input.AddSubnetIds, input.RemoveSubnetIds)
+	}
+
+	return attachment, nil
+}
+
+// requestAttachmentAcceptance publishes the SNS message requesting the
+// acceptance of the transit gateway attachment once and then again every
+// reminder interval. The published messages are tracked in the attachment
+// status of the topology.
+func (r *TransitGateway) requestAttachmentAcceptance(ctx context.Context, cluster *capi.Cluster, awsCluster *capa.AWSCluster, topology *v1alpha1.NetworkTopology, attachment *types.TransitGatewayVpcAttachment) error {
+	logger := r.getLogger(ctx)
+
+	attachmentID := awssdk.StringValue(attachment.TransitGatewayAttachmentId)
+	status := topology.Status.TransitGatewayAttachment
+	if status == nil {
+		// Only set for attachments with an ID
+		status = &v1alpha1.TransitGatewayAttachmentStatus{}
+	}
+
+	now := time.Now()
+	if !r.options.Notification.notificationDue(status.AcceptanceRequest, now) {
+		logger.Info("Acceptance of transit gateway attachment already requested", "attachmentID", attachmentID, "lastNotificationTime", status.AcceptanceRequest.LastNotificationTime)
 		return nil
 	}
 
-	describeTGWattachmentInput := &ec2.DeleteTransitGatewayInput{
-		TransitGatewayId: gatewayID,
+	notification := int32(1)
+	if status.AcceptanceRequest != nil {
+		notification = status.AcceptanceRequest.Notifications + 1
 	}
 
-	_, err := r.transitGatewayClient.DeleteTransitGateway(ctx, describeTGWattachmentInput)
+	logger.Info("Sending SNS message", "notification", notification)
+
+	input, err := r.attachmentRequest(ctx, cluster, awsCluster, attachment, notification)
 	if err != nil {
-		logger.Error(err, "failed to delete transit gateway")
 		return err
 	}
 
+	output, err := r.transitGatewayClient.PublishSNSMessage(ctx, input)
+	if err != nil {
+		logger.Error(err, "Failed sending SNS message")
+		r.recordWarning(ctx, EventReasonSNSMessagePublicationFailed, "Failed to publish SNS message requesting acceptance of transit gateway attachment %s: %v", attachmentID, err)
+		return &SNSUnavailableError{AttachmentID: attachmentID, Err: err}
+	}
+
+	status.AcceptanceRequest = &v1alpha1.AttachmentAcceptanceRequestStatus{
+		LastNotificationTime: &metav1.Time{Time: now},
+		Notifications:        notification,
+	}
+	if output != nil {
+		status.AcceptanceRequest.MessageID = awssdk.StringValue(output.MessageId)
+	}
+
+	if notification == 1 {
+		r.recordNormal(ctx, EventReasonSNSMessagePublished, "Published SNS message requesting acceptance of transit gateway attachment %s", attachmentID)
+	} else {
+		r.recordNormal(ctx, EventReasonSNSMessagePublished, "Published SNS reminder %d requesting acceptance of transit gateway attachment %s", notification-1, attachmentID)
+	}
+
 	return nil
 }
 
+// attachmentRequest returns the SNS message requesting the acceptance of the
+// transit gateway attachment of the cluster.
+func (r *TransitGateway) attachmentRequest(ctx context.Context, cluster *capi.Cluster, awsCluster *capa.AWSCluster, attachment *types.TransitGatewayVpcAttachment, notification int32) (*sns.PublishInput, error) {
+	cidrs, ipv6CIDRs, err := r.getVPCCIDRBlocks(ctx, awsCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	request := r.options.Notification.newAttachmentRequest()
+	request.Cluster = AttachmentRequestCluster{
+		Name:      cluster.Name,
+		Namespace: cluster.Namespace,
+	}
+	request.ManagementCluster = r.clusterClient.GetManagementClusterNamespacedName().Name
+	request.AccountID = awssdk.StringValue(attachment.VpcOwnerId)
+	request.Region = awsCluster.Spec.Region
+	request.VPCID = awsCluster.Spec.NetworkSpec.VPC.ID
+	request.TransitGatewayID = awssdk.StringValue(attachment.TransitGatewayId)
+	request.AttachmentID = awssdk.StringValue(attachment.TransitGatewayAttachmentId)
+	request.CIDRs = cidrs
+	if len(ipv6CIDRs) > 0 {
+		request.IPv6CIDRs = ipv6CIDRs
+	}
+
+	return r.options.Notification.publishInput(request, notification)
+}
+
+func hasIPv6Support(attachment *types.TransitGatewayVpcAttachment) bool {
+	return attachment.Options != nil && attachment.Options.Ipv6Support == types.Ipv6SupportValueEnable
+}
+
 func (r *TransitGateway) detachTransitGateway(ctx context.Context, gatewayID *string, awsCluster *capa.AWSCluster) error {
 	logger := r.getLogger(ctx)
 
@@ -559,34 +859,76 @@ func (r *TransitGateway) detachTransitGateway(ctx context.Context, gatewayID *st
 	}
 
 	for _, tgwAttachment := range attachments.TransitGatewayVpcAttachments {
+		if tgwAttachment.State == types.TransitGatewayAttachmentStateDeleting || tgwAttachment.State == types.TransitGatewayAttachmentStateDeleted {
+			continue
+		}
+
 		_, err := transitGatewayAttachmentClient.DeleteTransitGatewayVpcAttachment(ctx, &ec2.DeleteTransitGatewayVpcAttachmentInput{
 			TransitGatewayAttachmentId: tgwAttachment.TransitGatewayAttachmentId,
 		})
 		if err != nil {
 			logger.Error(err, "Failed to delete TransitGatewayAttachment", "transitGatewayID", gatewayID, "vpcID", vpcID, "transitGatewayAttachmentID", tgwAttachment.TransitGatewayAttachmentId)
+			r.recordWarning(ctx, EventReasonAttachmentDeletionFailed, "Failed to delete transit gateway attachment %s: %v", awssdk.StringValue(tgwAttachment.TransitGatewayAttachmentId), err)
 			return err
 		}
+		r.recordNormal(ctx, EventReasonAttachmentDeleted, "Deleted transit gateway attachment %s of VPC %s to transit gateway %s", awssdk.StringValue(tgwAttachment.TransitGatewayAttachmentId), vpcID, *gatewayID)
 	}
 
 	logger.Info("TransitGateway detached from VPC", "vpcID", vpcID, "transitGatewayID", gatewayID)
 	return nil
 }
 
-func (r *TransitGateway) getOrCreatePrefixList(ctx context.Context) (*types.ManagedPrefixList, error) {
+func (r *TransitGateway) getOrCreatePrefixList(ctx context.Context, addressFamily string) (*types.ManagedPrefixList, error) {
 	logger := r.getLogger(ctx)
 
-	mc, err := r.clusterClient.GetManagementCluster(ctx)
+	prefixList, prefixListName, err := r.findPrefixList(ctx, addressFamily)
 	if err != nil {
-		logger.Error(err, "Failed to get management cluster")
 		return nil, err
 	}
+	if prefixList != nil {
+		return prefixList, nil
+	}
 
-	prefixListID, err := getPrefixListID(logger, mc)
+	output, err := r.transitGatewayClient.CreateManagedPrefixList(ctx, &ec2.CreateManagedPrefixListInput{
+		AddressFamily:  awssdk.String(addressFamily),
+		MaxEntries:     awssdk.Int32(PREFIX_LIST_MAX_ENTRIES),
+		PrefixListName: &prefixListName,
+	})
 	if err != nil {
-		logger.Error(err, "Failed to get prefix list id from cluster")
+		logger.Error(err, "Failed to create prefix list", "prefixListName", prefixListName)
 		return nil, err
 	}
 
+	logger.Info("Created new prefix list", "prefixListName", prefixListName)
+	return output.PrefixList, nil
+}
+
+// findPrefixList returns the prefix list of the management cluster referenced
+// by its NetworkTopology, falling back to the expected name. It returns nil and
+// the expected name if there is no prefix list or it is being deleted.
+func (r *TransitGateway) findPrefixList(ctx context.Context, addressFamily string) (*types.ManagedPrefixList, string, error) {
+	logger := r.getLogger(ctx)
+
+	mcTopology, err := r.getManagementClusterNetworkTopology(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefixListRef := mcTopology.Spec.PrefixList
+	prefixListNameSuffix := "tgw-prefixlist"
+	if addressFamily == AddressFamilyIPv6 {
+		prefixListRef = mcTopology.Spec.IPv6PrefixList
+		prefixListNameSuffix = "tgw-ipv6-prefixlist"
+	}
+
+	prefixListName := fmt.Sprintf("%s-%s-%s", r.clusterClient.GetManagementClusterNamespacedName().Name, r.clusterClient.GetManagementClusterNamespacedName().Namespace, prefixListNameSuffix)
+
+	prefixListID, err := getResourceID(logger, prefixListRef, "prefix list")
+	if err != nil {
+		logger.Error(err, "Failed to get prefix list id from cluster")
+		return nil, "", err
+	}
+
 	if prefixListID != "" {
 		result, err := r.transitGatewayClient.DescribeManagedPrefixLists(ctx, &ec2.DescribeManagedPrefixListsInput{
 			Filters: []types.Filter{
@@ -597,12 +939,15 @@ func (r *TransitGateway) getOrCreatePrefixList(ctx context.Context) (*types.Mana
 			},
 		})
 		if err == nil && len(result.PrefixLists) == 1 {
-			return &result.PrefixLists[0], nil
+			if isPrefixListDeleted(&result.PrefixLists[0]) {
+				logger.Info("Prefix list from NetworkTopology is deleted", "prefixListID", prefixListID)
+				return nil, prefixListName, nil
+			}
+			return &result.PrefixLists[0], prefixListName, nil
 		}
-		logger.Info("Failed to get prefix list with ID from annotation, falling back to expected prefix list name")
+		logger.Info("Failed to get prefix list with ID from NetworkTopology, falling back to expected prefix list name")
 	}
 
-	prefixListName := fmt.Sprintf("%s-%s-tgw-prefixlist", r.clusterClient.GetManagementClusterNamespacedName().Name, r.clusterClient.GetManagementClusterNamespacedName().Namespace)
 	result, err := r.transitGatewayClient.DescribeManagedPrefixLists(ctx, &ec2.DescribeManagedPrefixListsInput{
 		Filters: []types.Filter{
 			{
@@ -613,204 +958,486 @@ func (r *TransitGateway) getOrCreatePrefixList(ctx context.Context) (*types.Mana
 	})
 	if err != nil {
 		logger.Error(err, "Failed to get prefix list", "prefixListName", prefixListName)
-		return nil, err
+		return nil, "", err
 	}
 
-	if len(result.PrefixLists) > 1 {
-		return nil, fmt.Errorf("unexpected number of prefix lists returned")
-	} else if len(result.PrefixLists) == 1 {
-		return &result.PrefixLists[0], nil
+	prefixLists := []types.ManagedPrefixList{}
+	for _, prefixList := range result.PrefixLists {
+		if !isPrefixListDeleted(&prefixList) {
+			prefixLists = append(prefixLists, prefixList)
+		}
 	}
 
-	output, err := r.transitGatewayClient.CreateManagedPrefixList(ctx, &ec2.CreateManagedPrefixListInput{
-		AddressFamily:  awssdk.String("IPv4"),
-		MaxEntries:     awssdk.Int32(PREFIX_LIST_MAX_ENTRIES),
-		PrefixListName: &prefixListName,
-	})
-	if err != nil {
-		logger.Error(err, "Failed to create prefix list", "prefixListName", prefixListName)
-		return nil, err
+	if len(prefixLists) > 1 {
+		return nil, "", fmt.Errorf("unexpected number of prefix lists returned")
+	} else if len(prefixLists) == 1 {
+		return &prefixLists[0], prefixListName, nil
 	}
 
-	logger.Info("Created new prefix list", "prefixListName", prefixListName)
-	return output.PrefixList, nil
+	return nil, prefixListName, nil
 }
 
-func (r *TransitGateway) addToPrefixList(ctx context.Context, awsCluster *capa.AWSCluster) (*types.ManagedPrefixList, error) {
+func (r *TransitGateway) addToPrefixList(ctx context.Context, addressFamily string, awsCluster *capa.AWSCluster, cidrs []string) (*types.ManagedPrefixList, error) {
 	logger := r.getLogger(ctx)
 
-	prefixList, err := r.getOrCreatePrefixList(ctx)
+	prefixList, err := r.getOrCreatePrefixList(ctx, addressFamily)
 	if err != nil {
 		return nil, err
 	}
 	prefixListID := *prefixList.PrefixListId
+	description := buildEntryDescription(awsCluster)
+	legacyDescription := buildLegacyEntryDescription(awsCluster)
 
-	result, err := r.transitGatewayClient.GetManagedPrefixListEntries(ctx, &ec2.GetManagedPrefixListEntriesInput{
-		PrefixListId:  &prefixListID,
-		MaxResults:    awssdk.Int32(100),
-		TargetVersion: prefixList.Version,
-	})
-	if err != nil {
-		logger.Error(err, "Failed to get prefix list entries", "prefixListID", prefixListID, "version", prefixList.Version)
-		return nil, err
-	}
+	err = r.modifyPrefixList(ctx, prefixList, func(prefixList *types.ManagedPrefixList) error {
+		entries, err := r.getPrefixListEntries(ctx, prefixList)
+		if err != nil {
+			return err
+		}
 
-	description := buildEntryDescription(awsCluster)
+		existing := map[string]bool{}
+		removeEntries := []types.RemovePrefixListEntry{}
+		migrateEntries := []types.AddPrefixListEntry{}
+		for _, entry := range entries {
+			cidr := awssdk.StringValue(entry.Cidr)
+			if awssdk.StringValue(entry.Description) == description {
+				if containsString(cidrs, cidr) {
+					existing[cidr] = true
+				} else {
+					// the CIDR block is no longer associated with the VPC
+					removeEntries = append(removeEntries, types.RemovePrefixListEntry{Cidr: entry.Cidr})
+				}
+				continue
+			}
+
+			if awssdk.StringValue(entry.Description) == legacyDescription && containsString(cidrs, cidr) {
+				// Adding an existing CIDR block updates the description of its entry
+				existing[cidr] = true
+				migrateEntries = append(migrateEntries, types.AddPrefixListEntry{
+					Cidr:        entry.Cidr,
+					Description: &description,
+				})
+				continue
+			}
 
-	for _, entry := range result.Entries {
-		if *entry.Cidr == awsCluster.Spec.NetworkSpec.VPC.CidrBlock {
-			if *entry.Description != description {
+			if containsString(cidrs, cidr) {
 				err = fmt.Errorf("conflicting CIDR already exists on prefix list")
-				logger.Error(err, "The CIDR already exists on the prefix list and belongs to another cluster", "prefixListID", prefixListID, "version", prefixList.Version, "cidr", awsCluster.Spec.NetworkSpec.VPC.CidrBlock)
-				return nil, err
+				logger.Error(err, "The CIDR already exists on the prefix list and belongs to another cluster", "prefixListID", prefixListID, "version", prefixList.Version, "cidr", cidr)
+				return err
 			}
+		}
 
-			// entry already exists
-			logger.Info("Entry already exists in prefix list, skipping")
-			return prefixList, err
+		addEntries := []types.AddPrefixListEntry{}
+		for _, cidr := range cidrs {
+			if !existing[cidr] {
+				addEntries = append(addEntries, types.AddPrefixListEntry{
+					Cidr:        awssdk.String(cidr),
+					Description: &description,
+				})
+			}
 		}
-	}
 
-	_, err = r.transitGatewayClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
-		PrefixListId:   &prefixListID,
-		CurrentVersion: prefixList.Version,
-		AddEntries: []types.AddPrefixListEntry{
-			{
-				Cidr:        &awsCluster.Spec.NetworkSpec.VPC.CidrBlock,
-				Description: &description,
-			},
-		},
+		if len(addEntries) == 0 && len(removeEntries) == 0 && len(migrateEntries) == 0 {
+			logger.Info("Entries already exist in prefix list, skipping")
+			return nil
+		}
+
+		if err := r.ensurePrefixListCapacity(ctx, prefixList, len(entries)+len(addEntries)-len(removeEntries)); err != nil {
+			return err
+		}
+
+		input := &ec2.ModifyManagedPrefixListInput{
+			PrefixListId:   &prefixListID,
+			CurrentVersion: prefixList.Version,
+		}
+		if len(addEntries) > 0 || len(migrateEntries) > 0 {
+			input.AddEntries = append(addEntries, migrateEntries...)
+		}
+		if len(removeEntries) > 0 {
+			input.RemoveEntries = removeEntries
+		}
+
+		_, err = r.transitGatewayClient.ModifyManagedPrefixList(ctx, input)
+		if err != nil {
+			logger.Error(err, "Failed to update prefix list", "prefixListID", prefixListID, "version", prefixList.Version, "cidrs", cidrs)
+			if !isPrefixListConflict(err) {
+				r.recordWarning(ctx, EventReasonPrefixListModificationFailed, "Failed to update CIDRs %v in prefix list %s: %v", cidrs, prefixListID, err)
+			}
+			return err
+		}
+
+		logger.Info("Updated CIDRs in prefix list", "prefixListID", prefixListID, "version", prefixList.Version, "added", len(addEntries), "removed", len(removeEntries), "migrated", len(migrateEntries))
+		if len(addEntries) > 0 {
+			r.recordNormal(ctx, EventReasonPrefixListEntriesAdded, "Added CIDRs %v to prefix list %s", entryCIDRs(addEntries), prefixListID)
+		}
+		if len(removeEntries) > 0 {
+			r.recordNormal(ctx, EventReasonPrefixListEntriesRemoved, "Removed CIDRs %v from prefix list %s", removeEntryCIDRs(removeEntries), prefixListID)
+		}
+		return nil
 	})
 	if err != nil {
-		logger.Error(err, "Failed to add to prefix list", "prefixListID", prefixListID, "version", prefixList.Version, "cidr", awsCluster.Spec.NetworkSpec.VPC.CidrBlock)
 		return nil, err
 	}
 
-	logger.Info("Added CIDR to prefix list", "prefixListID", prefixListID, "version", prefixList.Version, "cidr", awsCluster.Spec.NetworkSpec.VPC.CidrBlock)
 	return prefixList, nil
 }
 
-func (r *TransitGateway) removeFromPrefixList(ctx context.Context, awsCluster *capa.AWSCluster) error {
+// removeFromPrefixList removes all entries of the cluster from the prefix list,
+// including the entries of the given CIDR blocks added by earlier versions.
+func (r *TransitGateway) removeFromPrefixList(ctx context.Context, addressFamily string, awsCluster *capa.AWSCluster, cidrs []string) error {
 	logger := r.getLogger(ctx)
 
-	prefixList, err := r.getOrCreatePrefixList(ctx)
+	prefixList, _, err := r.findPrefixList(ctx, addressFamily)
 	if err != nil {
 		return err
 	}
+	if prefixList == nil {
+		logger.Info("Prefix list doesn't exist, skipping entry removal", "addressFamily", addressFamily)
+		return nil
+	}
 
-	result, err := r.transitGatewayClient.GetManagedPrefixListEntries(ctx, &ec2.GetManagedPrefixListEntriesInput{
-		PrefixListId:  prefixList.PrefixListId,
-		MaxResults:    awssdk.Int32(100),
-		TargetVersion: prefixList.Version,
+	description := buildEntryDescription(awsCluster)
+	legacyDescription := buildLegacyEntryDescription(awsCluster)
+
+	return r.modifyPrefixList(ctx, prefixList, func(prefixList *types.ManagedPrefixList) error {
+		entries, err := r.getPrefixListEntries(ctx, prefixList)
+		if err != nil {
+			return err
+		}
+
+		removeEntries := []types.RemovePrefixListEntry{}
+		removeCIDRs := []string{}
+		for _, entry := range entries {
+			entryDescription := awssdk.StringValue(entry.Description)
+			if entryDescription == description || (entryDescription == legacyDescription && containsString(cidrs, awssdk.StringValue(entry.Cidr))) {
+				removeEntries = append(removeEntries, types.RemovePrefixListEntry{Cidr: entry.Cidr})
+				removeCIDRs = append(removeCIDRs, awssdk.StringValue(entry.Cidr))
+			}
+		}
+
+		if len(removeEntries) == 0 {
+			return nil
+		}
+
+		_, err = r.transitGatewayClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+			PrefixListId:   prefixList.PrefixListId,
+			CurrentVersion: prefixList.Version,
+			RemoveEntries:  removeEntries,
+		})
+		if err != nil {
+			logger.Error(err, "Failed to remove from prefix list", "prefixListID", prefixList.PrefixListId, "version", prefixList.Version, "cidrs", removeCIDRs)
+			if !isPrefixListConflict(err) {
+				r.recordWarning(ctx, EventReasonPrefixListModificationFailed, "Failed to remove CIDRs %v from prefix list %s: %v", removeCIDRs, awssdk.StringValue(prefixList.PrefixListId), err)
+			}
+			return err
+		}
+
+		logger.Info("Removed CIDRs from prefix list", "prefixListID", prefixList.PrefixListId, "version", prefixList.Version, "cidrs", removeCIDRs)
+		r.recordNormal(ctx, EventReasonPrefixListEntriesRemoved, "Removed CIDRs %v from prefix list %s", removeCIDRs, awssdk.StringValue(prefixList.PrefixListId))
+		return nil
+	})
+}
+
+// getVPCCIDRBlocks returns the primary and all associated secondary IPv4 CIDR blocks of the
+// VPC of the cluster, starting with the primary CIDR block, and the associated IPv6 CIDR
+// blocks of dual-stack VPCs.
+func (r *TransitGateway) getVPCCIDRBlocks(ctx context.Context, awsCluster *capa.AWSCluster) ([]string, []string, error) {
+	logger := r.getLogger(ctx)
+
+	cidrs := []string{}
+	if awsCluster.Spec.NetworkSpec.VPC.CidrBlock != "" {
+		cidrs = append(cidrs, awsCluster.Spec.NetworkSpec.VPC.CidrBlock)
+	}
+	ipv6CIDRs := []string{}
+
+	// The VPC is owned by the AWS account of the workload cluster
+	transitGatewayClient := r.getTransitGatewayClientForWorkloadCluster(k8stypes.NamespacedName{
+		Name:      awsCluster.ObjectMeta.Name,
+		Namespace: awsCluster.ObjectMeta.Namespace,
+	})
+
+	vpcID := awsCluster.Spec.NetworkSpec.VPC.ID
+	result, err := transitGatewayClient.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+		VpcIds: []string{vpcID},
 	})
 	if err != nil {
-		logger.Error(err, "Failed to get prefix list entries", "prefixListID", prefixList.PrefixListId, "version", prefixList.Version)
-		return err
+		logger.Error(err, "Failed to get VPC", "vpcID", vpcID)
+		return nil, nil, err
+	}
+	if result == nil {
+		return cidrs, ipv6CIDRs, nil
 	}
 
-	for _, entry := range result.Entries {
-		if *entry.Cidr == awsCluster.Spec.NetworkSpec.VPC.CidrBlock && *entry.Description == buildEntryDescription(awsCluster) {
-			_, err = r.transitGatewayClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
-				PrefixListId:   prefixList.PrefixListId,
-				CurrentVersion: prefixList.Version,
-				RemoveEntries: []types.RemovePrefixListEntry{
-					{Cidr: &awsCluster.Spec.NetworkSpec.VPC.CidrBlock},
-				},
-			})
-			if err != nil {
-				logger.Error(err, "Failed to remove from prefix list", "prefixListID", prefixList.PrefixListId, "version", prefixList.Version, "cidr", awsCluster.Spec.NetworkSpec.VPC.CidrBlock)
-				return err
+	for _, vpc := range result.Vpcs {
+		for _, association := range vpc.CidrBlockAssociationSet {
+			if association.CidrBlockState == nil || association.CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+				continue
 			}
 
-			logger.Info("Removed CIDR from prefix list", "prefixListID", prefixList.PrefixListId, "version", prefixList.Version, "cidr", awsCluster.Spec.NetworkSpec.VPC.CidrBlock)
-			return nil
+			cidr := awssdk.StringValue(association.CidrBlock)
+			if cidr != "" && !containsString(cidrs, cidr) {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+
+		for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+			if association.Ipv6CidrBlockState == nil || association.Ipv6CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+				continue
+			}
+
+			cidr := awssdk.StringValue(association.Ipv6CidrBlock)
+			if cidr != "" && !containsString(ipv6CIDRs, cidr) {
+				ipv6CIDRs = append(ipv6CIDRs, cidr)
+			}
 		}
 	}
 
-	return nil
+	return cidrs, ipv6CIDRs, nil
+}
+
+// getPrefixListEntries returns all entries of the current version of the prefix list
+func (r *TransitGateway) getPrefixListEntries(ctx context.Context, prefixList *types.ManagedPrefixList) ([]types.PrefixListEntry, error) {
+	logger := r.getLogger(ctx)
+
+	entries := []types.PrefixListEntry{}
+	input := &ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId:  prefixList.PrefixListId,
+		MaxResults:    awssdk.Int32(100),
+		TargetVersion: prefixList.Version,
+	}
+	for {
+		result, err := r.transitGatewayClient.GetManagedPrefixListEntries(ctx, input)
+		if err != nil {
+			logger.Error(err, "Failed to get prefix list entries", "prefixListID", prefixList.PrefixListId, "version", prefixList.Version)
+			return nil, err
+		}
+
+		entries = append(entries, result.Entries...)
+
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	metrics.SetPrefixListEntries(*prefixList.PrefixListId, len(entries), prefixList.MaxEntries)
+	return entries, nil
 }
 
-// Search subnets with expected attachment, if there are not any
-// choose first one per AZ
-func (r *TransitGateway) getTGWGAttachmentSubnetsOrDefault(ctx context.Context, transitGatewayClient awsclient.TransitGatewayClient, awsCluster *capa.AWSCluster) ([]string, error) {
+// ensurePrefixListCapacity grows the prefix list if it can't hold the required number of
+// entries. Resizing and modifying the entries of a prefix list can't be done at the same
+// time, so the entry has to be added once the resize is complete.
+func (r *TransitGateway) ensurePrefixListCapacity(ctx context.Context, prefixList *types.ManagedPrefixList, requiredEntries int) error {
+	logger := r.getLogger(ctx)
+
+	if prefixList.MaxEntries == nil || int(*prefixList.MaxEntries) >= requiredEntries {
+		return nil
+	}
+
+	maxEntries := *prefixList.MaxEntries
+	if maxEntries >= r.options.PrefixListMaxEntriesCeiling || int(r.options.PrefixListMaxEntriesCeiling) < requiredEntries {
+		err := &PrefixListFullError{PrefixListID: *prefixList.PrefixListId, MaxEntries: maxEntries}
+		logger.Error(err, "Prefix list reached the maximum number of entries", "prefixListID", prefixList.PrefixListId, "maxEntries", maxEntries, "ceiling", r.options.PrefixListMaxEntriesCeiling)
+		return err
+	}
+
+	newMaxEntries := maxEntries + PREFIX_LIST_MAX_ENTRIES_INCREMENT
+	if newMaxEntries > r.options.PrefixListMaxEntriesCeiling {
+		newMaxEntries = r.options.PrefixListMaxEntriesCeiling
+	}
+
+	_, err := r.transitGatewayClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+		PrefixListId:   prefixList.PrefixListId,
+		CurrentVersion: prefixList.Version,
+		MaxEntries:     &newMaxEntries,
+	})
+	if err != nil {
+		logger.Error(err, "Failed to resize prefix list", "prefixListID", prefixList.PrefixListId, "maxEntries", maxEntries, "newMaxEntries", newMaxEntries)
+		return err
+	}
+
+	logger.Info("Resized prefix list", "prefixListID", prefixList.PrefixListId, "maxEntries", maxEntries, "newMaxEntries", newMaxEntries)
+	return &PrefixListNotReadyError{}
+}
+
+// getTGWGAttachmentSubnetsOrDefault returns one selected subnet per availability zone. Without
+// a subnet selector the private subnets labelled for transit gateway attachments are selected,
+// falling back to the private subnets of the cluster. With a selector only subnets with
+// matching tags are selected and there is no fallback.
+// Attached subnets are preferred, so the choice stays stable while they are still selected.
+func (r *TransitGateway) getTGWGAttachmentSubnetsOrDefault(ctx context.Context, transitGatewayClient awsclient.TransitGatewayClient, awsCluster *capa.AWSCluster, subnetSelector map[string]string, attachedSubnets []string) ([]string, error) {
 	result := make([]string, 0)
+
+	filters := []types.Filter{
+		{Name: awssdk.String(tagKey + capa.NameKubernetesAWSCloudProviderPrefix + awsCluster.Name), Values: []string{"owned", "shared"}},
+	}
+	if len(subnetSelector) == 0 {
+		filters = append(filters,
+			types.Filter{Name: awssdk.String(tagKey + SubnetTGWAttachementsLabel), Values: []string{"true"}},
+			types.Filter{Name: awssdk.String(tagKey + SubnetRoleLabel), Values: []string{"private"}},
+		)
+	}
+	for key, value := range subnetSelector {
+		filters = append(filters, types.Filter{Name: awssdk.String(tagKey + key), Values: []string{value}})
+	}
+
 	output, err := transitGatewayClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
-		Filters: []types.Filter{
-			{Name: awssdk.String(tagKey + capa.NameKubernetesAWSCloudProviderPrefix + awsCluster.Name), Values: []string{"owned", "shared"}},
-			{Name: awssdk.String(tagKey + SubnetTGWAttachementsLabel), Values: []string{"true"}},
-			{Name: awssdk.String(tagKey + SubnetRoleLabel), Values: []string{"private"}},
-		},
+		Filters: filters,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(subnetSelector) > 0 && (output == nil || len(output.Subnets) == 0) {
+		return result, nil
+	}
+
 	if output == nil || len(output.Subnets) == 0 {
-		result := getPrivateSubnetsByAZ(awsCluster.Spec.NetworkSpec.Subnets)
+		result := getPrivateSubnetsByAZ(awsCluster.Spec.NetworkSpec.Subnets, attachedSubnets)
 		return result, nil
 	}
 
-	azMap := make(map[string]bool)
+	azIndex := make(map[string]int)
 	for _, subnet := range output.Subnets {
-		if !azMap[*subnet.AvailabilityZone] {
-			result = append(result, *subnet.SubnetId)
-			azMap[*subnet.AvailabilityZone] = true
-		}
+		result = selectSubnetByAZ(result, azIndex, *subnet.AvailabilityZone, *subnet.SubnetId, attachedSubnets)
 	}
 	return result, nil
 }
 
-func getPrivateSubnetsByAZ(subnets capa.Subnets) []string {
-	azMap := make(map[string]bool)
+func getPrivateSubnetsByAZ(subnets capa.Subnets, attachedSubnets []string) []string {
+	azIndex := make(map[string]int)
 	result := make([]string, 0)
 	for _, subnet := range subnets {
 		if !subnet.IsPublic {
-			if !azMap[subnet.AvailabilityZone] {
-				result = append(result, subnet.ID)
-				azMap[subnet.AvailabilityZone] = true
-			}
+			result = selectSubnetByAZ(result, azIndex, subnet.AvailabilityZone, subnet.ID, attachedSubnets)
 		}
 	}
 
 	return result
 }
 
+// selectSubnetByAZ adds the subnet to the selected subnets if none of its availability zone
+// is selected yet, or replaces the selected one if only the subnet is attached.
+func selectSubnetByAZ(selected []string, azIndex map[string]int, az, subnetID string, attachedSubnets []string) []string {
+	i, ok := azIndex[az]
+	if !ok {
+		azIndex[az] = len(selected)
+		return append(selected, subnetID)
+	}
+
+	if !containsString(attachedSubnets, selected[i]) && containsString(attachedSubnets, subnetID) {
+		selected[i] = subnetID
+	}
+	return selected
+}
+
+// buildEntryDescription returns the description of the prefix list entries of
+// the cluster, which is unique across namespaces and VPCs.
 func buildEntryDescription(awsCluster *capa.AWSCluster) string {
+	return fmt.Sprintf("CIDR block for cluster %s/%s in %s", awsCluster.Namespace, awsCluster.Name, awsCluster.Spec.NetworkSpec.VPC.ID)
+}
+
+// buildLegacyEntryDescription returns the description earlier versions used for
+// the prefix list entries of the cluster. It is shared by clusters with the
+// same name in different namespaces, so only entries with a CIDR block of the
+// cluster are migrated to the current description.
+func buildLegacyEntryDescription(awsCluster *capa.AWSCluster) string {
 	return fmt.Sprintf("CIDR block for cluster %s", awsCluster.Name)
 }
 
-func getTransitGatewayID(logger logr.Logger, cluster *capi.Cluster) (string, error) {
-	gatewayAnnotation := annotations.GetNetworkTopologyTransitGateway(cluster)
-	if gatewayAnnotation == "" {
-		return "", nil
+// entryStatusCIDRs returns the CIDR blocks of the prefix list entry status.
+func entryStatusCIDRs(status *v1alpha1.PrefixListEntryStatus) []string {
+	if status == nil {
+		return nil
 	}
+	return append([]string{status.CIDR}, status.SecondaryCIDRs...)
+}
 
-	// For migration purposes we allow the transit gateway annotation to either
-	// contain an ARN or the ID. If we can't parse the arn we assume that an ID
-	// is provided. We always save the ARN later
-	transitGatewayID, err := aws.GetARNResourceID(gatewayAnnotation)
-	if err != nil {
-		logger.Info("Failed to parse transit gateway ARN, assuming ID is provided")
-		return gatewayAnnotation, nil
+func entryCIDRs(entries []types.AddPrefixListEntry) []string {
+	cidrs := []string{}
+	for _, entry := range entries {
+		cidrs = append(cidrs, awssdk.StringValue(entry.Cidr))
+	}
+	return cidrs
+}
+
+func removeEntryCIDRs(entries []types.RemovePrefixListEntry) []string {
+	cidrs := []string{}
+	for _, entry := range entries {
+		cidrs = append(cidrs, awssdk.StringValue(entry.Cidr))
+	}
+	return cidrs
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func setTransitGatewayAttachmentStatus(topology *v1alpha1.NetworkTopology, attachment *types.TransitGatewayVpcAttachment) {
+	if attachment == nil || attachment.TransitGatewayAttachmentId == nil {
+		return
 	}
 
-	return transitGatewayID, nil
+	status := &v1alpha1.TransitGatewayAttachmentStatus{
+		ID:    *attachment.TransitGatewayAttachmentId,
+		State: string(attachment.State),
+	}
+
+	// Keep track of the acceptance requests of the attachment and of the
+	// recreations of failed attachments until an attachment is available
+	previous := topology.Status.TransitGatewayAttachment
+	if previous != nil && previous.ID == status.ID {
+		status.AcceptanceRequest = previous.AcceptanceRequest
+	}
+	if previous != nil && attachment.State != types.TransitGatewayAttachmentStateAvailable {
+		status.Recreations = previous.Recreations
+	}
+
+	// The route table of accepted attachments of user managed transit gateways
+	// is only known from the approval callback
+	if response := acceptanceResponse(status); response != nil && response.Decision == v1alpha1.AttachmentAccepted {
+		status.RouteTableID = response.RouteTableID
+	}
+
+	topology.Status.TransitGatewayAttachment = status
+}
+
+func acceptanceResponse(status *v1alpha1.TransitGatewayAttachmentStatus) *v1alpha1.AttachmentAcceptanceResponse {
+	if status == nil || status.AcceptanceRequest == nil {
+		return nil
+	}
+	return status.AcceptanceRequest.Response
 }
 
-func getPrefixListID(logger logr.Logger, cluster *capi.Cluster) (string, error) {
-	prefixListAnnoation := annotations.GetNetworkTopologyPrefixList(cluster)
-	if prefixListAnnoation == "" {
+func getTransitGatewayID(logger logr.Logger, topology *v1alpha1.NetworkTopology) (string, error) {
+	return getResourceID(logger, topology.Spec.TransitGateway, "transit gateway")
+}
+
+func getPrefixListID(logger logr.Logger, topology *v1alpha1.NetworkTopology) (string, error) {
+	return getResourceID(logger, topology.Spec.PrefixList, "prefix list")
+}
+
+func getResourceID(logger logr.Logger, ref *v1alpha1.AWSResourceReference, resourceType string) (string, error) {
+	if ref.IsZero() {
 		return "", nil
 	}
 
-	// For migration purposes we allow the prefix list annotation to either
-	// contain an ARN or the ID. If we can't parse the arn we assume that an ID
+	if ref.ARN == "" {
+		return ref.ID, nil
+	}
+
+	// For migration purposes the ARN imported from the annotations may
+	// actually contain the ID. If we can't parse the arn we assume that an ID
 	// is provided. We always save the ARN later
-	prefixListID, err := aws.GetARNResourceID(prefixListAnnoation)
+	resourceID, err := aws.GetARNResourceID(ref.ARN)
 	if err != nil {
-		logger.Info("Failed to parse prefix list ARN, assuming ID is provided")
-		return prefixListAnnoation, nil
+		logger.Info(fmt.Sprintf("Failed to parse %s ARN, assuming ID is provided", resourceType))
+		return ref.ARN, nil
 	}
 
-	return prefixListID, nil
+	return resourceID, nil
 }
@@ -0,0 +1,472 @@
+package registrar_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws/awsfakes"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar/registrarfakes"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/tracing"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+const (
+	region    = "eu-north-1"
+	mcAccount = "111111111111"
+	mcVPCID   = "vpc-mc"
+)
+
+// transitGatewayClient calls the EC2 API of the fake AWS server. The SNS
+// calls go to a counterfeiter fake.
+type transitGatewayClient struct {
+	*ec2.Client
+	snsClient
+}
+
+type snsClient struct {
+	*awsfakes.FakeTransitGatewayClient
+}
+
+var _ = Describe("TransitGateway", func() {
+	var (
+		ctx context.Context
+
+		server        *fakeaws.Server
+		clusterClient *registrarfakes.FakeClusterClient
+		registrarTGW  *registrar.TransitGateway
+
+		cluster    *capi.Cluster
+		awsCluster *capa.AWSCluster
+		topology   *v1alpha1.NetworkTopology
+
+		managementCluster = k8stypes.NamespacedName{Name: "mc", Namespace: "giantswarm"}
+	)
+
+	newTransitGatewayClient := func(account string) awsclient.TransitGatewayClient {
+		return &transitGatewayClient{
+			Client: ec2.New(ec2.Options{
+				Region:       region,
+				BaseEndpoint: aws.String(server.URL()),
+				Credentials:  credentials.NewStaticCredentialsProvider(fakeaws.AccessKey(account), "secret", ""),
+			}),
+			snsClient: snsClient{new(awsfakes.FakeTransitGatewayClient)},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		server = fakeaws.NewServer(region)
+		server.AddVPC(mcAccount, types.Vpc{VpcId: aws.String(mcVPCID), CidrBlock: aws.String("10.0.0.0/16")})
+		server.AddSubnet(mcAccount, types.Subnet{
+			SubnetId:         aws.String("subnet-mc-a"),
+			VpcId:            aws.String(mcVPCID),
+			AvailabilityZone: aws.String(region + "a"),
+		})
+
+		cluster = &capi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      managementCluster.Name,
+				Namespace: managementCluster.Namespace,
+			},
+			Spec: capi.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{
+					Name:      managementCluster.Name,
+					Namespace: managementCluster.Namespace,
+				},
+			},
+		}
+		awsCluster = &capa.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      managementCluster.Name,
+				Namespace: managementCluster.Namespace,
+			},
+			Spec: capa.AWSClusterSpec{
+				NetworkSpec: capa.NetworkSpec{
+					VPC: capa.VPCSpec{ID: mcVPCID, CidrBlock: "10.0.0.0/16"},
+					Subnets: capa.Subnets{
+						{ID: "subnet-mc-a", AvailabilityZone: region + "a"},
+					},
+				},
+			},
+		}
+
+		clusterClient = new(registrarfakes.FakeClusterClient)
+		clusterClient.GetManagementClusterNamespacedNameReturns(managementCluster)
+		clusterClient.IsManagementClusterReturns(true)
+		clusterClient.GetAWSClusterReturns(awsCluster, nil)
+		clusterClient.GetNetworkTopologyStub = func(context.Context, k8stypes.NamespacedName) (*v1alpha1.NetworkTopology, error) {
+			return topology, nil
+		}
+
+		registrarTGW = registrar.NewTransitGateway(
+			newTransitGatewayClient(mcAccount),
+			clusterClient,
+			func(k8stypes.NamespacedName) awsclient.TransitGatewayClient {
+				return newTransitGatewayClient(mcAccount)
+			},
+			registrar.TransitGatewayOptions{AttachmentRecreationLimit: registrar.DefaultAttachmentRecreationLimit},
+			record.NewFakeRecorder(100),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		Expect(server.UnsupportedActions()).To(BeEmpty())
+	})
+
+	Describe("attachment subnets", func() {
+		var attachment *types.TransitGatewayVpcAttachment
+
+		BeforeEach(func() {
+			transitGateway := server.AddTransitGateway(mcAccount, nil)
+			prefixList := server.AddManagedPrefixList(mcAccount, "mc-giantswarm-tgw-prefixlist", registrar.AddressFamilyIPv4, 45)
+
+			// Two selected subnets in the availability zone, the second one is attached
+			for _, subnetID := range []string{"subnet-mc-a1", "subnet-mc-a2"} {
+				server.AddSubnet(mcAccount, types.Subnet{
+					SubnetId:         aws.String(subnetID),
+					VpcId:            aws.String(mcVPCID),
+					AvailabilityZone: aws.String(region + "a"),
+					Tags: []types.Tag{
+						{Key: aws.String("kubernetes.io/cluster/mc"), Value: aws.String("owned")},
+						{Key: aws.String("team"), Value: aws.String("network")},
+					},
+				})
+			}
+
+			out, err := newTransitGatewayClient(mcAccount).CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
+				TransitGatewayId: transitGateway.TransitGatewayId,
+				VpcId:            aws.String(mcVPCID),
+				SubnetIds:        []string{"subnet-mc-a2"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			attachment = out.TransitGatewayVpcAttachment
+
+			topology = &v1alpha1.NetworkTopology{
+				Spec: v1alpha1.NetworkTopologySpec{
+					Mode:                     v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+					TransitGateway:           &v1alpha1.AWSResourceReference{ID: *transitGateway.TransitGatewayId, ARN: *transitGateway.TransitGatewayArn},
+					PrefixList:               &v1alpha1.AWSResourceReference{ID: *prefixList.PrefixListId, ARN: *prefixList.PrefixListArn},
+					AttachmentSubnetSelector: map[string]string{"team": "network"},
+				},
+			}
+		})
+
+		It("keeps the attached subnet while it is selected", func() {
+			Expect(registrarTGW.Register(ctx, cluster, topology)).To(Succeed())
+
+			Expect(server.TransitGatewayVpcAttachments()).To(ConsistOf(HaveField("SubnetIds", ConsistOf("subnet-mc-a2"))))
+			Expect(server.RequestCount("ec2", "ModifyTransitGatewayVpcAttachment")).To(BeZero())
+		})
+
+		When("a subnet in another availability zone is selected", func() {
+			BeforeEach(func() {
+				server.AddSubnet(mcAccount, types.Subnet{
+					SubnetId:         aws.String("subnet-mc-b"),
+					VpcId:            aws.String(mcVPCID),
+					AvailabilityZone: aws.String(region + "b"),
+					Tags: []types.Tag{
+						{Key: aws.String("kubernetes.io/cluster/mc"), Value: aws.String("owned")},
+						{Key: aws.String("team"), Value: aws.String("network")},
+					},
+				})
+			})
+
+			It("adds the subnet and continues with the prefix list", func() {
+				Expect(registrarTGW.Register(ctx, cluster, topology)).To(Succeed())
+
+				Expect(server.TransitGatewayVpcAttachments()).To(ConsistOf(HaveField("SubnetIds", ConsistOf("subnet-mc-a2", "subnet-mc-b"))))
+				Expect(server.PrefixListEntries(topology.Spec.PrefixList.ID)).To(ContainElement(HaveField("Cidr", aws.String("10.0.0.0/16"))))
+				Expect(topology.Status.PrefixListEntry).NotTo(BeNil())
+			})
+		})
+
+		When("the selector doesn't match any subnet", func() {
+			BeforeEach(func() {
+				topology.Spec.AttachmentSubnetSelector = map[string]string{"team": "unknown"}
+			})
+
+			It("keeps the subnets of the attachment", func() {
+				err := registrarTGW.Register(ctx, cluster, topology)
+				Expect(errors.Is(err, &registrar.NoAttachmentSubnetsError{})).To(BeTrue())
+				Expect(topology.Status.TransitGatewayAttachment.ID).To(Equal(*attachment.TransitGatewayAttachmentId))
+
+				Expect(server.TransitGatewayVpcAttachments()).To(ConsistOf(HaveField("SubnetIds", ConsistOf("subnet-mc-a2"))))
+				Expect(server.RequestCount("ec2", "ModifyTransitGatewayVpcAttachment")).To(BeZero())
+			})
+		})
+	})
+
+	Describe("failed attachments", func() {
+		var attachmentID string
+
+		BeforeEach(func() {
+			transitGateway := server.AddTransitGateway(mcAccount, nil)
+			prefixList := server.AddManagedPrefixList(mcAccount, "mc-giantswarm-tgw-prefixlist", registrar.AddressFamilyIPv4, 45)
+
+			out, err := newTransitGatewayClient(mcAccount).CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
+				TransitGatewayId: transitGateway.TransitGatewayId,
+				VpcId:            aws.String(mcVPCID),
+				SubnetIds:        []string{"subnet-mc-a"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			attachmentID = *out.TransitGatewayVpcAttachment.TransitGatewayAttachmentId
+			Expect(server.SetTransitGatewayVpcAttachmentState(attachmentID, types.TransitGatewayAttachmentStateFailed)).To(Succeed())
+
+			topology = &v1alpha1.NetworkTopology{
+				Spec: v1alpha1.NetworkTopologySpec{
+					Mode:           v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+					TransitGateway: &v1alpha1.AWSResourceReference{ID: *transitGateway.TransitGatewayId, ARN: *transitGateway.TransitGatewayArn},
+					PrefixList:     &v1alpha1.AWSResourceReference{ID: *prefixList.PrefixListId, ARN: *prefixList.PrefixListArn},
+				},
+			}
+		})
+
+		It("deletes the failed attachment before creating a new one", func() {
+			err := registrarTGW.Register(ctx, cluster, topology)
+			Expect(errors.Is(err, &registrar.AttachmentFailedError{})).To(BeTrue())
+			Expect(err.(*registrar.AttachmentFailedError).State).To(Equal(string(types.TransitGatewayAttachmentStateDeleting)))
+			Expect(topology.Status.TransitGatewayAttachment.ID).To(Equal(attachmentID))
+			Expect(topology.Status.TransitGatewayAttachment.Recreations).To(Equal(int32(1)))
+
+			Expect(server.RequestCount("ec2", "DeleteTransitGatewayVpcAttachment")).To(Equal(1))
+			Expect(server.RequestCount("ec2", "CreateTransitGatewayVpcAttachment")).To(Equal(1))
+			Expect(server.TransitGatewayVpcAttachments()).To(BeEmpty())
+
+			Expect(registrarTGW.Register(ctx, cluster, topology)).To(Succeed())
+			Expect(server.TransitGatewayVpcAttachments()).To(ConsistOf(HaveField("State", types.TransitGatewayAttachmentStateAvailable)))
+			Expect(topology.Status.TransitGatewayAttachment.ID).NotTo(Equal(attachmentID))
+		})
+
+		When("the failed attachment is still being deleted", func() {
+			BeforeEach(func() {
+				Expect(server.SetTransitGatewayVpcAttachmentState(attachmentID, types.TransitGatewayAttachmentStateDeleting)).To(Succeed())
+				topology.Status.TransitGatewayAttachment = &v1alpha1.TransitGatewayAttachmentStatus{
+					ID:          attachmentID,
+					State:       string(types.TransitGatewayAttachmentStateDeleting),
+					Recreations: 1,
+				}
+			})
+
+			It("waits for the deletion", func() {
+				err := registrarTGW.Register(ctx, cluster, topology)
+				Expect(errors.Is(err, &registrar.AttachmentFailedError{})).To(BeTrue())
+				Expect(topology.Status.TransitGatewayAttachment.Recreations).To(Equal(int32(1)))
+
+				Expect(server.RequestCount("ec2", "DeleteTransitGatewayVpcAttachment")).To(BeZero())
+				Expect(server.RequestCount("ec2", "CreateTransitGatewayVpcAttachment")).To(Equal(1))
+			})
+		})
+
+		When("the failed attachment is deleted", func() {
+			BeforeEach(func() {
+				Expect(server.SetTransitGatewayVpcAttachmentState(attachmentID, types.TransitGatewayAttachmentStateDeleted)).To(Succeed())
+				topology.Status.TransitGatewayAttachment = &v1alpha1.TransitGatewayAttachmentStatus{
+					ID:          attachmentID,
+					State:       string(types.TransitGatewayAttachmentStateDeleting),
+					Recreations: 1,
+				}
+			})
+
+			It("creates a new attachment without counting the recreation again", func() {
+				Expect(registrarTGW.Register(ctx, cluster, topology)).To(Succeed())
+				Expect(topology.Status.TransitGatewayAttachment.ID).NotTo(Equal(attachmentID))
+				Expect(topology.Status.TransitGatewayAttachment.Recreations).To(Equal(int32(1)))
+
+				Expect(server.RequestCount("ec2", "CreateTransitGatewayVpcAttachment")).To(Equal(2))
+				Expect(server.TransitGatewayVpcAttachments()).To(ConsistOf(
+					HaveField("State", types.TransitGatewayAttachmentStateDeleted),
+					HaveField("State", types.TransitGatewayAttachmentStateAvailable),
+				))
+			})
+		})
+
+		When("the recreation limit is reached", func() {
+			BeforeEach(func() {
+				topology.Status.TransitGatewayAttachment = &v1alpha1.TransitGatewayAttachmentStatus{
+					ID:          attachmentID,
+					State:       string(types.TransitGatewayAttachmentStateFailed),
+					Recreations: registrar.DefaultAttachmentRecreationLimit,
+				}
+			})
+
+			It("keeps the failed attachment", func() {
+				err := registrarTGW.Register(ctx, cluster, topology)
+				Expect(errors.Is(err, &registrar.AttachmentFailedError{})).To(BeTrue())
+				Expect(err.(*registrar.AttachmentFailedError).Exhausted).To(BeTrue())
+
+				Expect(server.RequestCount("ec2", "DeleteTransitGatewayVpcAttachment")).To(BeZero())
+				Expect(server.TransitGatewayVpcAttachments()).To(ConsistOf(HaveField("State", types.TransitGatewayAttachmentStateFailed)))
+			})
+		})
+	})
+
+	Describe("missing IDs", func() {
+		BeforeEach(func() {
+			topology = &v1alpha1.NetworkTopology{
+				Spec: v1alpha1.NetworkTopologySpec{
+					Mode: v1alpha1.NetworkTopologyModeUserManaged,
+				},
+			}
+		})
+
+		It("returns an error that can be recorded on a span", func() {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			spanCtx, span := provider.Tracer("test").Start(ctx, "TransitGateway.Register")
+
+			err := registrarTGW.Register(spanCtx, cluster, topology)
+			Expect(err).To(MatchError(&registrar.IDNotProvidedError{}))
+			Expect(func() { tracing.End(span, err) }).NotTo(Panic())
+
+			Expect(spanRecorder.Ended()).To(ConsistOf(HaveField("Status().Description", "PrefixList ID not provided")))
+		})
+	})
+
+	Describe("prefix list entries", func() {
+		var (
+			prefixList types.ManagedPrefixList
+
+			description      = "CIDR block for cluster giantswarm/mc in " + mcVPCID
+			otherDescription = "CIDR block for cluster other/mc in vpc-other"
+		)
+
+		BeforeEach(func() {
+			transitGateway := server.AddTransitGateway(mcAccount, nil)
+			prefixList = server.AddManagedPrefixList(mcAccount, "mc-giantswarm-tgw-prefixlist", registrar.AddressFamilyIPv4, 45)
+
+			// Entries of the management cluster, added by an earlier version, and of a
+			// cluster with the same name in another namespace
+			_, err := newTransitGatewayClient(mcAccount).ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+				PrefixListId:   prefixList.PrefixListId,
+				CurrentVersion: prefixList.Version,
+				AddEntries: []types.AddPrefixListEntry{
+					{Cidr: aws.String("10.0.0.0/16"), Description: aws.String("CIDR block for cluster mc")},
+					{Cidr: aws.String("10.1.0.0/16"), Description: aws.String("CIDR block for cluster mc")},
+					{Cidr: aws.String("10.2.0.0/16"), Description: aws.String(otherDescription)},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			topology = &v1alpha1.NetworkTopology{
+				Spec: v1alpha1.NetworkTopologySpec{
+					Mode:           v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+					DeletionPolicy: v1alpha1.DeletionPolicyRetain,
+					TransitGateway: &v1alpha1.AWSResourceReference{ID: *transitGateway.TransitGatewayId, ARN: *transitGateway.TransitGatewayArn},
+					PrefixList:     &v1alpha1.AWSResourceReference{ID: *prefixList.PrefixListId, ARN: *prefixList.PrefixListArn},
+				},
+			}
+		})
+
+		It("migrates the entries of the cluster and keeps the entries of other clusters", func() {
+			Expect(registrarTGW.Register(ctx, cluster, topology)).To(Succeed())
+
+			Expect(server.PrefixListEntries(*prefixList.PrefixListId)).To(ConsistOf(
+				types.PrefixListEntry{Cidr: aws.String("10.0.0.0/16"), Description: aws.String(description)},
+				types.PrefixListEntry{Cidr: aws.String("10.1.0.0/16"), Description: aws.String("CIDR block for cluster mc")},
+				types.PrefixListEntry{Cidr: aws.String("10.2.0.0/16"), Description: aws.String(otherDescription)},
+			))
+			Expect(topology.Status.PrefixListEntry.Description).To(Equal(description))
+		})
+
+		It("only removes the entries of the cluster", func() {
+			topology.Status.PrefixListEntry = &v1alpha1.PrefixListEntryStatus{
+				PrefixListID: *prefixList.PrefixListId,
+				CIDR:         "10.0.0.0/16",
+			}
+
+			Expect(registrarTGW.Unregister(ctx, cluster, topology)).To(Succeed())
+
+			Expect(server.PrefixListEntries(*prefixList.PrefixListId)).To(ConsistOf(
+				types.PrefixListEntry{Cidr: aws.String("10.1.0.0/16"), Description: aws.String("CIDR block for cluster mc")},
+				types.PrefixListEntry{Cidr: aws.String("10.2.0.0/16"), Description: aws.String(otherDescription)},
+			))
+		})
+	})
+
+	Describe("Unregister", func() {
+		When("the management cluster is deleted and a prefix list is still referenced", func() {
+			var ipv4PrefixList, ipv6PrefixList types.ManagedPrefixList
+
+			BeforeEach(func() {
+				transitGateway := server.AddTransitGateway(mcAccount, nil)
+				ipv4PrefixList = server.AddManagedPrefixList(mcAccount, "mc-giantswarm-tgw-prefixlist", registrar.AddressFamilyIPv4, 45)
+				ipv6PrefixList = server.AddManagedPrefixList(mcAccount, "mc-giantswarm-tgw-ipv6-prefixlist", registrar.AddressFamilyIPv6, 45)
+				Expect(server.AssociateManagedPrefixList(*ipv6PrefixList.PrefixListId, "sg-1234")).To(Succeed())
+
+				topology = &v1alpha1.NetworkTopology{
+					Spec: v1alpha1.NetworkTopologySpec{
+						Mode:           v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+						TransitGateway: &v1alpha1.AWSResourceReference{ID: *transitGateway.TransitGatewayId, ARN: *transitGateway.TransitGatewayArn},
+						PrefixList:     &v1alpha1.AWSResourceReference{ID: *ipv4PrefixList.PrefixListId, ARN: *ipv4PrefixList.PrefixListArn},
+						IPv6PrefixList: &v1alpha1.AWSResourceReference{ID: *ipv6PrefixList.PrefixListId, ARN: *ipv6PrefixList.PrefixListArn},
+					},
+				}
+			})
+
+			It("doesn't recreate the deleted prefix list on the following passes", func() {
+				for pass := 0; pass < 3; pass++ {
+					err := registrarTGW.Unregister(ctx, cluster, topology)
+					var blockedErr *registrar.TransitGatewayDeletionBlockedError
+					Expect(errors.As(err, &blockedErr)).To(BeTrue(), "pass %d", pass)
+					Expect(blockedErr.Stage).To(Equal(string(v1alpha1.TransitGatewayDeletionDeletingPrefixLists)))
+					Expect(blockedErr.Blocking).To(ConsistOf(*ipv6PrefixList.PrefixListId + " (sg-1234)"))
+					Expect(topology.Status.TransitGatewayDeletion.Attempts).To(BeEquivalentTo(pass + 1))
+
+					prefixLists := server.ManagedPrefixLists()
+					Expect(prefixLists).To(HaveLen(1))
+					Expect(prefixLists[0].PrefixListId).To(Equal(ipv6PrefixList.PrefixListId))
+				}
+
+				Expect(server.TransitGateways()).To(BeEmpty())
+				Expect(server.RequestCount("ec2", "CreateManagedPrefixList")).To(BeZero())
+				Expect(server.RequestCount("ec2", "DeleteTransitGateway")).To(Equal(1))
+
+				expected := fmt.Sprintf(`
+# HELP aws_network_topology_operator_prefix_list_entries Number of entries used in the prefix list.
+# TYPE aws_network_topology_operator_prefix_list_entries gauge
+aws_network_topology_operator_prefix_list_entries{prefix_list_id="%s"} 0
+`, *ipv6PrefixList.PrefixListId)
+				Expect(testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), "aws_network_topology_operator_prefix_list_entries")).To(Succeed())
+			})
+
+			It("continues with the prefix lists if the deletion stage of the transit gateway was lost", func() {
+				err := registrarTGW.Unregister(ctx, cluster, topology)
+				Expect(err).To(MatchError(&registrar.TransitGatewayDeletionBlockedError{}))
+
+				// The status patch after deleting the transit gateway failed
+				topology.Status.TransitGatewayDeletion = nil
+
+				err = registrarTGW.Unregister(ctx, cluster, topology)
+				var blockedErr *registrar.TransitGatewayDeletionBlockedError
+				Expect(errors.As(err, &blockedErr)).To(BeTrue())
+				Expect(blockedErr.Stage).To(Equal(string(v1alpha1.TransitGatewayDeletionDeletingPrefixLists)))
+
+				Expect(server.RequestCount("ec2", "DeleteTransitGateway")).To(Equal(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+)
+
+const otlpTracesPath = "/v1/traces"
+
+// NewOTLPExporter returns an exporter sending spans to the OTLP/HTTP receiver at the
+// endpoint, e.g. http://otel-collector:4318. TLS is used for https endpoints.
+func NewOTLPExporter(ctx context.Context, endpoint string) (*otlptrace.Exporter, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" || (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, expected a URL like http://otel-collector:4318", endpoint)
+	}
+
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(endpointURL.Host),
+		otlptracehttp.WithURLPath(strings.TrimSuffix(endpointURL.Path, "/") + otlpTracesPath),
+		otlptracehttp.WithTimeout(10 * time.Second),
+	}
+	if endpointURL.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	return otlptracehttp.New(ctx, options...)
+}
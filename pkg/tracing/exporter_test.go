@@ -0,0 +1,97 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/giantswarm/aws-network-topology-operator/pkg/tracing"
+)
+
+var _ = Describe("OTLPExporter", func() {
+	var (
+		ctx context.Context
+
+		server   *httptest.Server
+		requests chan *coltracepb.ExportTraceServiceRequest
+		provider *sdktrace.TracerProvider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		requests = make(chan *coltracepb.ExportTraceServiceRequest, 10)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.URL.Path).To(Equal("/otel/v1/traces"))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/x-protobuf"))
+
+			body, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			request := &coltracepb.ExportTraceServiceRequest{}
+			Expect(proto.Unmarshal(body, request)).To(Succeed())
+			requests <- request
+		}))
+
+		exporter, err := tracing.NewOTLPExporter(ctx, server.URL+"/otel/")
+		Expect(err).NotTo(HaveOccurred())
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	})
+
+	AfterEach(func() {
+		Expect(provider.Shutdown(ctx)).To(Succeed())
+		server.Close()
+	})
+
+	It("exports the spans with their parent, attributes and status", func() {
+		tracer := provider.Tracer("test")
+		parentCtx, parent := tracer.Start(ctx, "NetworkTopologyReconciler.Reconcile")
+		_, child := tracer.Start(parentCtx, "EC2.CreateTransitGateway")
+		child.SetAttributes(tracing.AWSAccount.String("123456789012"), tracing.AWSAttempts.Int(3))
+		tracing.End(child, errors.New("throttled"))
+		parent.End()
+
+		var request *coltracepb.ExportTraceServiceRequest
+		Eventually(requests).Should(Receive(&request))
+
+		spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+		Expect(spans).To(HaveLen(1))
+
+		span := spans[0]
+		parentTraceID := parent.SpanContext().TraceID()
+		parentSpanID := parent.SpanContext().SpanID()
+		Expect(span.Name).To(Equal("EC2.CreateTransitGateway"))
+		Expect(span.TraceId).To(Equal(parentTraceID[:]))
+		Expect(span.ParentSpanId).To(Equal(parentSpanID[:]))
+		Expect(span.Attributes).To(ContainElements(
+			HaveField("Key", "aws.account"),
+			HaveField("Key", "aws.attempts"),
+		))
+		for _, attribute := range span.Attributes {
+			switch attribute.Key {
+			case "aws.account":
+				Expect(attribute.Value.Value).To(Equal(&commonpb.AnyValue_StringValue{StringValue: "123456789012"}))
+			case "aws.attempts":
+				Expect(attribute.Value.Value).To(Equal(&commonpb.AnyValue_IntValue{IntValue: 3}))
+			}
+		}
+		Expect(span.Status.Code).To(Equal(tracepb.Status_STATUS_CODE_ERROR))
+		Expect(span.Status.Message).To(Equal("throttled"))
+	})
+
+	It("rejects endpoints that aren't URLs", func() {
+		_, err := tracing.NewOTLPExporter(ctx, "otel-collector:4318")
+		Expect(err).To(HaveOccurred())
+	})
+})
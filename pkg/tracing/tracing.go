@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "aws-network-topology-operator"
+	tracerName  = "github.com/giantswarm/aws-network-topology-operator"
+)
+
+// Attributes set on the spans of the operator.
+const (
+	ClusterName      = attribute.Key("cluster.name")
+	ClusterNamespace = attribute.Key("cluster.namespace")
+	AWSAccount       = attribute.Key("aws.account")
+	AWSService       = attribute.Key("aws.service")
+	AWSOperation     = attribute.Key("aws.operation")
+	AWSRequestID     = attribute.Key("aws.request_id")
+	AWSAttempts      = attribute.Key("aws.attempts")
+)
+
+// Options configures the export of traces.
+type Options struct {
+	// Endpoint is the URL of the OTLP/HTTP receiver, e.g. http://otel-collector:4318.
+	// Tracing is disabled if it is empty.
+	Endpoint string
+	// SamplingRatio is the ratio of root spans that are sampled.
+	SamplingRatio float64
+}
+
+// Setup installs the global tracer provider exporting spans to the OTLP endpoint. The
+// returned function flushes and stops the export of spans.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	if options.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := NewOTLPExporter(ctx, options.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SamplingRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the operator.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// End records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
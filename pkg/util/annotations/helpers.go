@@ -21,26 +21,18 @@ func IsNetworkTopologyModeNone(o metav1.Object) bool {
 	return GetAnnotation(o, gsannotation.NetworkTopologyModeAnnotation) == gsannotation.NetworkTopologyModeNone
 }
 
+// GetNetworkTopologyTransitGateway returns the transit gateway annotation. The annotation
+// is deprecated and only imported into the NetworkTopology, use its spec instead.
 func GetNetworkTopologyTransitGateway(o metav1.Object) string {
 	return GetAnnotation(o, gsannotation.NetworkTopologyTransitGatewayIDAnnotation)
 }
 
+// GetNetworkTopologyPrefixList returns the prefix list annotation. The annotation
+// is deprecated and only imported into the NetworkTopology, use its spec instead.
 func GetNetworkTopologyPrefixList(o metav1.Object) string {
 	return GetAnnotation(o, gsannotation.NetworkTopologyPrefixListIDAnnotation)
 }
 
-func SetNetworkTopologyTransitGateway(o metav1.Object, transitGatewayID string) {
-	AddAnnotations(o, map[string]string{
-		gsannotation.NetworkTopologyTransitGatewayIDAnnotation: transitGatewayID,
-	})
-}
-
-func SetNetworkTopologyPrefixList(o metav1.Object, prefixList string) {
-	AddAnnotations(o, map[string]string{
-		gsannotation.NetworkTopologyPrefixListIDAnnotation: prefixList,
-	})
-}
-
 // GetAnnotation returns the value of the specified annotation.
 func GetAnnotation(o metav1.Object, annotation string) string {
 	annotations := o.GetAnnotations()
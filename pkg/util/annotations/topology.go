@@ -0,0 +1,91 @@
+package annotations
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+)
+
+// NetworkTopologyRouteTableGroupAnnotation selects the transit gateway route table group
+// of a cluster. It may also be set as a label.
+const NetworkTopologyRouteTableGroupAnnotation = "network-topology.giantswarm.io/route-table-group"
+
+// NetworkTopologyIPv6PrefixListAnnotation contains the ID or ARN of the IPv6 prefix list
+// of a dual-stack cluster. It is only imported into the NetworkTopology.
+const NetworkTopologyIPv6PrefixListAnnotation = "network-topology.giantswarm.io/ipv6-prefix-list"
+
+// GetNetworkTopologyIPv6PrefixList returns the IPv6 prefix list annotation. The annotation
+// is deprecated and only imported into the NetworkTopology, use its spec instead.
+func GetNetworkTopologyIPv6PrefixList(o metav1.Object) string {
+	return GetAnnotation(o, NetworkTopologyIPv6PrefixListAnnotation)
+}
+
+// GetNetworkTopologyRouteTableGroup returns the route table group of the object,
+// preferring the annotation over the label.
+func GetNetworkTopologyRouteTableGroup(o metav1.Object) string {
+	if group := GetAnnotation(o, NetworkTopologyRouteTableGroupAnnotation); group != "" {
+		return group
+	}
+	return o.GetLabels()[NetworkTopologyRouteTableGroupAnnotation]
+}
+
+// ImportNetworkTopology fills the unset fields of the NetworkTopology spec from the
+// deprecated network-topology annotations of the given object. Fields already set on the
+// NetworkTopology always take precedence over the annotations.
+// It returns true if the spec was changed.
+func ImportNetworkTopology(o metav1.Object, topology *v1alpha1.NetworkTopology) bool {
+	changed := false
+
+	if topology.Spec.Mode == "" && HasNetworkTopologyMode(o) {
+		if mode := GetAnnotation(o, gsannotation.NetworkTopologyModeAnnotation); mode != "" {
+			topology.Spec.Mode = v1alpha1.NetworkTopologyMode(mode)
+			changed = true
+		}
+	}
+
+	if topology.Spec.TransitGateway.IsZero() {
+		if ref := resourceReference(GetNetworkTopologyTransitGateway(o)); ref != nil {
+			topology.Spec.TransitGateway = ref
+			changed = true
+		}
+	}
+
+	if topology.Spec.PrefixList.IsZero() {
+		if ref := resourceReference(GetNetworkTopologyPrefixList(o)); ref != nil {
+			topology.Spec.PrefixList = ref
+			changed = true
+		}
+	}
+
+	if topology.Spec.IPv6PrefixList.IsZero() {
+		if ref := resourceReference(GetNetworkTopologyIPv6PrefixList(o)); ref != nil {
+			topology.Spec.IPv6PrefixList = ref
+			changed = true
+		}
+	}
+
+	if topology.Spec.RouteTableGroup == "" {
+		if group := GetNetworkTopologyRouteTableGroup(o); group != "" {
+			topology.Spec.RouteTableGroup = group
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// resourceReference converts an annotation value, which for migration purposes
+// may either contain an ARN or an ID, to a resource reference.
+func resourceReference(value string) *v1alpha1.AWSResourceReference {
+	if value == "" {
+		return nil
+	}
+
+	if _, err := arn.Parse(value); err == nil {
+		return &v1alpha1.AWSResourceReference{ARN: value}
+	}
+
+	return &v1alpha1.AWSResourceReference{ID: value}
+}
@@ -0,0 +1,204 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/giantswarm/microerror"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
+)
+
+var (
+	transitGatewayIDPattern = regexp.MustCompile(`^tgw-[0-9a-f]+$`)
+	prefixListIDPattern     = regexp.MustCompile(`^pl-[0-9a-f]+$`)
+
+	annotationsPath = field.NewPath("metadata", "annotations")
+)
+
+//+kubebuilder:webhook:path=/validate-cluster-x-k8s-io-v1beta1-cluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=cluster.x-k8s.io,resources=clusters,verbs=create;update,versions=v1beta1,name=vcluster.network-topology.giantswarm.io,admissionReviewVersions=v1
+
+// ClusterValidator validates the network-topology annotations of Cluster
+// resources so that typos are rejected when they are applied rather than
+// surfacing as reconcile errors. Mode changes of clusters with a
+// NetworkTopology are validated on the NetworkTopology, see
+// NetworkTopologyValidator.
+type ClusterValidator struct {
+	client client.Reader
+}
+
+var _ admission.CustomValidator = &ClusterValidator{}
+
+func NewClusterValidator(client client.Reader) *ClusterValidator {
+	return &ClusterValidator{
+		client: client,
+	}
+}
+
+func (v *ClusterValidator) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&capi.Cluster{}).
+		WithValidator(v).
+		Complete()
+	return microerror.Mask(err)
+}
+
+func (v *ClusterValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	cluster, ok := obj.(*capi.Cluster)
+	if !ok {
+		return k8serrors.NewBadRequest(fmt.Sprintf("expected a Cluster but got a %T", obj))
+	}
+
+	return toInvalidError(cluster, validateAnnotations(nil, cluster))
+}
+
+func (v *ClusterValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldCluster, ok := oldObj.(*capi.Cluster)
+	if !ok {
+		return k8serrors.NewBadRequest(fmt.Sprintf("expected a Cluster but got a %T", oldObj))
+	}
+	cluster, ok := newObj.(*capi.Cluster)
+	if !ok {
+		return k8serrors.NewBadRequest(fmt.Sprintf("expected a Cluster but got a %T", newObj))
+	}
+
+	// Clusters being deleted must not be blocked, e.g. when their finalizers
+	// are removed
+	if !cluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	allErrs := validateAnnotations(oldCluster, cluster)
+
+	modeErr, err := v.validateModeChange(ctx, oldCluster, cluster)
+	if err != nil {
+		return k8serrors.NewInternalError(err)
+	}
+	if modeErr != nil {
+		allErrs = append(allErrs, modeErr)
+	}
+
+	return toInvalidError(cluster, allErrs)
+}
+
+func (v *ClusterValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// validateAnnotations validates the network-topology annotations of the
+// cluster. On updates only annotations whose values changed are validated, so
+// values accepted before, e.g. by an older version of the webhook, don't block
+// unrelated changes.
+func validateAnnotations(oldCluster, cluster *capi.Cluster) field.ErrorList {
+	var allErrs field.ErrorList
+
+	changed := func(annotation string) bool {
+		return oldCluster == nil || annotations.GetAnnotation(oldCluster, annotation) != annotations.GetAnnotation(cluster, annotation)
+	}
+
+	if annotations.HasNetworkTopologyMode(cluster) && changed(gsannotation.NetworkTopologyModeAnnotation) {
+		mode := annotations.GetAnnotation(cluster, gsannotation.NetworkTopologyModeAnnotation)
+		switch mode {
+		case gsannotation.NetworkTopologyModeNone,
+			gsannotation.NetworkTopologyModeUserManaged,
+			gsannotation.NetworkTopologyModeGiantSwarmManaged:
+		default:
+			allErrs = append(allErrs, field.NotSupported(
+				annotationsPath.Key(gsannotation.NetworkTopologyModeAnnotation),
+				mode,
+				[]string{
+					gsannotation.NetworkTopologyModeNone,
+					gsannotation.NetworkTopologyModeUserManaged,
+					gsannotation.NetworkTopologyModeGiantSwarmManaged,
+				},
+			))
+		}
+	}
+
+	if value := annotations.GetNetworkTopologyTransitGateway(cluster); value != "" && changed(gsannotation.NetworkTopologyTransitGatewayIDAnnotation) {
+		if err := validateResource(value, transitGatewayIDPattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(gsannotation.NetworkTopologyTransitGatewayIDAnnotation), value, err.Error()))
+		}
+	}
+
+	if value := annotations.GetNetworkTopologyPrefixList(cluster); value != "" && changed(gsannotation.NetworkTopologyPrefixListIDAnnotation) {
+		if err := validateResource(value, prefixListIDPattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(gsannotation.NetworkTopologyPrefixListIDAnnotation), value, err.Error()))
+		}
+	}
+
+	if value := annotations.GetNetworkTopologyIPv6PrefixList(cluster); value != "" && changed(annotations.NetworkTopologyIPv6PrefixListAnnotation) {
+		if err := validateResource(value, prefixListIDPattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(annotations.NetworkTopologyIPv6PrefixListAnnotation), value, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateModeChange rejects changes of the mode annotation of clusters that
+// already have a NetworkTopology. The annotation is only imported when the
+// NetworkTopology is created, so the change would be ignored otherwise.
+func (v *ClusterValidator) validateModeChange(ctx context.Context, oldCluster, cluster *capi.Cluster) (*field.Error, error) {
+	mode := annotations.GetAnnotation(cluster, gsannotation.NetworkTopologyModeAnnotation)
+	if mode == "" || mode == annotations.GetAnnotation(oldCluster, gsannotation.NetworkTopologyModeAnnotation) {
+		return nil, nil
+	}
+
+	topology := &v1alpha1.NetworkTopology{}
+	err := v.client.Get(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, topology)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if topology.Spec.Mode == "" || string(topology.Spec.Mode) == mode {
+		return nil, nil
+	}
+
+	return field.Forbidden(
+		annotationsPath.Key(gsannotation.NetworkTopologyModeAnnotation),
+		fmt.Sprintf("the mode of the cluster is %q, change spec.mode of the NetworkTopology %s/%s instead", topology.Spec.Mode, topology.Namespace, topology.Name),
+	), nil
+}
+
+// validateResource accepts either an ARN or a plain resource ID, the same way
+// the registrar resolves the annotation values.
+func validateResource(value string, idPattern *regexp.Regexp) error {
+	id := value
+	if arn.IsARN(value) {
+		var err error
+		id, err = aws.GetARNResourceID(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("resource id %q does not match %q", id, idPattern.String())
+	}
+
+	return nil
+}
+
+func toInvalidError(cluster *capi.Cluster, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return k8serrors.NewInvalid(capi.GroupVersion.WithKind("Cluster").GroupKind(), cluster.Name, allErrs)
+}
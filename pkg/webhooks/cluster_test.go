@@ -0,0 +1,209 @@
+package webhooks_test
+
+import (
+	"context"
+
+	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/webhooks"
+)
+
+var _ = Describe("ClusterValidator", func() {
+	var (
+		ctx context.Context
+
+		transitGatewayARN = "arn:aws:ec2:eu-west-2:123456789012:transit-gateway/tgw-01234567890abcdef"
+		prefixListARN     = "arn:aws:ec2:eu-west-2:123456789012:prefix-list/pl-01234567890abcdef"
+
+		cluster   *capi.Cluster
+		k8sClient client.Client
+		validator *webhooks.ClusterValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(capi.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		cluster = &capi.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test",
+				Annotations: map[string]string{
+					gsannotation.NetworkTopologyModeAnnotation:             gsannotation.NetworkTopologyModeGiantSwarmManaged,
+					gsannotation.NetworkTopologyTransitGatewayIDAnnotation: transitGatewayARN,
+					gsannotation.NetworkTopologyPrefixListIDAnnotation:     prefixListARN,
+				},
+			},
+		}
+
+		validator = webhooks.NewClusterValidator(k8sClient)
+	})
+
+	Describe("ValidateCreate", func() {
+		It("accepts valid annotations", func() {
+			Expect(validator.ValidateCreate(ctx, cluster)).To(Succeed())
+		})
+
+		It("accepts resource IDs", func() {
+			cluster.Annotations[gsannotation.NetworkTopologyTransitGatewayIDAnnotation] = "tgw-01234567890abcdef"
+			cluster.Annotations[gsannotation.NetworkTopologyPrefixListIDAnnotation] = "pl-01234567890abcdef"
+
+			Expect(validator.ValidateCreate(ctx, cluster)).To(Succeed())
+		})
+
+		It("accepts clusters without annotations", func() {
+			cluster.Annotations = nil
+
+			Expect(validator.ValidateCreate(ctx, cluster)).To(Succeed())
+		})
+
+		It("rejects unknown modes", func() {
+			cluster.Annotations[gsannotation.NetworkTopologyModeAnnotation] = "GiantswarmManaged"
+
+			err := validator.ValidateCreate(ctx, cluster)
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(gsannotation.NetworkTopologyModeAnnotation))
+		})
+
+		DescribeTable("rejects malformed transit gateways",
+			func(value string) {
+				cluster.Annotations[gsannotation.NetworkTopologyTransitGatewayIDAnnotation] = value
+
+				err := validator.ValidateCreate(ctx, cluster)
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(gsannotation.NetworkTopologyTransitGatewayIDAnnotation))
+			},
+			Entry("invalid id", "tgw-xyz"),
+			Entry("prefix list id", "pl-01234567890abcdef"),
+			Entry("arn without resource id", "arn:aws:ec2:eu-west-2:123456789012:transit-gateway"),
+			Entry("arn of another resource", prefixListARN),
+			Entry("incomplete arn", "arn:aws:ec2"),
+		)
+
+		DescribeTable("rejects malformed prefix lists",
+			func(value string) {
+				cluster.Annotations[gsannotation.NetworkTopologyPrefixListIDAnnotation] = value
+
+				err := validator.ValidateCreate(ctx, cluster)
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(gsannotation.NetworkTopologyPrefixListIDAnnotation))
+			},
+			Entry("invalid id", "prefix-list"),
+			Entry("arn without resource id", "arn:aws:ec2:eu-west-2:123456789012:prefix-list/"),
+			Entry("arn of another resource", transitGatewayARN),
+		)
+
+		It("accepts an IPv6 prefix list", func() {
+			cluster.Annotations[annotations.NetworkTopologyIPv6PrefixListAnnotation] = prefixListARN
+			Expect(validator.ValidateCreate(ctx, cluster)).To(Succeed())
+		})
+
+		It("rejects a malformed IPv6 prefix list", func() {
+			cluster.Annotations[annotations.NetworkTopologyIPv6PrefixListAnnotation] = transitGatewayARN
+
+			err := validator.ValidateCreate(ctx, cluster)
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(annotations.NetworkTopologyIPv6PrefixListAnnotation))
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var oldCluster *capi.Cluster
+
+		BeforeEach(func() {
+			oldCluster = cluster.DeepCopy()
+		})
+
+		It("allows mode changes of clusters without a NetworkTopology", func() {
+			cluster.Annotations[gsannotation.NetworkTopologyModeAnnotation] = gsannotation.NetworkTopologyModeUserManaged
+
+			Expect(validator.ValidateUpdate(ctx, oldCluster, cluster)).To(Succeed())
+		})
+
+		When("the cluster has a NetworkTopology and is attached to a transit gateway", func() {
+			BeforeEach(func() {
+				topology := &v1alpha1.NetworkTopology{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      cluster.Name,
+						Namespace: cluster.Namespace,
+					},
+					Spec: v1alpha1.NetworkTopologySpec{
+						Mode: v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+					},
+					Status: v1alpha1.NetworkTopologyStatus{
+						TransitGatewayAttachment: &v1alpha1.TransitGatewayAttachmentStatus{
+							ID:    "tgw-attach-01234567890abcdef",
+							State: "available",
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, topology)).To(Succeed())
+			})
+
+			It("rejects changing the mode from GiantSwarmManaged to UserManaged through the annotation", func() {
+				cluster.Annotations[gsannotation.NetworkTopologyModeAnnotation] = gsannotation.NetworkTopologyModeUserManaged
+
+				err := validator.ValidateUpdate(ctx, oldCluster, cluster)
+				Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("spec.mode"))
+			})
+
+			It("allows setting the annotation to the mode of the NetworkTopology", func() {
+				delete(oldCluster.Annotations, gsannotation.NetworkTopologyModeAnnotation)
+
+				Expect(validator.ValidateUpdate(ctx, oldCluster, cluster)).To(Succeed())
+			})
+
+			It("allows removing the annotation", func() {
+				delete(cluster.Annotations, gsannotation.NetworkTopologyModeAnnotation)
+
+				Expect(validator.ValidateUpdate(ctx, oldCluster, cluster)).To(Succeed())
+			})
+		})
+
+		It("rejects changed annotations that are malformed", func() {
+			cluster.Annotations[gsannotation.NetworkTopologyPrefixListIDAnnotation] = "prefix-list"
+
+			err := validator.ValidateUpdate(ctx, oldCluster, cluster)
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(gsannotation.NetworkTopologyPrefixListIDAnnotation))
+		})
+
+		When("the cluster has malformed annotations already", func() {
+			BeforeEach(func() {
+				oldCluster.Annotations[gsannotation.NetworkTopologyModeAnnotation] = "GiantswarmManaged"
+				oldCluster.Annotations[gsannotation.NetworkTopologyTransitGatewayIDAnnotation] = "tgw-xyz"
+				cluster = oldCluster.DeepCopy()
+			})
+
+			It("allows changes of other fields", func() {
+				cluster.Labels = map[string]string{"foo": "bar"}
+				cluster.Annotations[gsannotation.NetworkTopologyPrefixListIDAnnotation] = "pl-01234567890abcdef"
+
+				Expect(validator.ValidateUpdate(ctx, oldCluster, cluster)).To(Succeed())
+			})
+
+			It("allows updates of clusters being deleted", func() {
+				now := metav1.Now()
+				cluster.DeletionTimestamp = &now
+				cluster.Annotations[gsannotation.NetworkTopologyPrefixListIDAnnotation] = "prefix-list"
+
+				Expect(validator.ValidateUpdate(ctx, oldCluster, cluster)).To(Succeed())
+			})
+		})
+	})
+})
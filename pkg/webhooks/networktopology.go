@@ -0,0 +1,77 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/validate-network-topology-giantswarm-io-v1alpha1-networktopology,mutating=false,failurePolicy=fail,sideEffects=None,groups=network-topology.giantswarm.io,resources=networktopologies,verbs=update,versions=v1alpha1,name=vnetworktopology.network-topology.giantswarm.io,admissionReviewVersions=v1
+
+// NetworkTopologyValidator rejects changes of spec.mode while the cluster is
+// attached to a transit gateway, as the attachment of the old mode would be
+// orphaned.
+type NetworkTopologyValidator struct{}
+
+var _ admission.CustomValidator = &NetworkTopologyValidator{}
+
+func NewNetworkTopologyValidator() *NetworkTopologyValidator {
+	return &NetworkTopologyValidator{}
+}
+
+func (v *NetworkTopologyValidator) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.NetworkTopology{}).
+		WithValidator(v).
+		Complete()
+	return microerror.Mask(err)
+}
+
+func (v *NetworkTopologyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *NetworkTopologyValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldTopology, ok := oldObj.(*v1alpha1.NetworkTopology)
+	if !ok {
+		return k8serrors.NewBadRequest(fmt.Sprintf("expected a NetworkTopology but got a %T", oldObj))
+	}
+	topology, ok := newObj.(*v1alpha1.NetworkTopology)
+	if !ok {
+		return k8serrors.NewBadRequest(fmt.Sprintf("expected a NetworkTopology but got a %T", newObj))
+	}
+
+	if !topology.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	// An unset mode is only recorded by the controller, it doesn't change
+	// what the cluster is attached to
+	if oldTopology.Spec.Mode == "" || oldTopology.Spec.Mode == topology.Spec.Mode || !isAttached(oldTopology) {
+		return nil
+	}
+
+	allErrs := field.ErrorList{
+		field.Forbidden(
+			field.NewPath("spec", "mode"),
+			fmt.Sprintf("mode can not be changed from %q to %q while the cluster is attached to a transit gateway", oldTopology.Spec.Mode, topology.Spec.Mode),
+		),
+	}
+	return k8serrors.NewInvalid(v1alpha1.GroupVersion.WithKind("NetworkTopology").GroupKind(), topology.Name, allErrs)
+}
+
+func (v *NetworkTopologyValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func isAttached(topology *v1alpha1.NetworkTopology) bool {
+	return topology.Status.TransitGatewayAttachment != nil && topology.Status.TransitGatewayAttachment.ID != ""
+}
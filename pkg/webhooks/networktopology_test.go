@@ -0,0 +1,81 @@
+package webhooks_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/webhooks"
+)
+
+var _ = Describe("NetworkTopologyValidator", func() {
+	var (
+		ctx context.Context
+
+		oldTopology *v1alpha1.NetworkTopology
+		topology    *v1alpha1.NetworkTopology
+		validator   *webhooks.NetworkTopologyValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		oldTopology = &v1alpha1.NetworkTopology{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test",
+			},
+			Spec: v1alpha1.NetworkTopologySpec{
+				Mode: v1alpha1.NetworkTopologyModeGiantSwarmManaged,
+			},
+		}
+		topology = oldTopology.DeepCopy()
+		topology.Spec.Mode = v1alpha1.NetworkTopologyModeUserManaged
+
+		validator = webhooks.NewNetworkTopologyValidator()
+	})
+
+	It("allows mode changes when the cluster is not attached", func() {
+		Expect(validator.ValidateUpdate(ctx, oldTopology, topology)).To(Succeed())
+	})
+
+	When("the cluster is attached to a transit gateway", func() {
+		BeforeEach(func() {
+			oldTopology.Status.TransitGatewayAttachment = &v1alpha1.TransitGatewayAttachmentStatus{
+				ID:    "tgw-attach-01234567890abcdef",
+				State: "available",
+			}
+			topology.Status = *oldTopology.Status.DeepCopy()
+		})
+
+		It("rejects mode changes", func() {
+			err := validator.ValidateUpdate(ctx, oldTopology, topology)
+			Expect(k8serrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.mode"))
+		})
+
+		It("allows setting the mode for the first time", func() {
+			oldTopology.Spec.Mode = ""
+
+			Expect(validator.ValidateUpdate(ctx, oldTopology, topology)).To(Succeed())
+		})
+
+		It("allows other changes", func() {
+			topology.Spec.Mode = oldTopology.Spec.Mode
+			topology.Labels = map[string]string{"foo": "bar"}
+
+			Expect(validator.ValidateUpdate(ctx, oldTopology, topology)).To(Succeed())
+		})
+
+		It("allows mode changes of topologies being deleted", func() {
+			now := metav1.Now()
+			topology.DeletionTimestamp = &now
+
+			Expect(validator.ValidateUpdate(ctx, oldTopology, topology)).To(Succeed())
+		})
+	})
+})
@@ -10,13 +10,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
-	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 
-	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
-
-	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
 	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
-	"github.com/giantswarm/aws-network-topology-operator/pkg/util/annotations"
 )
 
 var _ = Describe("Transit Gateways", func() {
@@ -37,19 +33,18 @@ var _ = Describe("Transit Gateways", func() {
 
 	It("creates the transit gateway", func() {
 		By("creating the transit gateway")
-		actualCluster := &capi.Cluster{}
+		actualTopology := &v1alpha1.NetworkTopology{}
 
-		getClusterAnnotations := func(g Gomega) map[string]string {
+		getTopologySpec := func(g Gomega) v1alpha1.NetworkTopologySpec {
 			nsName := k8sclient.GetNamespacedName(testFixture.ManagementCluster.Cluster)
-			err := k8sClient.Get(ctx, nsName, actualCluster)
+			err := k8sClient.Get(ctx, nsName, actualTopology)
 			g.Expect(err).NotTo(HaveOccurred())
 
-			return actualCluster.Annotations
+			return actualTopology.Spec
 		}
-		Eventually(getClusterAnnotations).Should(HaveKey(gsannotation.NetworkTopologyTransitGatewayIDAnnotation))
+		Eventually(getTopologySpec).Should(HaveField("TransitGateway.ID", Not(BeEmpty())))
 
-		transitGatewayID, err := aws.GetARNResourceID(annotations.GetNetworkTopologyTransitGateway(actualCluster))
-		Expect(err).NotTo(HaveOccurred())
+		transitGatewayID := actualTopology.Spec.TransitGateway.ID
 
 		output, err := testFixture.EC2Client.DescribeTransitGateways(&ec2.DescribeTransitGatewaysInput{
 			TransitGatewayIds: []*string{awssdk.String(transitGatewayID)},
@@ -80,10 +75,9 @@ var _ = Describe("Transit Gateways", func() {
 		}))))
 
 		By("creating the prefix list")
-		Eventually(getClusterAnnotations).Should(HaveKey(gsannotation.NetworkTopologyPrefixListIDAnnotation))
+		Eventually(getTopologySpec).Should(HaveField("PrefixList.ID", Not(BeEmpty())))
 
-		prefixListID, err := aws.GetARNResourceID(annotations.GetNetworkTopologyPrefixList(actualCluster))
-		Expect(err).NotTo(HaveOccurred())
+		prefixListID := actualTopology.Spec.PrefixList.ID
 
 		result, err := testFixture.EC2Client.GetManagedPrefixListEntries(&ec2.GetManagedPrefixListEntriesInput{
 			PrefixListId: awssdk.String(prefixListID),
@@ -92,7 +86,7 @@ var _ = Describe("Transit Gateways", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		managementAWSCluster := testFixture.ManagementCluster.AWSCluster
-		prefixListDescription := fmt.Sprintf("CIDR block for cluster %s", managementAWSCluster.Name)
+		prefixListDescription := fmt.Sprintf("CIDR block for cluster %s/%s in %s", managementAWSCluster.Namespace, managementAWSCluster.Name, managementAWSCluster.Spec.NetworkSpec.VPC.ID)
 		Expect(result.Entries).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
 			"Cidr":        PointTo(Equal(managementAWSCluster.Spec.NetworkSpec.VPC.CidrBlock)),
 			"Description": PointTo(Equal(prefixListDescription)),
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/onsi/ginkgo/v2"
@@ -34,6 +36,18 @@ func GetEnvOrSkip(env string) string {
 	return value
 }
 
+// ModuleDir returns the directory of the module with the version required by
+// go.mod, e.g. to load the CRDs of dependencies. It works with any GOPATH,
+// GOMODCACHE and vendored or replaced modules.
+func ModuleDir(module string) string {
+	output, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", module).Output()
+	Expect(err).NotTo(HaveOccurred(), "failed to find the directory of module %s", module)
+
+	dir := strings.TrimSpace(string(output))
+	Expect(dir).NotTo(BeEmpty(), "module %s isn't downloaded, run go mod download", module)
+	return dir
+}
+
 func PatchClusterStatus(k8sClient client.Client, cluster *capi.Cluster, status capi.ClusterStatus) {
 	patchedCluster := cluster.DeepCopy()
 	patchedCluster.Status = status
@@ -0,0 +1,301 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/kubectl/pkg/scheme"
+	capa "sigs.k8s.io/cluster-api-provider-aws/api/v1beta1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/controllers"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/k8sclient"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"github.com/giantswarm/aws-network-topology-operator/tests"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+const (
+	awsRegion = "eu-west-1"
+	mcAccount = "111111111111"
+	wcAccount = "222222222222"
+
+	mcVPCCIDR = "10.0.0.0/16"
+)
+
+var (
+	k8sClient client.Client
+	testEnv   *envtest.Environment
+	server    *fakeaws.Server
+	namespace string
+
+	managementCluster = k8stypes.NamespacedName{
+		Name:      "e2e-mc",
+		Namespace: "giantswarm",
+	}
+	snsTopic = fmt.Sprintf("arn:aws:sns:%s:%s:e2e", awsRegion, mcAccount)
+
+	stopManager context.CancelFunc
+)
+
+func TestE2E(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "E2E Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	tests.GetEnvOrSkip("KUBEBUILDER_ASSETS")
+
+	SetDefaultEventuallyTimeout(30 * time.Second)
+	SetDefaultEventuallyPollingInterval(250 * time.Millisecond)
+
+	By("starting the fake AWS API")
+	server = fakeaws.NewServer(awsRegion)
+
+	// The AWS SDK clients of the operator load the credentials from the
+	// environment, the fake tells the accounts apart by the access key
+	Expect(os.Setenv("AWS_REGION", awsRegion)).To(Succeed())
+	Expect(os.Setenv("AWS_ACCESS_KEY_ID", fakeaws.AccessKey(fakeaws.DefaultAccount))).To(Succeed())
+	Expect(os.Setenv("AWS_SECRET_ACCESS_KEY", "fake-secret-access-key")).To(Succeed())
+	Expect(os.Setenv("AWS_EC2_METADATA_DISABLED", "true")).To(Succeed())
+
+	By("bootstrapping test environment")
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "config", "crd", "bases"),
+			filepath.Join(tests.ModuleDir("sigs.k8s.io/cluster-api"), "config", "crd", "bases"),
+			filepath.Join(tests.ModuleDir("sigs.k8s.io/cluster-api-provider-aws"), "config", "crd", "bases"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	cfg, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
+	err = capa.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = capi.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = v1alpha1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(k8sClient).NotTo(BeNil())
+
+	namespaceObj := &corev1.Namespace{}
+	namespaceObj.Name = managementCluster.Namespace
+	Expect(k8sClient.Create(context.Background(), namespaceObj)).To(Succeed())
+
+	By("creating the management cluster")
+	createCluster(managementCluster, mcAccount, mcVPCCIDR, map[string]string{
+		gsannotation.NetworkTopologyModeAnnotation: gsannotation.NetworkTopologyModeGiantSwarmManaged,
+	})
+
+	By("starting the manager")
+	startManager(cfg)
+})
+
+var _ = AfterSuite(func() {
+	By("tearing down the test environment")
+	if stopManager != nil {
+		stopManager()
+	}
+	if testEnv != nil {
+		Expect(testEnv.Stop()).To(Succeed())
+	}
+	if server != nil {
+		server.Close()
+	}
+})
+
+var _ = BeforeEach(func() {
+	namespace = uuid.New().String()
+	namespaceObj := &corev1.Namespace{}
+	namespaceObj.Name = namespace
+	Expect(k8sClient.Create(context.Background(), namespaceObj)).To(Succeed())
+})
+
+var _ = AfterEach(func() {
+	Expect(server.UnsupportedActions()).To(BeEmpty())
+
+	namespaceObj := &corev1.Namespace{}
+	namespaceObj.Name = namespace
+	Expect(k8sClient.Delete(context.Background(), namespaceObj)).To(Succeed())
+})
+
+// startManager runs the controllers wired up like in main.go against the fake
+// AWS API
+func startManager(cfg *rest.Config) {
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme.Scheme,
+		MetricsBindAddress: "0",
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopManager = cancel
+
+	clusterClient := k8sclient.NewCluster(mgr.GetClient(), managementCluster)
+	recorder := mgr.GetEventRecorderFor("aws-network-topology-operator")
+
+	endpointOptions := aws.EndpointOptions{URL: server.URL()}
+	credentialsFactory := aws.NewCredentialsFactory(mgr.GetAPIReader(), "giantswarm", endpointOptions)
+	clientProvider := aws.NewClientProvider(credentialsFactory, aws.DefaultClusterTTL)
+	ec2Service := aws.NewEC2Client(clientProvider, managementCluster)
+	snsService := aws.NewSNSClient(snsTopic, clientProvider, managementCluster)
+	getTransitGatewayClientForWorkloadCluster := func(workloadCluster k8stypes.NamespacedName) aws.TransitGatewayClient {
+		return aws.NewTGWClient(*aws.NewEC2Client(clientProvider, workloadCluster), *snsService)
+	}
+
+	ramService := aws.NewClusterRAMClient(clientProvider, managementCluster)
+
+	registrars := []controllers.Registrar{
+		registrar.NewTransitGateway(aws.NewTGWClient(*ec2Service, *snsService), clusterClient, getTransitGatewayClientForWorkloadCluster, registrar.TransitGatewayOptions{
+			AttachmentRecreationLimit: registrar.DefaultAttachmentRecreationLimit,
+			ResourceShareClient:       ramService,
+		}, recorder),
+		registrar.NewTransitGatewayPeering(aws.NewTGWClient(*ec2Service, *snsService), clusterClient, v1alpha1.DeletionPolicyDelete),
+	}
+	Expect(controllers.NewNetworkTopologyReconciler(clusterClient, registrars, recorder).SetupWithManager(mgr)).To(Succeed())
+	Expect(controllers.NewIdentityReconciler(clientProvider).SetupWithManager(mgr)).To(Succeed())
+	Expect(controllers.NewShareReconciler(clusterClient, ramService, v1alpha1.DeletionPolicyDelete, mgr.GetEventRecorderFor("share-reconciler")).SetupWithManager(mgr)).To(Succeed())
+
+	go func() {
+		defer GinkgoRecover()
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
+}
+
+func roleARN(account string) string {
+	return fmt.Sprintf("arn:aws:iam::%s:role/e2e", account)
+}
+
+// createCluster creates the Cluster, AWSCluster and AWSClusterRoleIdentity of
+// a cluster in the account and adds its VPC with one private subnet per
+// availability zone to the fake AWS API
+func createCluster(name k8stypes.NamespacedName, account, cidr string, annotations map[string]string) *capi.Cluster {
+	ctx := context.Background()
+	vpcID := fmt.Sprintf("vpc-%s", name.Name)
+
+	server.AddVPC(account, types.Vpc{
+		VpcId:     awsv1.String(vpcID),
+		CidrBlock: awsv1.String(cidr),
+		CidrBlockAssociationSet: []types.VpcCidrBlockAssociation{
+			{
+				CidrBlock:      awsv1.String(cidr),
+				CidrBlockState: &types.VpcCidrBlockState{State: types.VpcCidrBlockStateCodeAssociated},
+			},
+		},
+	})
+	for _, zone := range []string{"a", "b"} {
+		server.AddSubnet(account, types.Subnet{
+			SubnetId:         awsv1.String(fmt.Sprintf("subnet-%s-%s", name.Name, zone)),
+			VpcId:            awsv1.String(vpcID),
+			AvailabilityZone: awsv1.String(awsRegion + zone),
+			Tags: []types.Tag{
+				{Key: awsv1.String(capa.NameKubernetesAWSCloudProviderPrefix + name.Name), Value: awsv1.String("owned")},
+				{Key: awsv1.String(registrar.SubnetTGWAttachementsLabel), Value: awsv1.String("true")},
+				{Key: awsv1.String(registrar.SubnetRoleLabel), Value: awsv1.String("private")},
+			},
+		})
+	}
+
+	identity := &capa.AWSClusterRoleIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%s", name.Namespace, name.Name),
+		},
+		Spec: capa.AWSClusterRoleIdentitySpec{
+			AWSRoleSpec: capa.AWSRoleSpec{
+				RoleArn: roleARN(account),
+			},
+			SourceIdentityRef: &capa.AWSIdentityReference{
+				Name: "default",
+				Kind: capa.ControllerIdentityKind,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, identity)).To(Succeed())
+
+	awsCluster := &capa.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: capa.AWSClusterSpec{
+			Region: awsRegion,
+			IdentityRef: &capa.AWSIdentityReference{
+				Name: identity.Name,
+				Kind: capa.ClusterRoleIdentityKind,
+			},
+			NetworkSpec: capa.NetworkSpec{
+				VPC: capa.VPCSpec{
+					ID:        vpcID,
+					CidrBlock: cidr,
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, awsCluster)).To(Succeed())
+	tests.PatchAWSClusterStatus(k8sClient, awsCluster, capa.AWSClusterStatus{
+		Ready: true,
+	})
+
+	cluster := &capi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name.Name,
+			Namespace:   name.Namespace,
+			Annotations: annotations,
+		},
+		Spec: capi.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				APIVersion: capa.GroupVersion.String(),
+				Kind:       "AWSCluster",
+				Namespace:  name.Namespace,
+				Name:       name.Name,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+	tests.PatchClusterStatus(k8sClient, cluster, capi.ClusterStatus{
+		InfrastructureReady: true,
+	})
+
+	return cluster
+}
+
+// getNetworkTopology returns a function polling the NetworkTopology of the
+// cluster
+func getNetworkTopology(name k8stypes.NamespacedName) func(g Gomega) *v1alpha1.NetworkTopology {
+	return func(g Gomega) *v1alpha1.NetworkTopology {
+		topology := &v1alpha1.NetworkTopology{}
+		g.Expect(k8sClient.Get(context.Background(), name, topology)).To(Succeed())
+		return topology
+	}
+}
@@ -0,0 +1,188 @@
+package e2e_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	credentialsv1 "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ram"
+	gsannotation "github.com/giantswarm/k8smetadata/pkg/annotation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/giantswarm/aws-network-topology-operator/api/v1alpha1"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/pkg/registrar"
+	"github.com/giantswarm/aws-network-topology-operator/tests"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+var _ = Describe("Network topology", func() {
+	var (
+		ctx context.Context
+
+		mcTopology *v1alpha1.NetworkTopology
+	)
+
+	getAttachment := func(attachmentID string) func() *types.TransitGatewayVpcAttachment {
+		return func() *types.TransitGatewayVpcAttachment {
+			for _, attachment := range server.TransitGatewayVpcAttachments() {
+				if *attachment.TransitGatewayAttachmentId == attachmentID {
+					return &attachment
+				}
+			}
+			return nil
+		}
+	}
+
+	getPrefixListCIDRs := func() []string {
+		cidrs := []string{}
+		for _, entry := range server.PrefixListEntries(mcTopology.Spec.PrefixList.ID) {
+			cidrs = append(cidrs, *entry.Cidr)
+		}
+		return cidrs
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		Eventually(func(g Gomega) {
+			mcTopology = getNetworkTopology(managementCluster)(g)
+			g.Expect(mcTopology.Spec.TransitGateway).NotTo(BeNil())
+			g.Expect(mcTopology.Spec.PrefixList).NotTo(BeNil())
+		}).Should(Succeed())
+	})
+
+	Describe("the management cluster in GiantSwarmManaged mode", func() {
+		It("creates the transit gateway and prefix list", func() {
+			Expect(server.TransitGateways()).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"TransitGatewayId": PointTo(Equal(mcTopology.Spec.TransitGateway.ID)),
+				"OwnerId":          PointTo(Equal(mcAccount)),
+			})))
+
+			Eventually(getNetworkTopology(managementCluster)).Should(HaveField("Status.TransitGatewayAttachment", PointTo(MatchFields(IgnoreExtras, Fields{
+				"State":        Equal(string(types.TransitGatewayAttachmentStateAvailable)),
+				"RouteTableID": Not(BeEmpty()),
+			}))))
+			Eventually(getPrefixListCIDRs).Should(ContainElement(mcVPCCIDR))
+		})
+	})
+
+	Describe("a workload cluster in GiantSwarmManaged mode", func() {
+		var (
+			name    k8stypes.NamespacedName
+			cluster *capi.Cluster
+		)
+
+		BeforeEach(func() {
+			name = k8stypes.NamespacedName{Name: tests.GenerateGUID("wc"), Namespace: namespace}
+			cluster = createCluster(name, wcAccount, "10.10.0.0/16", map[string]string{
+				gsannotation.NetworkTopologyModeAnnotation: gsannotation.NetworkTopologyModeGiantSwarmManaged,
+			})
+		})
+
+		It("attaches the cluster through the transit gateway shared with its account", func() {
+			Eventually(func() []fakeaws.ResourceShare {
+				return server.ResourceShares()
+			}).Should(ContainElements(
+				MatchFields(IgnoreExtras, Fields{
+					"Name":         Equal(name.Name + "-transit-gateway"),
+					"Principals":   ConsistOf(wcAccount),
+					"ResourceArns": ConsistOf(mcTopology.Spec.TransitGateway.ARN),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name":         Equal(name.Name + "-prefix-list"),
+					"Principals":   ConsistOf(wcAccount),
+					"ResourceArns": ConsistOf(mcTopology.Spec.PrefixList.ARN),
+				}),
+			))
+
+			Eventually(getNetworkTopology(name)).Should(HaveField("Status.TransitGatewayAttachment.State", string(types.TransitGatewayAttachmentStateAvailable)))
+			topology := getNetworkTopology(name)(Default)
+			attachment := getAttachment(topology.Status.TransitGatewayAttachment.ID)()
+			Expect(attachment).NotTo(BeNil())
+			Expect(attachment.VpcOwnerId).To(PointTo(Equal(wcAccount)))
+			Expect(attachment.SubnetIds).To(ConsistOf("subnet-"+name.Name+"-a", "subnet-"+name.Name+"-b"))
+			Expect(server.RouteTableAssociation(*attachment.TransitGatewayAttachmentId)).To(Equal(topology.Status.TransitGatewayAttachment.RouteTableID))
+
+			Eventually(getPrefixListCIDRs).Should(ContainElement("10.10.0.0/16"))
+		})
+
+		It("cleans up when the cluster is deleted", func() {
+			Eventually(getNetworkTopology(name)).Should(HaveField("Status.TransitGatewayAttachment.State", string(types.TransitGatewayAttachmentStateAvailable)))
+			Eventually(getPrefixListCIDRs).Should(ContainElement("10.10.0.0/16"))
+			attachmentID := getNetworkTopology(name)(Default).Status.TransitGatewayAttachment.ID
+
+			Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, name, &capi.Cluster{})
+				return k8serrors.IsNotFound(err)
+			}).Should(BeTrue())
+			Expect(getAttachment(attachmentID)()).To(BeNil())
+			Expect(getPrefixListCIDRs()).NotTo(ContainElement("10.10.0.0/16"))
+			Expect(server.ResourceShares()).NotTo(ContainElement(HaveField("Name", HavePrefix(name.Name))))
+		})
+	})
+
+	Describe("a workload cluster in UserManaged mode", func() {
+		var (
+			name           k8stypes.NamespacedName
+			transitGateway types.TransitGateway
+		)
+
+		BeforeEach(func() {
+			transitGateway = server.AddTransitGateway(mcAccount, &types.TransitGatewayOptions{
+				AutoAcceptSharedAttachments: types.AutoAcceptSharedAttachmentsValueDisable,
+			})
+			prefixList := server.AddManagedPrefixList(mcAccount, tests.GenerateGUID("user-managed"), "IPv4", 10)
+
+			name = k8stypes.NamespacedName{Name: tests.GenerateGUID("wc"), Namespace: namespace}
+
+			// Share the transit gateway with the account of the workload
+			// cluster like the customer would
+			sess, err := session.NewSession(&awsv1.Config{
+				Endpoint:    awsv1.String(server.URL()),
+				Region:      awsv1.String(awsRegion),
+				Credentials: credentialsv1.NewStaticCredentials(fakeaws.AccessKey(mcAccount), "fake-secret-access-key", ""),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = aws.NewRAMClient(ram.New(sess)).ApplyResourceShare(ctx, aws.ResourceShare{
+				Name:              name.Name,
+				ResourceArns:      []string{*transitGateway.TransitGatewayArn},
+				ExternalAccountID: wcAccount,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			createCluster(name, wcAccount, "10.20.0.0/16", map[string]string{
+				gsannotation.NetworkTopologyModeAnnotation:             gsannotation.NetworkTopologyModeUserManaged,
+				gsannotation.NetworkTopologyTransitGatewayIDAnnotation: *transitGateway.TransitGatewayArn,
+				gsannotation.NetworkTopologyPrefixListIDAnnotation:     *prefixList.PrefixListArn,
+			})
+		})
+
+		It("requests the acceptance of the attachment", func() {
+			Eventually(getNetworkTopology(name)).Should(HaveField("Status.TransitGatewayAttachment.State", string(types.TransitGatewayAttachmentStatePendingAcceptance)))
+			attachmentID := getNetworkTopology(name)(Default).Status.TransitGatewayAttachment.ID
+
+			Eventually(server.Messages).Should(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"TopicArn": Equal(snsTopic),
+				"Message":  ContainSubstring(`"cidrs":["10.20.0.0/16"]`),
+				"Attributes": And(
+					HaveKeyWithValue("Attachment_ID", attachmentID),
+					HaveKeyWithValue("Schema_Version", registrar.AttachmentRequestSchemaVersion),
+				),
+			})))
+			Eventually(getNetworkTopology(name)).Should(HaveField("Status.TransitGatewayAttachment.AcceptanceRequest.Notifications", int32(1)))
+
+			Expect(server.AcceptTransitGatewayVpcAttachment(attachmentID)).To(Succeed())
+			Eventually(getNetworkTopology(name)).Should(HaveField("Status.TransitGatewayAttachment.State", string(types.TransitGatewayAttachmentStateAvailable)))
+		})
+	})
+})
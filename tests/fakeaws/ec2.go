@@ -0,0 +1,949 @@
+package fakeaws
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+type ec2Handler func(s *Server, form url.Values, account string) (interface{}, *apiError)
+
+var ec2Handlers = map[string]ec2Handler{
+	"CreateTransitGateway":                       (*Server).createTransitGatewayAction,
+	"DescribeTransitGateways":                    (*Server).describeTransitGateways,
+	"DeleteTransitGateway":                       (*Server).deleteTransitGateway,
+	"CreateTransitGatewayVpcAttachment":          (*Server).createTransitGatewayVpcAttachment,
+	"DescribeTransitGatewayVpcAttachments":       (*Server).describeTransitGatewayVpcAttachments,
+	"ModifyTransitGatewayVpcAttachment":          (*Server).modifyTransitGatewayVpcAttachment,
+	"DeleteTransitGatewayVpcAttachment":          (*Server).deleteTransitGatewayVpcAttachment,
+	"DescribeTransitGatewayAttachments":          (*Server).describeTransitGatewayAttachments,
+	"CreateTransitGatewayRouteTable":             (*Server).createTransitGatewayRouteTable,
+	"DescribeTransitGatewayRouteTables":          (*Server).describeTransitGatewayRouteTables,
+	"DeleteTransitGatewayRouteTable":             (*Server).deleteTransitGatewayRouteTable,
+	"AssociateTransitGatewayRouteTable":          (*Server).associateTransitGatewayRouteTable,
+	"DisassociateTransitGatewayRouteTable":       (*Server).disassociateTransitGatewayRouteTable,
+	"EnableTransitGatewayRouteTablePropagation":  (*Server).enableTransitGatewayRouteTablePropagation,
+	"DisableTransitGatewayRouteTablePropagation": (*Server).disableTransitGatewayRouteTablePropagation,
+	"GetTransitGatewayAttachmentPropagations":    (*Server).getTransitGatewayAttachmentPropagations,
+	"CreateManagedPrefixList":                    (*Server).createManagedPrefixListAction,
+	"DescribeManagedPrefixLists":                 (*Server).describeManagedPrefixLists,
+	"ModifyManagedPrefixList":                    (*Server).modifyManagedPrefixList,
+	"GetManagedPrefixListEntries":                (*Server).getManagedPrefixListEntries,
+	"GetManagedPrefixListAssociations":           (*Server).getManagedPrefixListAssociations,
+	"DeleteManagedPrefixList":                    (*Server).deleteManagedPrefixList,
+	"DescribeVpcs":                               (*Server).describeVpcs,
+	"DescribeSubnets":                            (*Server).describeSubnets,
+}
+
+func (s *Server) serveEC2(w http.ResponseWriter, r *http.Request, account string) {
+	if err := r.ParseForm(); err != nil {
+		writeEC2Error(w, newError("MalformedQueryString", "%v", err))
+		return
+	}
+
+	action := r.PostForm.Get("Action")
+	handler, ok := ec2Handlers[action]
+	s.recordAction("ec2", action, ok)
+	if !ok {
+		writeEC2Error(w, newError("InvalidAction", "The action %s is not valid for this web service", action))
+		return
+	}
+
+	// The output may reference the stored resources, so it is written while holding the lock
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	output, apiErr := handler(s, r.PostForm, account)
+
+	if apiErr != nil {
+		writeEC2Error(w, apiErr)
+		return
+	}
+	writeEC2Response(w, action, output)
+}
+
+// Transit gateways
+
+func (s *Server) createTransitGateway(account string, description *string, options *types.TransitGatewayOptions, tags []types.Tag) *types.TransitGateway {
+	id := s.generateID("tgw")
+	if options == nil {
+		options = &types.TransitGatewayOptions{
+			AutoAcceptSharedAttachments:  types.AutoAcceptSharedAttachmentsValueDisable,
+			DefaultRouteTableAssociation: types.DefaultRouteTableAssociationValueEnable,
+			DefaultRouteTablePropagation: types.DefaultRouteTablePropagationValueEnable,
+		}
+	}
+
+	tgw := &types.TransitGateway{
+		TransitGatewayId:  aws.String(id),
+		TransitGatewayArn: aws.String(s.arn("ec2", account, "transit-gateway/"+id)),
+		OwnerId:           aws.String(account),
+		Description:       description,
+		State:             types.TransitGatewayStateAvailable,
+		CreationTime:      aws.Time(time.Now()),
+		Options:           options,
+		Tags:              tags,
+	}
+	s.transitGateways[id] = tgw
+	return tgw
+}
+
+func (s *Server) createTransitGatewayAction(form url.Values, account string) (interface{}, *apiError) {
+	options := &types.TransitGatewayOptions{
+		AutoAcceptSharedAttachments:  types.AutoAcceptSharedAttachmentsValue(valueOrDefault(form.Get("Options.AutoAcceptSharedAttachments"), "disable")),
+		DefaultRouteTableAssociation: types.DefaultRouteTableAssociationValue(valueOrDefault(form.Get("Options.DefaultRouteTableAssociation"), "enable")),
+		DefaultRouteTablePropagation: types.DefaultRouteTablePropagationValue(valueOrDefault(form.Get("Options.DefaultRouteTablePropagation"), "enable")),
+	}
+
+	var description *string
+	if form.Get("Description") != "" {
+		description = aws.String(form.Get("Description"))
+	}
+
+	tgw := s.createTransitGateway(account, description, options, formTags(form, types.ResourceTypeTransitGateway))
+	return &ec2.CreateTransitGatewayOutput{TransitGateway: tgw}, nil
+}
+
+// visibleTransitGateway returns the transit gateway if it is owned by or shared with the
+// account.
+func (s *Server) visibleTransitGateway(id, account string) (*types.TransitGateway, bool) {
+	tgw, ok := s.transitGateways[id]
+	if !ok {
+		return nil, false
+	}
+	if *tgw.OwnerId != account && !s.isSharedWith(*tgw.TransitGatewayArn, account) {
+		return nil, false
+	}
+	return tgw, true
+}
+
+func (s *Server) describeTransitGateways(form url.Values, account string) (interface{}, *apiError) {
+	ids := formList(form, "TransitGatewayIds")
+	for _, id := range ids {
+		if _, ok := s.visibleTransitGateway(id, account); !ok {
+			return nil, notFound("InvalidTransitGatewayID.NotFound", id)
+		}
+	}
+
+	output := &ec2.DescribeTransitGatewaysOutput{TransitGateways: []types.TransitGateway{}}
+	for _, id := range sortedKeys(s.transitGateways) {
+		tgw, ok := s.visibleTransitGateway(id, account)
+		if !ok || (len(ids) > 0 && !contains(ids, id)) {
+			continue
+		}
+
+		match, err := matchFilters(formFilters(form), map[string]string{
+			"transit-gateway-id": id,
+			"owner-id":           *tgw.OwnerId,
+			"state":              string(tgw.State),
+		}, tgw.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			output.TransitGateways = append(output.TransitGateways, *tgw)
+		}
+	}
+	return output, nil
+}
+
+func (s *Server) deleteTransitGateway(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("TransitGatewayId")
+	tgw, ok := s.transitGateways[id]
+	if !ok || *tgw.OwnerId != account {
+		return nil, notFound("InvalidTransitGatewayID.NotFound", id)
+	}
+
+	for _, attachment := range s.vpcAttachments {
+		if *attachment.TransitGatewayId == id && attachment.State != types.TransitGatewayAttachmentStateDeleted {
+			return nil, newError("IncorrectState", "%s has non-deleted Transit Gateway Attachments: %s", id, *attachment.TransitGatewayAttachmentId)
+		}
+	}
+	for _, routeTable := range s.routeTables {
+		if *routeTable.routeTable.TransitGatewayId == id {
+			return nil, newError("IncorrectState", "%s has non-deleted Transit Gateway Route Tables: %s", id, *routeTable.routeTable.TransitGatewayRouteTableId)
+		}
+	}
+
+	delete(s.transitGateways, id)
+	deleted := *tgw
+	deleted.State = types.TransitGatewayStateDeleting
+	return &ec2.DeleteTransitGatewayOutput{TransitGateway: &deleted}, nil
+}
+
+// Transit gateway VPC attachments
+
+// visibleVpcAttachment returns the attachment if the account owns the VPC or the transit
+// gateway of the attachment.
+func (s *Server) visibleVpcAttachment(id, account string) (*types.TransitGatewayVpcAttachment, bool) {
+	attachment, ok := s.vpcAttachments[id]
+	if !ok {
+		return nil, false
+	}
+	if *attachment.VpcOwnerId != account && stringValue(s.transitGatewayOwner(*attachment.TransitGatewayId)) != account {
+		return nil, false
+	}
+	return attachment, true
+}
+
+func (s *Server) transitGatewayOwner(id string) *string {
+	if tgw, ok := s.transitGateways[id]; ok {
+		return tgw.OwnerId
+	}
+	return nil
+}
+
+func (s *Server) createTransitGatewayVpcAttachment(form url.Values, account string) (interface{}, *apiError) {
+	tgwID := form.Get("TransitGatewayId")
+	tgw, ok := s.visibleTransitGateway(tgwID, account)
+	if !ok {
+		return nil, notFound("InvalidTransitGatewayID.NotFound", tgwID)
+	}
+
+	vpcID := form.Get("VpcId")
+	vpc, ok := s.vpcs[vpcID]
+	if !ok || *vpc.OwnerId != account {
+		return nil, notFound("InvalidVpcID.NotFound", vpcID)
+	}
+
+	subnetIDs := formList(form, "SubnetIds")
+	if len(subnetIDs) == 0 {
+		return nil, newError("MissingParameter", "The request must contain the parameter SubnetIds")
+	}
+	if err := s.validateAttachmentSubnets(vpcID, subnetIDs); err != nil {
+		return nil, err
+	}
+
+	// Failed attachments block new attachments of the VPC until they are deleted
+	for _, attachment := range s.vpcAttachments {
+		if *attachment.TransitGatewayId == tgwID && *attachment.VpcId == vpcID && attachment.State != types.TransitGatewayAttachmentStateDeleted {
+			return nil, newError("DuplicateTransitGatewayAttachment", "%s has non-deleted Transit Gateway Attachments with same VPC ID", tgwID)
+		}
+	}
+
+	state := types.TransitGatewayAttachmentStateAvailable
+	if *tgw.OwnerId != account && (tgw.Options == nil || tgw.Options.AutoAcceptSharedAttachments != types.AutoAcceptSharedAttachmentsValueEnable) {
+		state = types.TransitGatewayAttachmentStatePendingAcceptance
+	}
+
+	id := s.generateID("tgw-attach")
+	attachment := &types.TransitGatewayVpcAttachment{
+		TransitGatewayAttachmentId: aws.String(id),
+		TransitGatewayId:           aws.String(tgwID),
+		VpcId:                      aws.String(vpcID),
+		VpcOwnerId:                 aws.String(account),
+		SubnetIds:                  subnetIDs,
+		State:                      state,
+		CreationTime:               aws.Time(time.Now()),
+		Options: &types.TransitGatewayVpcAttachmentOptions{
+			ApplianceModeSupport: types.ApplianceModeSupportValueDisable,
+			DnsSupport:           types.DnsSupportValueEnable,
+			Ipv6Support:          types.Ipv6SupportValue(valueOrDefault(form.Get("Options.Ipv6Support"), "disable")),
+		},
+		Tags: formTags(form, types.ResourceTypeTransitGatewayAttachment),
+	}
+	s.vpcAttachments[id] = attachment
+
+	if tgw.Options != nil && tgw.Options.DefaultRouteTableAssociation == types.DefaultRouteTableAssociationValueEnable && tgw.Options.AssociationDefaultRouteTableId != nil {
+		s.routeAssociations[id] = *tgw.Options.AssociationDefaultRouteTableId
+	}
+
+	return &ec2.CreateTransitGatewayVpcAttachmentOutput{TransitGatewayVpcAttachment: attachment}, nil
+}
+
+// validateAttachmentSubnets checks that the subnets exist in the VPC and that there is at
+// most one subnet per availability zone.
+func (s *Server) validateAttachmentSubnets(vpcID string, subnetIDs []string) *apiError {
+	zones := map[string]bool{}
+	for _, subnetID := range subnetIDs {
+		subnet, ok := s.subnets[subnetID]
+		if !ok || *subnet.VpcId != vpcID {
+			return notFound("InvalidSubnetID.NotFound", subnetID)
+		}
+
+		zone := stringValue(subnet.AvailabilityZone)
+		if zones[zone] {
+			return newError("DuplicateSubnetsInSameZone", "Duplicate Subnets for same AZ")
+		}
+		zones[zone] = true
+	}
+	return nil
+}
+
+func (s *Server) describeTransitGatewayVpcAttachments(form url.Values, account string) (interface{}, *apiError) {
+	ids := formList(form, "TransitGatewayAttachmentIds")
+	for _, id := range ids {
+		if _, ok := s.visibleVpcAttachment(id, account); !ok {
+			return nil, notFound("InvalidTransitGatewayAttachmentID.NotFound", id)
+		}
+	}
+
+	output := &ec2.DescribeTransitGatewayVpcAttachmentsOutput{TransitGatewayVpcAttachments: []types.TransitGatewayVpcAttachment{}}
+	for _, id := range sortedKeys(s.vpcAttachments) {
+		attachment, ok := s.visibleVpcAttachment(id, account)
+		if !ok || (len(ids) > 0 && !contains(ids, id)) {
+			continue
+		}
+
+		match, err := matchFilters(formFilters(form), map[string]string{
+			"transit-gateway-attachment-id": id,
+			"transit-gateway-id":            *attachment.TransitGatewayId,
+			"vpc-id":                        *attachment.VpcId,
+			"state":                         string(attachment.State),
+		}, attachment.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			output.TransitGatewayVpcAttachments = append(output.TransitGatewayVpcAttachments, *attachment)
+		}
+	}
+	return output, nil
+}
+
+func (s *Server) modifyTransitGatewayVpcAttachment(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("TransitGatewayAttachmentId")
+	attachment, ok := s.vpcAttachments[id]
+	if !ok || *attachment.VpcOwnerId != account {
+		return nil, notFound("InvalidTransitGatewayAttachmentID.NotFound", id)
+	}
+	if attachment.State != types.TransitGatewayAttachmentStateAvailable {
+		return nil, newError("IncorrectState", "%s is in invalid state", id)
+	}
+
+	subnetIDs := []string{}
+	removeSubnetIDs := formList(form, "RemoveSubnetIds")
+	for _, subnetID := range attachment.SubnetIds {
+		if !contains(removeSubnetIDs, subnetID) {
+			subnetIDs = append(subnetIDs, subnetID)
+		}
+	}
+	subnetIDs = append(subnetIDs, formList(form, "AddSubnetIds")...)
+	if len(subnetIDs) == 0 {
+		return nil, newError("InvalidParameterValue", "A transit gateway attachment requires at least one subnet")
+	}
+	if err := s.validateAttachmentSubnets(*attachment.VpcId, subnetIDs); err != nil {
+		return nil, err
+	}
+
+	attachment.SubnetIds = subnetIDs
+	if ipv6Support := form.Get("Options.Ipv6Support"); ipv6Support != "" {
+		attachment.Options.Ipv6Support = types.Ipv6SupportValue(ipv6Support)
+	}
+
+	return &ec2.ModifyTransitGatewayVpcAttachmentOutput{TransitGatewayVpcAttachment: attachment}, nil
+}
+
+func (s *Server) deleteTransitGatewayVpcAttachment(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("TransitGatewayAttachmentId")
+	attachment, ok := s.visibleVpcAttachment(id, account)
+	if !ok {
+		return nil, notFound("InvalidTransitGatewayAttachmentID.NotFound", id)
+	}
+
+	delete(s.vpcAttachments, id)
+	delete(s.routeAssociations, id)
+	for _, routeTable := range s.routeTables {
+		delete(routeTable.propagations, id)
+	}
+
+	deleted := *attachment
+	deleted.State = types.TransitGatewayAttachmentStateDeleting
+	return &ec2.DeleteTransitGatewayVpcAttachmentOutput{TransitGatewayVpcAttachment: &deleted}, nil
+}
+
+func (s *Server) describeTransitGatewayAttachments(form url.Values, account string) (interface{}, *apiError) {
+	ids := formList(form, "TransitGatewayAttachmentIds")
+	for _, id := range ids {
+		if _, ok := s.visibleVpcAttachment(id, account); !ok {
+			return nil, notFound("InvalidTransitGatewayAttachmentID.NotFound", id)
+		}
+	}
+
+	output := &ec2.DescribeTransitGatewayAttachmentsOutput{TransitGatewayAttachments: []types.TransitGatewayAttachment{}}
+	for _, id := range sortedKeys(s.vpcAttachments) {
+		vpcAttachment, ok := s.visibleVpcAttachment(id, account)
+		if !ok || (len(ids) > 0 && !contains(ids, id)) {
+			continue
+		}
+
+		match, err := matchFilters(formFilters(form), map[string]string{
+			"transit-gateway-attachment-id": id,
+			"transit-gateway-id":            *vpcAttachment.TransitGatewayId,
+			"resource-id":                   *vpcAttachment.VpcId,
+			"resource-type":                 string(types.TransitGatewayAttachmentResourceTypeVpc),
+			"state":                         string(vpcAttachment.State),
+		}, vpcAttachment.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		attachment := types.TransitGatewayAttachment{
+			TransitGatewayAttachmentId: vpcAttachment.TransitGatewayAttachmentId,
+			TransitGatewayId:           vpcAttachment.TransitGatewayId,
+			TransitGatewayOwnerId:      s.transitGatewayOwner(*vpcAttachment.TransitGatewayId),
+			ResourceId:                 vpcAttachment.VpcId,
+			ResourceOwnerId:            vpcAttachment.VpcOwnerId,
+			ResourceType:               types.TransitGatewayAttachmentResourceTypeVpc,
+			State:                      vpcAttachment.State,
+			CreationTime:               vpcAttachment.CreationTime,
+			Tags:                       vpcAttachment.Tags,
+		}
+		if routeTableID, ok := s.routeAssociations[id]; ok {
+			attachment.Association = &types.TransitGatewayAttachmentAssociation{
+				TransitGatewayRouteTableId: aws.String(routeTableID),
+				State:                      types.TransitGatewayAssociationStateAssociated,
+			}
+		}
+		output.TransitGatewayAttachments = append(output.TransitGatewayAttachments, attachment)
+	}
+	return output, nil
+}
+
+// Transit gateway route tables
+
+func (s *Server) createTransitGatewayRouteTable(form url.Values, account string) (interface{}, *apiError) {
+	tgwID := form.Get("TransitGatewayId")
+	tgw, ok := s.transitGateways[tgwID]
+	if !ok || *tgw.OwnerId != account {
+		return nil, notFound("InvalidTransitGatewayID.NotFound", tgwID)
+	}
+
+	id := s.generateID("tgw-rtb")
+	s.routeTables[id] = &routeTable{
+		routeTable: types.TransitGatewayRouteTable{
+			TransitGatewayRouteTableId:   aws.String(id),
+			TransitGatewayId:             aws.String(tgwID),
+			State:                        types.TransitGatewayRouteTableStateAvailable,
+			DefaultAssociationRouteTable: aws.Bool(false),
+			DefaultPropagationRouteTable: aws.Bool(false),
+			CreationTime:                 aws.Time(time.Now()),
+			Tags:                         formTags(form, types.ResourceTypeTransitGatewayRouteTable),
+		},
+		propagations: map[string]bool{},
+	}
+
+	routeTable := s.routeTables[id].routeTable
+	return &ec2.CreateTransitGatewayRouteTableOutput{TransitGatewayRouteTable: &routeTable}, nil
+}
+
+// ownedRouteTable returns the route table if the account owns its transit gateway.
+func (s *Server) ownedRouteTable(id, account string) (*routeTable, bool) {
+	routeTable, ok := s.routeTables[id]
+	if !ok || stringValue(s.transitGatewayOwner(*routeTable.routeTable.TransitGatewayId)) != account {
+		return nil, false
+	}
+	return routeTable, true
+}
+
+func (s *Server) describeTransitGatewayRouteTables(form url.Values, account string) (interface{}, *apiError) {
+	ids := formList(form, "TransitGatewayRouteTableIds")
+	for _, id := range ids {
+		if _, ok := s.ownedRouteTable(id, account); !ok {
+			return nil, notFound("InvalidRouteTableID.NotFound", id)
+		}
+	}
+
+	output := &ec2.DescribeTransitGatewayRouteTablesOutput{TransitGatewayRouteTables: []types.TransitGatewayRouteTable{}}
+	for _, id := range sortedKeys(s.routeTables) {
+		routeTable, ok := s.ownedRouteTable(id, account)
+		if !ok || (len(ids) > 0 && !contains(ids, id)) {
+			continue
+		}
+
+		match, err := matchFilters(formFilters(form), map[string]string{
+			"transit-gateway-route-table-id": id,
+			"transit-gateway-id":             *routeTable.routeTable.TransitGatewayId,
+			"state":                          string(routeTable.routeTable.State),
+		}, routeTable.routeTable.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			output.TransitGatewayRouteTables = append(output.TransitGatewayRouteTables, routeTable.routeTable)
+		}
+	}
+	return output, nil
+}
+
+func (s *Server) deleteTransitGatewayRouteTable(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("TransitGatewayRouteTableId")
+	routeTable, ok := s.ownedRouteTable(id, account)
+	if !ok {
+		return nil, notFound("InvalidRouteTableID.NotFound", id)
+	}
+
+	for attachmentID, routeTableID := range s.routeAssociations {
+		if routeTableID == id {
+			return nil, newError("IncorrectState", "%s has associations: %s", id, attachmentID)
+		}
+	}
+
+	delete(s.routeTables, id)
+	deleted := routeTable.routeTable
+	deleted.State = types.TransitGatewayRouteTableStateDeleting
+	return &ec2.DeleteTransitGatewayRouteTableOutput{TransitGatewayRouteTable: &deleted}, nil
+}
+
+// routeTableAttachment returns the route table and the attachment if both exist, the
+// account owns the transit gateway and the attachment belongs to it.
+func (s *Server) routeTableAttachment(form url.Values, account string) (*routeTable, *types.TransitGatewayVpcAttachment, *apiError) {
+	routeTableID := form.Get("TransitGatewayRouteTableId")
+	routeTable, ok := s.ownedRouteTable(routeTableID, account)
+	if !ok {
+		return nil, nil, notFound("InvalidRouteTableID.NotFound", routeTableID)
+	}
+
+	attachmentID := form.Get("TransitGatewayAttachmentId")
+	attachment, ok := s.vpcAttachments[attachmentID]
+	if !ok || *attachment.TransitGatewayId != *routeTable.routeTable.TransitGatewayId {
+		return nil, nil, notFound("InvalidTransitGatewayAttachmentID.NotFound", attachmentID)
+	}
+	if attachment.State != types.TransitGatewayAttachmentStateAvailable {
+		return nil, nil, newError("IncorrectState", "%s is in invalid state", attachmentID)
+	}
+
+	return routeTable, attachment, nil
+}
+
+func (s *Server) associateTransitGatewayRouteTable(form url.Values, account string) (interface{}, *apiError) {
+	routeTable, attachment, err := s.routeTableAttachment(form, account)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentID := *attachment.TransitGatewayAttachmentId
+	if current, ok := s.routeAssociations[attachmentID]; ok {
+		return nil, newError("Resource.AlreadyAssociated", "Transit Gateway Attachment %s is already associated to a route table %s", attachmentID, current)
+	}
+	s.routeAssociations[attachmentID] = *routeTable.routeTable.TransitGatewayRouteTableId
+
+	return &ec2.AssociateTransitGatewayRouteTableOutput{
+		Association: &types.TransitGatewayAssociation{
+			TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+			TransitGatewayRouteTableId: routeTable.routeTable.TransitGatewayRouteTableId,
+			ResourceId:                 attachment.VpcId,
+			ResourceType:               types.TransitGatewayAttachmentResourceTypeVpc,
+			State:                      types.TransitGatewayAssociationStateAssociated,
+		},
+	}, nil
+}
+
+func (s *Server) disassociateTransitGatewayRouteTable(form url.Values, account string) (interface{}, *apiError) {
+	routeTable, attachment, err := s.routeTableAttachment(form, account)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentID := *attachment.TransitGatewayAttachmentId
+	if s.routeAssociations[attachmentID] != *routeTable.routeTable.TransitGatewayRouteTableId {
+		return nil, newError("InvalidAssociationID.NotFound", "Transit Gateway Attachment %s is not associated with route table %s", attachmentID, *routeTable.routeTable.TransitGatewayRouteTableId)
+	}
+	delete(s.routeAssociations, attachmentID)
+
+	return &ec2.DisassociateTransitGatewayRouteTableOutput{
+		Association: &types.TransitGatewayAssociation{
+			TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+			TransitGatewayRouteTableId: routeTable.routeTable.TransitGatewayRouteTableId,
+			ResourceId:                 attachment.VpcId,
+			ResourceType:               types.TransitGatewayAttachmentResourceTypeVpc,
+			State:                      types.TransitGatewayAssociationStateDisassociating,
+		},
+	}, nil
+}
+
+func (s *Server) enableTransitGatewayRouteTablePropagation(form url.Values, account string) (interface{}, *apiError) {
+	routeTable, attachment, err := s.routeTableAttachment(form, account)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentID := *attachment.TransitGatewayAttachmentId
+	if routeTable.propagations[attachmentID] {
+		return nil, newError("TransitGatewayRouteTablePropagation.Duplicate", "Propagation for %s already exists", attachmentID)
+	}
+	routeTable.propagations[attachmentID] = true
+
+	return &ec2.EnableTransitGatewayRouteTablePropagationOutput{
+		Propagation: propagation(routeTable, attachment, types.TransitGatewayPropagationStateEnabled),
+	}, nil
+}
+
+func (s *Server) disableTransitGatewayRouteTablePropagation(form url.Values, account string) (interface{}, *apiError) {
+	routeTable, attachment, err := s.routeTableAttachment(form, account)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentID := *attachment.TransitGatewayAttachmentId
+	if !routeTable.propagations[attachmentID] {
+		return nil, newError("TransitGatewayRouteTablePropagation.NotFound", "Propagation for %s does not exist", attachmentID)
+	}
+	delete(routeTable.propagations, attachmentID)
+
+	return &ec2.DisableTransitGatewayRouteTablePropagationOutput{
+		Propagation: propagation(routeTable, attachment, types.TransitGatewayPropagationStateDisabled),
+	}, nil
+}
+
+func propagation(routeTable *routeTable, attachment *types.TransitGatewayVpcAttachment, state types.TransitGatewayPropagationState) *types.TransitGatewayPropagation {
+	return &types.TransitGatewayPropagation{
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+		TransitGatewayRouteTableId: routeTable.routeTable.TransitGatewayRouteTableId,
+		ResourceId:                 attachment.VpcId,
+		ResourceType:               types.TransitGatewayAttachmentResourceTypeVpc,
+		State:                      state,
+	}
+}
+
+func (s *Server) getTransitGatewayAttachmentPropagations(form url.Values, account string) (interface{}, *apiError) {
+	attachmentID := form.Get("TransitGatewayAttachmentId")
+	attachment, ok := s.vpcAttachments[attachmentID]
+	if !ok || stringValue(s.transitGatewayOwner(*attachment.TransitGatewayId)) != account {
+		return nil, notFound("InvalidTransitGatewayAttachmentID.NotFound", attachmentID)
+	}
+
+	output := &ec2.GetTransitGatewayAttachmentPropagationsOutput{TransitGatewayAttachmentPropagations: []types.TransitGatewayAttachmentPropagation{}}
+	for _, id := range sortedKeys(s.routeTables) {
+		if s.routeTables[id].propagations[attachmentID] {
+			output.TransitGatewayAttachmentPropagations = append(output.TransitGatewayAttachmentPropagations, types.TransitGatewayAttachmentPropagation{
+				TransitGatewayRouteTableId: aws.String(id),
+				State:                      types.TransitGatewayPropagationStateEnabled,
+			})
+		}
+	}
+	return output, nil
+}
+
+// Managed prefix lists
+
+func (p *prefixList) currentEntries() []types.PrefixListEntry {
+	return p.versions[len(p.versions)-1]
+}
+
+func (s *Server) createManagedPrefixList(account, name, addressFamily string, maxEntries int32) *prefixList {
+	id := s.generateID("pl")
+	prefixList := &prefixList{
+		prefixList: types.ManagedPrefixList{
+			PrefixListId:   aws.String(id),
+			PrefixListArn:  aws.String(s.arn("ec2", account, "prefix-list/"+id)),
+			PrefixListName: aws.String(name),
+			AddressFamily:  aws.String(addressFamily),
+			MaxEntries:     aws.Int32(maxEntries),
+			OwnerId:        aws.String(account),
+			State:          types.PrefixListStateCreateComplete,
+			Version:        aws.Int64(1),
+		},
+		versions: [][]types.PrefixListEntry{{}},
+	}
+	s.prefixLists[id] = prefixList
+	return prefixList
+}
+
+func (s *Server) createManagedPrefixListAction(form url.Values, account string) (interface{}, *apiError) {
+	maxEntries, err := formInt32(form, "MaxEntries")
+	if err != nil {
+		return nil, err
+	}
+	if maxEntries == nil || form.Get("PrefixListName") == "" || form.Get("AddressFamily") == "" {
+		return nil, newError("MissingParameter", "The request must contain the parameters AddressFamily, MaxEntries and PrefixListName")
+	}
+
+	prefixList := s.createManagedPrefixList(account, form.Get("PrefixListName"), form.Get("AddressFamily"), *maxEntries)
+	return &ec2.CreateManagedPrefixListOutput{PrefixList: &prefixList.prefixList}, nil
+}
+
+// visiblePrefixList returns the prefix list if it is owned by or shared with the account.
+func (s *Server) visiblePrefixList(id, account string) (*prefixList, bool) {
+	prefixList, ok := s.prefixLists[id]
+	if !ok {
+		return nil, false
+	}
+	if *prefixList.prefixList.OwnerId != account && !s.isSharedWith(*prefixList.prefixList.PrefixListArn, account) {
+		return nil, false
+	}
+	return prefixList, true
+}
+
+func (s *Server) describeManagedPrefixLists(form url.Values, account string) (interface{}, *apiError) {
+	ids := formList(form, "PrefixListId")
+	for _, id := range ids {
+		if _, ok := s.visiblePrefixList(id, account); !ok {
+			return nil, notFound("InvalidPrefixListID.NotFound", id)
+		}
+	}
+
+	output := &ec2.DescribeManagedPrefixListsOutput{PrefixLists: []types.ManagedPrefixList{}}
+	for _, id := range sortedKeys(s.prefixLists) {
+		prefixList, ok := s.visiblePrefixList(id, account)
+		if !ok || (len(ids) > 0 && !contains(ids, id)) {
+			continue
+		}
+
+		match, err := matchFilters(formFilters(form), map[string]string{
+			"prefix-list-id":   id,
+			"prefix-list-name": *prefixList.prefixList.PrefixListName,
+			"owner-id":         *prefixList.prefixList.OwnerId,
+		}, prefixList.prefixList.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			output.PrefixLists = append(output.PrefixLists, prefixList.prefixList)
+		}
+	}
+	return output, nil
+}
+
+func (s *Server) modifyManagedPrefixList(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("PrefixListId")
+	prefixList, ok := s.prefixLists[id]
+	if !ok || *prefixList.prefixList.OwnerId != account {
+		return nil, notFound("InvalidPrefixListID.NotFound", id)
+	}
+
+	currentVersion, err := formInt64(form, "CurrentVersion")
+	if err != nil {
+		return nil, err
+	}
+	if currentVersion != nil && *currentVersion != *prefixList.prefixList.Version {
+		return nil, newError("PrefixListVersionMismatch", "The prefix list %s has version %d, not %d", id, *prefixList.prefixList.Version, *currentVersion)
+	}
+
+	maxEntries, err := formInt32(form, "MaxEntries")
+	if err != nil {
+		return nil, err
+	}
+
+	addEntries := formStructs(form, "AddEntry")
+	removeEntries := formStructs(form, "RemoveEntry")
+	entriesChanged := len(addEntries) > 0 || len(removeEntries) > 0
+
+	if maxEntries != nil && entriesChanged {
+		return nil, newError("InvalidParameterCombination", "The prefix list can't be resized while its entries are modified")
+	}
+	if entriesChanged && currentVersion == nil {
+		return nil, newError("MissingParameter", "The request must contain the parameter CurrentVersion")
+	}
+
+	if maxEntries != nil {
+		if int(*maxEntries) < len(prefixList.currentEntries()) {
+			return nil, newError("InvalidParameterValue", "The prefix list %s has more than %d entries", id, *maxEntries)
+		}
+		prefixList.prefixList.MaxEntries = maxEntries
+	}
+
+	if entriesChanged {
+		entries := []types.PrefixListEntry{}
+		removeCIDRs := []string{}
+		for _, prefix := range removeEntries {
+			removeCIDRs = append(removeCIDRs, form.Get(prefix+".Cidr"))
+		}
+		for _, cidr := range removeCIDRs {
+			if !hasEntry(prefixList.currentEntries(), cidr) {
+				return nil, newError("InvalidRequest", "The CIDR %s does not exist in prefix list %s", cidr, id)
+			}
+		}
+		for _, entry := range prefixList.currentEntries() {
+			if !contains(removeCIDRs, *entry.Cidr) {
+				entries = append(entries, entry)
+			}
+		}
+		for _, prefix := range addEntries {
+			cidr := form.Get(prefix + ".Cidr")
+			entry := types.PrefixListEntry{Cidr: aws.String(cidr)}
+			if description := form.Get(prefix + ".Description"); description != "" {
+				entry.Description = aws.String(description)
+			}
+
+			// Adding an existing CIDR updates the description of its entry
+			updated := false
+			for i := range entries {
+				if *entries[i].Cidr == cidr {
+					entries[i] = entry
+					updated = true
+				}
+			}
+			if !updated {
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) > int(*prefixList.prefixList.MaxEntries) {
+			return nil, newError("InvalidRequest", "The prefix list %s can't have more than %d entries", id, *prefixList.prefixList.MaxEntries)
+		}
+
+		prefixList.versions = append(prefixList.versions, entries)
+	} else {
+		prefixList.versions = append(prefixList.versions, prefixList.currentEntries())
+	}
+
+	prefixList.prefixList.Version = aws.Int64(int64(len(prefixList.versions)))
+	prefixList.prefixList.State = types.PrefixListStateModifyComplete
+
+	modified := prefixList.prefixList
+	return &ec2.ModifyManagedPrefixListOutput{PrefixList: &modified}, nil
+}
+
+func hasEntry(entries []types.PrefixListEntry, cidr string) bool {
+	for _, entry := range entries {
+		if *entry.Cidr == cidr {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) deleteManagedPrefixList(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("PrefixListId")
+	prefixList, ok := s.prefixLists[id]
+	if !ok || *prefixList.prefixList.OwnerId != account {
+		return nil, notFound("InvalidPrefixListID.NotFound", id)
+	}
+
+	delete(s.prefixLists, id)
+	deleted := prefixList.prefixList
+	deleted.State = types.PrefixListStateDeleteInProgress
+	return &ec2.DeleteManagedPrefixListOutput{PrefixList: &deleted}, nil
+}
+
+func (s *Server) getManagedPrefixListEntries(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("PrefixListId")
+	prefixList, ok := s.visiblePrefixList(id, account)
+	if !ok {
+		return nil, notFound("InvalidPrefixListID.NotFound", id)
+	}
+
+	entries := prefixList.currentEntries()
+	targetVersion, err := formInt64(form, "TargetVersion")
+	if err != nil {
+		return nil, err
+	}
+	if targetVersion != nil {
+		if *targetVersion < 1 || int(*targetVersion) > len(prefixList.versions) {
+			return nil, newError("InvalidParameterValue", "The prefix list %s has no version %d", id, *targetVersion)
+		}
+		entries = prefixList.versions[*targetVersion-1]
+	}
+
+	return paginate(form, entries, func(page []types.PrefixListEntry, nextToken *string) interface{} {
+		return &ec2.GetManagedPrefixListEntriesOutput{Entries: page, NextToken: nextToken}
+	})
+}
+
+func (s *Server) getManagedPrefixListAssociations(form url.Values, account string) (interface{}, *apiError) {
+	id := form.Get("PrefixListId")
+	prefixList, ok := s.prefixLists[id]
+	if !ok || *prefixList.prefixList.OwnerId != account {
+		return nil, notFound("InvalidPrefixListID.NotFound", id)
+	}
+
+	return paginate(form, prefixList.associations, func(page []types.PrefixListAssociation, nextToken *string) interface{} {
+		return &ec2.GetManagedPrefixListAssociationsOutput{PrefixListAssociations: page, NextToken: nextToken}
+	})
+}
+
+// paginate returns the page of the items requested with MaxResults and NextToken.
+func paginate[T any](form url.Values, items []T, output func(page []T, nextToken *string) interface{}) (interface{}, *apiError) {
+	start := 0
+	if token := form.Get("NextToken"); token != "" {
+		var err error
+		start, err = strconv.Atoi(token)
+		if err != nil || start < 0 || start > len(items) {
+			return nil, newError("InvalidNextToken", "The token '%s' is invalid", token)
+		}
+	}
+
+	maxResults, apiErr := formInt32(form, "MaxResults")
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	end := len(items)
+	if maxResults != nil && start+int(*maxResults) < end {
+		end = start + int(*maxResults)
+	}
+
+	var nextToken *string
+	if end < len(items) {
+		nextToken = aws.String(strconv.Itoa(end))
+	}
+	return output(append([]T{}, items[start:end]...), nextToken), nil
+}
+
+// VPCs and subnets
+
+func (s *Server) describeVpcs(form url.Values, account string) (interface{}, *apiError) {
+	ids := formList(form, "VpcId")
+	for _, id := range ids {
+		if vpc, ok := s.vpcs[id]; !ok || *vpc.OwnerId != account {
+			return nil, notFound("InvalidVpcID.NotFound", id)
+		}
+	}
+
+	output := &ec2.DescribeVpcsOutput{Vpcs: []types.Vpc{}}
+	for _, id := range sortedKeys(s.vpcs) {
+		vpc := s.vpcs[id]
+		if *vpc.OwnerId != account || (len(ids) > 0 && !contains(ids, id)) {
+			continue
+		}
+
+		match, err := matchFilters(formFilters(form), map[string]string{
+			"vpc-id":     id,
+			"cidr-block": stringValue(vpc.CidrBlock),
+			"state":      string(vpc.State),
+		}, vpc.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			output.Vpcs = append(output.Vpcs, *vpc)
+		}
+	}
+	return output, nil
+}
+
+func (s *Server) describeSubnets(form url.Values, account string) (interface{}, *apiError) {
+	ids := formList(form, "SubnetId")
+	for _, id := range ids {
+		if subnet, ok := s.subnets[id]; !ok || *subnet.OwnerId != account {
+			return nil, notFound("InvalidSubnetID.NotFound", id)
+		}
+	}
+
+	output := &ec2.DescribeSubnetsOutput{Subnets: []types.Subnet{}}
+	for _, id := range sortedKeys(s.subnets) {
+		subnet := s.subnets[id]
+		if *subnet.OwnerId != account || (len(ids) > 0 && !contains(ids, id)) {
+			continue
+		}
+
+		match, err := matchFilters(formFilters(form), map[string]string{
+			"subnet-id":         id,
+			"vpc-id":            stringValue(subnet.VpcId),
+			"availability-zone": stringValue(subnet.AvailabilityZone),
+			"state":             string(subnet.State),
+		}, subnet.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			output.Subnets = append(output.Subnets, *subnet)
+		}
+	}
+	return output, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,335 @@
+package fakeaws
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/google/uuid"
+)
+
+const ec2Namespace = "http://ec2.amazonaws.com/doc/2016-11-15/"
+
+// ec2ElementNames maps the fields of the SDK types whose XML element names differ from
+// the field name in lower camel case.
+var ec2ElementNames = map[string]string{
+	"Entries":                "entrySet",
+	"PrefixListAssociations": "prefixListAssociationSet",
+	"PrefixLists":            "prefixListSet",
+	"Subnets":                "subnetSet",
+	"Tags":                   "tagSet",
+	"TransitGateways":        "transitGatewaySet",
+	"Vpcs":                   "vpcSet",
+}
+
+var metadataType = reflect.TypeOf(middleware.Metadata{})
+
+// apiError is an error returned to the client with the AWS error code.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+func newError(code, messageFmt string, args ...interface{}) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: code, message: fmt.Sprintf(messageFmt, args...)}
+}
+
+func notFound(code, id string) *apiError {
+	return newError(code, "The ID '%s' does not exist", id)
+}
+
+// writeEC2Response writes the output of the action in the XML format of the EC2 query
+// protocol. The output is an output struct of the SDK, e.g. ec2.CreateTransitGatewayOutput.
+func writeEC2Response(w http.ResponseWriter, action string, output interface{}) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<?xml version="1.0" encoding="UTF-8"?><%sResponse xmlns="%s"><requestId>%s</requestId>`, action, ec2Namespace, uuid.NewString())
+	encodeEC2Fields(buf, reflect.Indirect(reflect.ValueOf(output)))
+	fmt.Fprintf(buf, "</%sResponse>", action)
+
+	w.Header().Set("Content-Type", "text/xml;charset=UTF-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func writeEC2Error(w http.ResponseWriter, err *apiError) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?><Response><Errors><Error>`)
+	writeElement(buf, "Code", err.code)
+	writeElement(buf, "Message", err.message)
+	buf.WriteString(`</Error></Errors>`)
+	writeElement(buf, "RequestID", uuid.NewString())
+	buf.WriteString(`</Response>`)
+
+	w.Header().Set("Content-Type", "text/xml;charset=UTF-8")
+	w.WriteHeader(err.status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func encodeEC2Fields(buf *bytes.Buffer, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type == metadataType {
+			continue
+		}
+
+		name, ok := ec2ElementNames[field.Name]
+		if !ok {
+			name = lowerFirst(field.Name)
+		}
+		encodeEC2Value(buf, name, v.Field(i))
+	}
+}
+
+func encodeEC2Value(buf *bytes.Buffer, name string, v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if t, ok := v.Interface().(time.Time); ok {
+			writeElement(buf, name, t.UTC().Format(time.RFC3339))
+			return
+		}
+		fmt.Fprintf(buf, "<%s>", name)
+		encodeEC2Fields(buf, v)
+		fmt.Fprintf(buf, "</%s>", name)
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		fmt.Fprintf(buf, "<%s>", name)
+		for i := 0; i < v.Len(); i++ {
+			encodeEC2Value(buf, "item", v.Index(i))
+		}
+		fmt.Fprintf(buf, "</%s>", name)
+	case reflect.Map:
+		// Maps aren't used by the EC2 query protocol
+	default:
+		writeElement(buf, name, fmt.Sprint(v.Interface()))
+	}
+}
+
+func writeElement(buf *bytes.Buffer, name, value string) {
+	fmt.Fprintf(buf, "<%s>", name)
+	_ = xml.EscapeText(buf, []byte(value))
+	fmt.Fprintf(buf, "</%s>", name)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// writeQueryResponse writes the result of the action in the XML format of the AWS query
+// protocol used by SNS and STS. The result is written as is.
+func writeQueryResponse(w http.ResponseWriter, action, result string) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<?xml version="1.0" encoding="UTF-8"?><%sResponse><%sResult>%s</%sResult>`, action, action, result, action)
+	buf.WriteString("<ResponseMetadata>")
+	writeElement(buf, "RequestId", uuid.NewString())
+	fmt.Fprintf(buf, "</ResponseMetadata></%sResponse>", action)
+
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func writeQueryError(w http.ResponseWriter, err *apiError) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?><ErrorResponse><Error><Type>Sender</Type>`)
+	writeElement(buf, "Code", err.code)
+	writeElement(buf, "Message", err.message)
+	buf.WriteString("</Error>")
+	writeElement(buf, "RequestId", uuid.NewString())
+	buf.WriteString("</ErrorResponse>")
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(err.status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// writeJSONResponse writes the output in the JSON format of the REST-JSON protocol used
+// by RAM.
+func writeJSONResponse(w http.ResponseWriter, output interface{}) {
+	body, err := json.Marshal(output)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Amzn-Requestid", uuid.NewString())
+	_, _ = w.Write(body)
+}
+
+func writeJSONError(w http.ResponseWriter, err *apiError) {
+	body, _ := json.Marshal(map[string]string{"message": err.message})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Amzn-Errortype", err.code)
+	w.Header().Set("X-Amzn-Requestid", uuid.NewString())
+	w.WriteHeader(err.status)
+	_, _ = w.Write(body)
+}
+
+// formList returns the values of the flattened list with the prefix, e.g. the values of
+// SubnetIds.1, SubnetIds.2, ... for the prefix SubnetIds.
+func formList(form url.Values, prefix string) []string {
+	values := []string{}
+	for i := 1; ; i++ {
+		key := fmt.Sprintf("%s.%d", prefix, i)
+		if _, ok := form[key]; !ok {
+			return values
+		}
+		values = append(values, form.Get(key))
+	}
+}
+
+// formStructs returns the prefixes of the members of the flattened list of structures
+// with the prefix, e.g. AddEntry.1, AddEntry.2, ... for the prefix AddEntry.
+func formStructs(form url.Values, prefix string) []string {
+	prefixes := []string{}
+	for i := 1; ; i++ {
+		memberPrefix := fmt.Sprintf("%s.%d.", prefix, i)
+		found := false
+		for key := range form {
+			if strings.HasPrefix(key, memberPrefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return prefixes
+		}
+		prefixes = append(prefixes, strings.TrimSuffix(memberPrefix, "."))
+	}
+}
+
+func formFilters(form url.Values) []types.Filter {
+	filters := []types.Filter{}
+	for _, prefix := range formStructs(form, "Filter") {
+		name := form.Get(prefix + ".Name")
+		filters = append(filters, types.Filter{
+			Name:   &name,
+			Values: formList(form, prefix+".Value"),
+		})
+	}
+	return filters
+}
+
+// formTags returns the tags of the tag specifications for the resource type.
+func formTags(form url.Values, resourceType types.ResourceType) []types.Tag {
+	tags := []types.Tag{}
+	// CreateTransitGateway uses TagSpecification, all other actions TagSpecifications
+	for _, listPrefix := range []string{"TagSpecification", "TagSpecifications"} {
+		for _, prefix := range formStructs(form, listPrefix) {
+			if form.Get(prefix+".ResourceType") != string(resourceType) {
+				continue
+			}
+			for _, tagPrefix := range formStructs(form, prefix+".Tag") {
+				key := form.Get(tagPrefix + ".Key")
+				value := form.Get(tagPrefix + ".Value")
+				tags = append(tags, types.Tag{Key: &key, Value: &value})
+			}
+		}
+	}
+	return tags
+}
+
+func formInt32(form url.Values, key string) (*int32, *apiError) {
+	value := form.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, newError("InvalidParameterValue", "invalid value %q for %s", value, key)
+	}
+	result := int32(i)
+	return &result, nil
+}
+
+func formInt64(form url.Values, key string) (*int64, *apiError) {
+	value := form.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, newError("InvalidParameterValue", "invalid value %q for %s", value, key)
+	}
+	return &i, nil
+}
+
+// matchFilters returns true if the resource with the attributes and tags matches all
+// filters. Filters on attributes the resource doesn't have are rejected like EC2 does.
+func matchFilters(filters []types.Filter, attributes map[string]string, tags []types.Tag) (bool, *apiError) {
+	for _, filter := range filters {
+		name := stringValue(filter.Name)
+
+		switch {
+		case strings.HasPrefix(name, "tag:"):
+			value, ok := tagValue(tags, strings.TrimPrefix(name, "tag:"))
+			if !ok || !contains(filter.Values, value) {
+				return false, nil
+			}
+		case name == "tag-key":
+			found := false
+			for _, tag := range tags {
+				if contains(filter.Values, stringValue(tag.Key)) {
+					found = true
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		default:
+			value, ok := attributes[name]
+			if !ok {
+				return false, newError("InvalidParameterValue", "The filter '%s' is invalid", name)
+			}
+			if !contains(filter.Values, value) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func tagValue(tags []types.Tag, key string) (string, bool) {
+	for _, tag := range tags {
+		if stringValue(tag.Key) == key {
+			return stringValue(tag.Value), true
+		}
+	}
+	return "", false
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,13 @@
+package fakeaws_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFakeAWS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "FakeAWS Suite")
+}
@@ -0,0 +1,113 @@
+package fakeaws
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sessionDuration is the lifetime of the credentials issued by the fake STS.
+const sessionDuration = time.Hour
+
+func (s *Server) serveSNS(w http.ResponseWriter, r *http.Request, account string) {
+	if err := r.ParseForm(); err != nil {
+		writeQueryError(w, newError("MalformedQueryString", "%v", err))
+		return
+	}
+
+	action := r.PostForm.Get("Action")
+	s.recordAction("sns", action, action == "Publish")
+	if action != "Publish" {
+		writeQueryError(w, newError("InvalidAction", "The action %s is not valid for this web service", action))
+		return
+	}
+
+	message := Message{
+		TopicArn:   r.PostForm.Get("TopicArn"),
+		Message:    r.PostForm.Get("Message"),
+		Attributes: map[string]string{},
+	}
+	if message.TopicArn == "" {
+		writeQueryError(w, newError("InvalidParameter", "Invalid parameter: TopicArn"))
+		return
+	}
+	for _, prefix := range formStructs(r.PostForm, "MessageAttributes.entry") {
+		message.Attributes[r.PostForm.Get(prefix+".Name")] = r.PostForm.Get(prefix + ".Value.StringValue")
+	}
+
+	message.MessageGroupID = r.PostForm.Get("MessageGroupId")
+	message.DeduplicationID = r.PostForm.Get("MessageDeduplicationId")
+	if strings.HasSuffix(message.TopicArn, ".fifo") {
+		if message.MessageGroupID == "" {
+			writeQueryError(w, newError("InvalidParameter", "Invalid parameter: The MessageGroupId parameter is required for FIFO topics"))
+			return
+		}
+	} else if message.MessageGroupID != "" || message.DeduplicationID != "" {
+		writeQueryError(w, newError("InvalidParameter", "Invalid parameter: MessageGroupId and MessageDeduplicationId are only valid for FIFO topics"))
+		return
+	}
+
+	s.mu.Lock()
+	duplicate := false
+	for _, published := range s.messages {
+		if message.DeduplicationID != "" && published.TopicArn == message.TopicArn && published.DeduplicationID == message.DeduplicationID {
+			duplicate = true
+		}
+	}
+	// Like SNS FIFO topics duplicates are accepted but not delivered again
+	if !duplicate {
+		s.messages = append(s.messages, message)
+	}
+	s.mu.Unlock()
+
+	buf := &bytes.Buffer{}
+	writeElement(buf, "MessageId", uuid.NewString())
+	writeQueryResponse(w, "Publish", buf.String())
+}
+
+func (s *Server) serveSTS(w http.ResponseWriter, r *http.Request, account string) {
+	if err := r.ParseForm(); err != nil {
+		writeQueryError(w, newError("MalformedQueryString", "%v", err))
+		return
+	}
+
+	action := r.PostForm.Get("Action")
+	s.recordAction("sts", action, action == "AssumeRole")
+	if action != "AssumeRole" {
+		writeQueryError(w, newError("InvalidAction", "The action %s is not valid for this web service", action))
+		return
+	}
+
+	roleARN := r.PostForm.Get("RoleArn")
+	sessionName := r.PostForm.Get("RoleSessionName")
+	if roleARN == "" || sessionName == "" {
+		writeQueryError(w, newError("ValidationError", "RoleArn and RoleSessionName are required"))
+		return
+	}
+
+	s.mu.Lock()
+	s.assumedRoles = append(s.assumedRoles, AssumedRole{
+		Account:         account,
+		RoleArn:         roleARN,
+		RoleSessionName: sessionName,
+		ExternalID:      r.PostForm.Get("ExternalId"),
+		DurationSeconds: r.PostForm.Get("DurationSeconds"),
+	})
+	s.mu.Unlock()
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("<Credentials>")
+	writeElement(buf, "AccessKeyId", AccessKey(accountFromARN(roleARN)))
+	writeElement(buf, "SecretAccessKey", "fake-secret-access-key")
+	writeElement(buf, "SessionToken", uuid.NewString())
+	writeElement(buf, "Expiration", time.Now().Add(sessionDuration).UTC().Format(time.RFC3339))
+	buf.WriteString("</Credentials><AssumedRoleUser>")
+	writeElement(buf, "Arn", fmt.Sprintf("arn:aws:sts::%s:assumed-role/%s", accountFromARN(roleARN), sessionName))
+	writeElement(buf, "AssumedRoleId", "AROAFAKE:"+sessionName)
+	buf.WriteString("</AssumedRoleUser>")
+	writeQueryResponse(w, "AssumeRole", buf.String())
+}
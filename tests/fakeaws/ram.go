@@ -0,0 +1,185 @@
+package fakeaws
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type ramResourceShare struct {
+	ResourceShareArn        string `json:"resourceShareArn"`
+	Name                    string `json:"name"`
+	OwningAccountID         string `json:"owningAccountId"`
+	AllowExternalPrincipals bool   `json:"allowExternalPrincipals"`
+	Status                  string `json:"status"`
+}
+
+type ramGetResourceSharesInput struct {
+	Name          string `json:"name"`
+	ResourceOwner string `json:"resourceOwner"`
+	NextToken     string `json:"nextToken"`
+}
+
+type ramResourceShareAssociation struct {
+	ResourceShareArn  string `json:"resourceShareArn"`
+	ResourceShareName string `json:"resourceShareName"`
+	AssociatedEntity  string `json:"associatedEntity"`
+	AssociationType   string `json:"associationType"`
+	Status            string `json:"status"`
+}
+
+type ramGetResourceShareAssociationsInput struct {
+	AssociationType string `json:"associationType"`
+	ResourceArn     string `json:"resourceArn"`
+	NextToken       string `json:"nextToken"`
+}
+
+type ramCreateResourceShareInput struct {
+	Name                    string   `json:"name"`
+	Principals              []string `json:"principals"`
+	ResourceArns            []string `json:"resourceArns"`
+	AllowExternalPrincipals *bool    `json:"allowExternalPrincipals"`
+}
+
+func (s *Server) serveRAM(w http.ResponseWriter, r *http.Request, account string) {
+	action := strings.TrimPrefix(r.URL.Path, "/")
+
+	var handler func(*http.Request, string) (interface{}, *apiError)
+	switch action {
+	case "getresourceshares":
+		handler = s.getResourceShares
+	case "createresourceshare":
+		handler = s.createResourceShare
+	case "deleteresourceshare":
+		handler = s.deleteResourceShare
+	case "getresourceshareassociations":
+		handler = s.getResourceShareAssociations
+	}
+	s.recordAction("ram", action, handler != nil)
+	if handler == nil {
+		writeJSONError(w, &apiError{status: http.StatusNotFound, code: "UnknownOperationException", message: "unsupported operation " + action})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	output, err := handler(r, account)
+	if err != nil {
+		writeJSONError(w, err)
+		return
+	}
+	writeJSONResponse(w, output)
+}
+
+func (s *Server) getResourceShares(r *http.Request, account string) (interface{}, *apiError) {
+	input := ramGetResourceSharesInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return nil, newError("MalformedArnException", "invalid request body: %v", err)
+	}
+	if input.ResourceOwner != "SELF" && input.ResourceOwner != "OTHER-ACCOUNTS" {
+		return nil, newError("InvalidParameterException", "resourceOwner must be SELF or OTHER-ACCOUNTS")
+	}
+
+	shares := []ramResourceShare{}
+	for _, arn := range sortedKeys(s.resourceShares) {
+		share := s.resourceShares[arn]
+		if input.Name != "" && share.Name != input.Name {
+			continue
+		}
+
+		owned := share.OwningAccountID == account
+		if (input.ResourceOwner == "SELF") != owned {
+			continue
+		}
+		if !owned && !contains(share.Principals, account) {
+			continue
+		}
+
+		shares = append(shares, toRAMResourceShare(share))
+	}
+
+	return map[string]interface{}{"resourceShares": shares}, nil
+}
+
+func (s *Server) getResourceShareAssociations(r *http.Request, account string) (interface{}, *apiError) {
+	input := ramGetResourceShareAssociationsInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return nil, newError("MalformedArnException", "invalid request body: %v", err)
+	}
+	if input.AssociationType != "RESOURCE" {
+		return nil, newError("InvalidParameterException", "only the RESOURCE association type is supported")
+	}
+
+	associations := []ramResourceShareAssociation{}
+	for _, arn := range sortedKeys(s.resourceShares) {
+		share := s.resourceShares[arn]
+		if share.OwningAccountID != account || share.Status != "ACTIVE" {
+			continue
+		}
+
+		for _, resourceARN := range share.ResourceArns {
+			if input.ResourceArn != "" && resourceARN != input.ResourceArn {
+				continue
+			}
+			associations = append(associations, ramResourceShareAssociation{
+				ResourceShareArn:  share.ARN,
+				ResourceShareName: share.Name,
+				AssociatedEntity:  resourceARN,
+				AssociationType:   "RESOURCE",
+				Status:            "ASSOCIATED",
+			})
+		}
+	}
+
+	return map[string]interface{}{"resourceShareAssociations": associations}, nil
+}
+
+func (s *Server) createResourceShare(r *http.Request, account string) (interface{}, *apiError) {
+	input := ramCreateResourceShareInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return nil, newError("MalformedArnException", "invalid request body: %v", err)
+	}
+	if input.Name == "" {
+		return nil, newError("MissingRequiredParameterException", "name is required")
+	}
+
+	for _, resourceARN := range input.ResourceArns {
+		if accountFromARN(resourceARN) != account {
+			return nil, newError("UnknownResourceException", "The resource %s is not owned by account %s", resourceARN, account)
+		}
+	}
+
+	share := &ResourceShare{
+		Name:            input.Name,
+		ARN:             s.arn("ram", account, "resource-share/"+s.generateID("share")),
+		OwningAccountID: account,
+		Principals:      input.Principals,
+		ResourceArns:    input.ResourceArns,
+		Status:          "ACTIVE",
+	}
+	s.resourceShares[share.ARN] = share
+
+	return map[string]interface{}{"resourceShare": toRAMResourceShare(share)}, nil
+}
+
+func (s *Server) deleteResourceShare(r *http.Request, account string) (interface{}, *apiError) {
+	arn := r.URL.Query().Get("resourceShareArn")
+	share, ok := s.resourceShares[arn]
+	if !ok || share.OwningAccountID != account {
+		return nil, newError("UnknownResourceException", "The resource share %s does not exist", arn)
+	}
+
+	share.Status = "DELETED"
+	return map[string]interface{}{"returnValue": true}, nil
+}
+
+func toRAMResourceShare(share *ResourceShare) ramResourceShare {
+	return ramResourceShare{
+		ResourceShareArn:        share.ARN,
+		Name:                    share.Name,
+		OwningAccountID:         share.OwningAccountID,
+		AllowExternalPrincipals: true,
+		Status:                  share.Status,
+	}
+}
@@ -0,0 +1,434 @@
+// Package fakeaws provides a stateful in-memory fake of the EC2, RAM, SNS and STS APIs
+// used by the operator. It is served over HTTP so the real AWS SDK clients can be
+// pointed at it by configuring a custom endpoint.
+package fakeaws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const (
+	// DefaultAccount is the account of requests signed with credentials that weren't
+	// issued by the fake STS.
+	DefaultAccount = "000000000000"
+
+	// accessKeyPrefix is the prefix of the access keys issued by the fake STS. The
+	// access key ends with the account of the assumed role.
+	accessKeyPrefix = "ASIAFAKE"
+)
+
+// credentialScope matches the credential scope of a SigV4 Authorization header:
+// Credential=<access key>/<date>/<region>/<service>/aws4_request
+var credentialScope = regexp.MustCompile(`Credential=([^/]+)/[^/]+/[^/]+/([^/]+)/aws4_request`)
+
+// Message is a message published to an SNS topic.
+type Message struct {
+	TopicArn   string
+	Message    string
+	Attributes map[string]string
+
+	// MessageGroupID and DeduplicationID are only set for FIFO topics.
+	MessageGroupID  string
+	DeduplicationID string
+}
+
+// AssumedRole is a role assumed through the fake STS.
+type AssumedRole struct {
+	// Account is the account of the credentials that assumed the role.
+	Account         string
+	RoleArn         string
+	RoleSessionName string
+	ExternalID      string
+	DurationSeconds string
+}
+
+// ResourceShare is a RAM resource share.
+type ResourceShare struct {
+	Name            string
+	ARN             string
+	OwningAccountID string
+	Principals      []string
+	ResourceArns    []string
+	Status          string
+}
+
+type prefixList struct {
+	prefixList types.ManagedPrefixList
+	// versions holds the entries of every version of the prefix list, starting with
+	// version 1 at index 0.
+	versions [][]types.PrefixListEntry
+	// associations holds the resources referencing the prefix list.
+	associations []types.PrefixListAssociation
+}
+
+type routeTable struct {
+	routeTable types.TransitGatewayRouteTable
+	// propagations holds the IDs of the attachments propagating to the route table.
+	propagations map[string]bool
+}
+
+// Server is the fake AWS API server. All resources are available as soon as they are
+// created and are removed as soon as they are deleted.
+type Server struct {
+	server *httptest.Server
+	region string
+
+	mu                 sync.Mutex
+	nextID             int
+	transitGateways    map[string]*types.TransitGateway
+	vpcAttachments     map[string]*types.TransitGatewayVpcAttachment
+	routeTables        map[string]*routeTable
+	routeAssociations  map[string]string
+	prefixLists        map[string]*prefixList
+	vpcs               map[string]*types.Vpc
+	subnets            map[string]*types.Subnet
+	resourceShares     map[string]*ResourceShare
+	messages           []Message
+	assumedRoles       []AssumedRole
+	requestedActions   map[string]int
+	unsupportedActions []string
+}
+
+// NewServer starts a fake AWS API server for the region.
+func NewServer(region string) *Server {
+	s := &Server{
+		region:            region,
+		transitGateways:   map[string]*types.TransitGateway{},
+		vpcAttachments:    map[string]*types.TransitGatewayVpcAttachment{},
+		routeTables:       map[string]*routeTable{},
+		routeAssociations: map[string]string{},
+		prefixLists:       map[string]*prefixList{},
+		vpcs:              map[string]*types.Vpc{},
+		subnets:           map[string]*types.Subnet{},
+		resourceShares:    map[string]*ResourceShare{},
+		requestedActions:  map[string]int{},
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the endpoint of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Region returns the region of the resources of the server.
+func (s *Server) Region() string {
+	return s.region
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	accessKey, service := parseCredentialScope(r)
+	account := accountFromAccessKey(accessKey)
+
+	switch service {
+	case "ec2":
+		s.serveEC2(w, r, account)
+	case "ram":
+		s.serveRAM(w, r, account)
+	case "sns":
+		s.serveSNS(w, r, account)
+	case "sts":
+		s.serveSTS(w, r, account)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported service %q", service), http.StatusBadRequest)
+	}
+}
+
+// AccessKey returns the access key identifying requests of the account. The fake STS
+// issues it for the roles of the account.
+func AccessKey(account string) string {
+	return accessKeyPrefix + account
+}
+
+func parseCredentialScope(r *http.Request) (string, string) {
+	match := credentialScope.FindStringSubmatch(r.Header.Get("Authorization"))
+	if match == nil {
+		return "", ""
+	}
+	return match[1], match[2]
+}
+
+func accountFromAccessKey(accessKey string) string {
+	if strings.HasPrefix(accessKey, accessKeyPrefix) {
+		return strings.TrimPrefix(accessKey, accessKeyPrefix)
+	}
+	return DefaultAccount
+}
+
+func accountFromARN(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 5 || parts[4] == "" {
+		return DefaultAccount
+	}
+	return parts[4]
+}
+
+func (s *Server) generateID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%017x", prefix, s.nextID)
+}
+
+func (s *Server) arn(service, account, resource string) string {
+	return fmt.Sprintf("arn:aws:%s:%s:%s:%s", service, s.region, account, resource)
+}
+
+// isSharedWith returns true if the resource is shared with the account through an active
+// resource share.
+func (s *Server) isSharedWith(resourceARN, account string) bool {
+	for _, share := range s.resourceShares {
+		if share.Status == "ACTIVE" && contains(share.Principals, account) && contains(share.ResourceArns, resourceARN) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) recordAction(service, action string, supported bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requestedActions[service+"."+action]++
+	if !supported {
+		s.unsupportedActions = append(s.unsupportedActions, service+"."+action)
+	}
+}
+
+// AddVPC adds the VPC to the account.
+func (s *Server) AddVPC(account string, vpc types.Vpc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vpc.OwnerId = &account
+	if vpc.State == "" {
+		vpc.State = types.VpcStateAvailable
+	}
+	s.vpcs[*vpc.VpcId] = &vpc
+}
+
+// AddSubnet adds the subnet to the account.
+func (s *Server) AddSubnet(account string, subnet types.Subnet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subnet.OwnerId = &account
+	if subnet.State == "" {
+		subnet.State = types.SubnetStateAvailable
+	}
+	s.subnets[*subnet.SubnetId] = &subnet
+}
+
+// AddTransitGateway adds a transit gateway that wasn't created by the operator to the
+// account and returns it.
+func (s *Server) AddTransitGateway(account string, options *types.TransitGatewayOptions) types.TransitGateway {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.createTransitGateway(account, nil, options, nil)
+}
+
+// AddManagedPrefixList adds a prefix list that wasn't created by the operator to the
+// account and returns it.
+func (s *Server) AddManagedPrefixList(account, name, addressFamily string, maxEntries int32) types.ManagedPrefixList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createManagedPrefixList(account, name, addressFamily, maxEntries).prefixList
+}
+
+// AssociateManagedPrefixList records that the resource, e.g. a route table or security group,
+// references the prefix list.
+func (s *Server) AssociateManagedPrefixList(prefixListID, resourceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefixList, ok := s.prefixLists[prefixListID]
+	if !ok {
+		return fmt.Errorf("prefix list %s not found", prefixListID)
+	}
+	prefixList.associations = append(prefixList.associations, types.PrefixListAssociation{
+		ResourceId:    aws.String(resourceID),
+		ResourceOwner: prefixList.prefixList.OwnerId,
+	})
+	return nil
+}
+
+// AcceptTransitGatewayVpcAttachment accepts the pending attachment like the owner of the
+// transit gateway would.
+func (s *Server) AcceptTransitGatewayVpcAttachment(attachmentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attachment, ok := s.vpcAttachments[attachmentID]
+	if !ok {
+		return fmt.Errorf("transit gateway attachment %s not found", attachmentID)
+	}
+	if attachment.State != types.TransitGatewayAttachmentStatePendingAcceptance {
+		return fmt.Errorf("transit gateway attachment %s is in state %s", attachmentID, attachment.State)
+	}
+	attachment.State = types.TransitGatewayAttachmentStateAvailable
+	return nil
+}
+
+// SetTransitGatewayVpcAttachmentState sets the state of the attachment, e.g. to simulate
+// failed attachments or attachments which are still being deleted.
+func (s *Server) SetTransitGatewayVpcAttachmentState(attachmentID string, state types.TransitGatewayAttachmentState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attachment, ok := s.vpcAttachments[attachmentID]
+	if !ok {
+		return fmt.Errorf("transit gateway attachment %s not found", attachmentID)
+	}
+	attachment.State = state
+	return nil
+}
+
+// TransitGateways returns all transit gateways.
+func (s *Server) TransitGateways() []types.TransitGateway {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := []types.TransitGateway{}
+	for _, id := range sortedKeys(s.transitGateways) {
+		result = append(result, *s.transitGateways[id])
+	}
+	return result
+}
+
+// TransitGatewayVpcAttachments returns all transit gateway VPC attachments.
+func (s *Server) TransitGatewayVpcAttachments() []types.TransitGatewayVpcAttachment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := []types.TransitGatewayVpcAttachment{}
+	for _, id := range sortedKeys(s.vpcAttachments) {
+		result = append(result, *s.vpcAttachments[id])
+	}
+	return result
+}
+
+// TransitGatewayRouteTables returns all transit gateway route tables.
+func (s *Server) TransitGatewayRouteTables() []types.TransitGatewayRouteTable {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := []types.TransitGatewayRouteTable{}
+	for _, id := range sortedKeys(s.routeTables) {
+		result = append(result, s.routeTables[id].routeTable)
+	}
+	return result
+}
+
+// RouteTableAssociation returns the ID of the route table the attachment is associated
+// with or an empty string.
+func (s *Server) RouteTableAssociation(attachmentID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.routeAssociations[attachmentID]
+}
+
+// ManagedPrefixLists returns all prefix lists.
+func (s *Server) ManagedPrefixLists() []types.ManagedPrefixList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := []types.ManagedPrefixList{}
+	for _, id := range sortedKeys(s.prefixLists) {
+		result = append(result, s.prefixLists[id].prefixList)
+	}
+	return result
+}
+
+// PrefixListEntries returns the entries of the current version of the prefix list.
+func (s *Server) PrefixListEntries(prefixListID string) []types.PrefixListEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefixList, ok := s.prefixLists[prefixListID]
+	if !ok {
+		return nil
+	}
+	return append([]types.PrefixListEntry{}, prefixList.currentEntries()...)
+}
+
+// ResourceShares returns all RAM resource shares that weren't deleted.
+func (s *Server) ResourceShares() []ResourceShare {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := []ResourceShare{}
+	for _, arn := range sortedKeys(s.resourceShares) {
+		if s.resourceShares[arn].Status == "ACTIVE" {
+			result = append(result, *s.resourceShares[arn])
+		}
+	}
+	return result
+}
+
+// Messages returns all messages published to SNS topics.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message{}, s.messages...)
+}
+
+// AssumedRoles returns all roles assumed through STS.
+func (s *Server) AssumedRoles() []AssumedRole {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]AssumedRole{}, s.assumedRoles...)
+}
+
+// RequestCount returns how often the action of the service was requested, e.g.
+// RequestCount("ec2", "CreateTransitGateway").
+func (s *Server) RequestCount(service, action string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requestedActions[service+"."+action]
+}
+
+// UnsupportedActions returns the actions that were requested but aren't implemented by
+// the fake.
+func (s *Server) UnsupportedActions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.unsupportedActions...)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
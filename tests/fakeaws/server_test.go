@@ -0,0 +1,488 @@
+package fakeaws_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	credentialsv1 "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ram"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	awsclient "github.com/giantswarm/aws-network-topology-operator/pkg/aws"
+	"github.com/giantswarm/aws-network-topology-operator/tests/fakeaws"
+)
+
+const (
+	region    = "eu-north-1"
+	mcAccount = "111111111111"
+	wcAccount = "222222222222"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		ctx    context.Context
+		server *fakeaws.Server
+
+		mcClient *ec2.Client
+		wcClient *ec2.Client
+	)
+
+	newEC2Client := func(account string) *ec2.Client {
+		return ec2.New(ec2.Options{
+			Region:       region,
+			BaseEndpoint: aws.String(server.URL()),
+			Credentials:  credentials.NewStaticCredentialsProvider(fakeaws.AccessKey(account), "secret", ""),
+		})
+	}
+
+	addVPC := func(account, vpcID string, subnets map[string]string) {
+		server.AddVPC(account, types.Vpc{VpcId: aws.String(vpcID), CidrBlock: aws.String("10.0.0.0/16")})
+		for subnetID, zone := range subnets {
+			server.AddSubnet(account, types.Subnet{
+				SubnetId:         aws.String(subnetID),
+				VpcId:            aws.String(vpcID),
+				AvailabilityZone: aws.String(zone),
+				Tags: []types.Tag{
+					{Key: aws.String("subnet.giantswarm.io/role"), Value: aws.String("private")},
+				},
+			})
+		}
+	}
+
+	createTransitGateway := func(client *ec2.Client) *types.TransitGateway {
+		out, err := client.CreateTransitGateway(ctx, &ec2.CreateTransitGatewayInput{
+			Description: aws.String("test"),
+			Options: &types.TransitGatewayRequestOptions{
+				DefaultRouteTableAssociation: types.DefaultRouteTableAssociationValueDisable,
+			},
+			TagSpecifications: []types.TagSpecification{
+				{
+					ResourceType: types.ResourceTypeTransitGateway,
+					Tags:         []types.Tag{{Key: aws.String("Name"), Value: aws.String("test")}},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return out.TransitGateway
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = fakeaws.NewServer(region)
+
+		mcClient = newEC2Client(mcAccount)
+		wcClient = newEC2Client(wcAccount)
+	})
+
+	AfterEach(func() {
+		Expect(server.UnsupportedActions()).To(BeEmpty())
+		server.Close()
+	})
+
+	Describe("transit gateways", func() {
+		It("creates and describes transit gateways", func() {
+			tgw := createTransitGateway(mcClient)
+			Expect(tgw.State).To(Equal(types.TransitGatewayStateAvailable))
+			Expect(tgw.OwnerId).To(Equal(aws.String(mcAccount)))
+
+			out, err := mcClient.DescribeTransitGateways(ctx, &ec2.DescribeTransitGatewaysInput{
+				TransitGatewayIds: []string{*tgw.TransitGatewayId},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.TransitGateways).To(HaveLen(1))
+			Expect(out.TransitGateways[0].Tags).To(ContainElement(types.Tag{Key: aws.String("Name"), Value: aws.String("test")}))
+			Expect(out.TransitGateways[0].Options.DefaultRouteTableAssociation).To(Equal(types.DefaultRouteTableAssociationValueDisable))
+		})
+
+		It("returns a not found error for unknown transit gateways", func() {
+			_, err := mcClient.DescribeTransitGateways(ctx, &ec2.DescribeTransitGatewaysInput{
+				TransitGatewayIds: []string{"tgw-unknown"},
+			})
+			Expect(awsclient.HasErrorCode(err, "InvalidTransitGatewayID.NotFound")).To(BeTrue())
+		})
+
+		It("hides transit gateways of other accounts", func() {
+			tgw := createTransitGateway(mcClient)
+
+			_, err := wcClient.DescribeTransitGateways(ctx, &ec2.DescribeTransitGatewaysInput{
+				TransitGatewayIds: []string{*tgw.TransitGatewayId},
+			})
+			Expect(awsclient.HasErrorCode(err, "InvalidTransitGatewayID.NotFound")).To(BeTrue())
+		})
+	})
+
+	Describe("transit gateway VPC attachments", func() {
+		var tgw *types.TransitGateway
+
+		BeforeEach(func() {
+			tgw = createTransitGateway(mcClient)
+			addVPC(mcAccount, "vpc-mc", map[string]string{"subnet-mc-a": "eu-north-1a", "subnet-mc-a2": "eu-north-1a", "subnet-mc-b": "eu-north-1b"})
+			addVPC(wcAccount, "vpc-wc", map[string]string{"subnet-wc-a": "eu-north-1a"})
+		})
+
+		It("attaches VPCs of the owner of the transit gateway", func() {
+			out, err := mcClient.CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
+				TransitGatewayId: tgw.TransitGatewayId,
+				VpcId:            aws.String("vpc-mc"),
+				SubnetIds:        []string{"subnet-mc-a"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			attachment := out.TransitGatewayVpcAttachment
+			Expect(attachment.State).To(Equal(types.TransitGatewayAttachmentStateAvailable))
+
+			_, err = mcClient.ModifyTransitGatewayVpcAttachment(ctx, &ec2.ModifyTransitGatewayVpcAttachmentInput{
+				TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+				AddSubnetIds:               []string{"subnet-mc-b"},
+				RemoveSubnetIds:            []string{"subnet-mc-a"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			describeOut, err := mcClient.DescribeTransitGatewayVpcAttachments(ctx, &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+				Filters: []types.Filter{
+					{Name: aws.String("transit-gateway-id"), Values: []string{*tgw.TransitGatewayId}},
+					{Name: aws.String("vpc-id"), Values: []string{"vpc-mc"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(describeOut.TransitGatewayVpcAttachments).To(HaveLen(1))
+			Expect(describeOut.TransitGatewayVpcAttachments[0].SubnetIds).To(ConsistOf("subnet-mc-b"))
+		})
+
+		It("rejects subnets in the same availability zone", func() {
+			_, err := mcClient.CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
+				TransitGatewayId: tgw.TransitGatewayId,
+				VpcId:            aws.String("vpc-mc"),
+				SubnetIds:        []string{"subnet-mc-a", "subnet-mc-a2"},
+			})
+			Expect(awsclient.HasErrorCode(err, "DuplicateSubnetsInSameZone")).To(BeTrue())
+		})
+
+		It("requires the transit gateway to be shared with other accounts", func() {
+			_, err := wcClient.CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
+				TransitGatewayId: tgw.TransitGatewayId,
+				VpcId:            aws.String("vpc-wc"),
+				SubnetIds:        []string{"subnet-wc-a"},
+			})
+			Expect(awsclient.HasErrorCode(err, "InvalidTransitGatewayID.NotFound")).To(BeTrue())
+		})
+
+		It("requires attachments of other accounts to be accepted", func() {
+			userManaged := server.AddTransitGateway(mcAccount, &types.TransitGatewayOptions{
+				AutoAcceptSharedAttachments: types.AutoAcceptSharedAttachmentsValueDisable,
+			})
+			_, err := awsclient.NewRAMClient(newRAMClient(server, mcAccount)).ApplyResourceShare(ctx, awsclient.ResourceShare{
+				Name:              "user-managed",
+				ResourceArns:      []string{*userManaged.TransitGatewayArn},
+				ExternalAccountID: wcAccount,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			out, err := wcClient.CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
+				TransitGatewayId: userManaged.TransitGatewayId,
+				VpcId:            aws.String("vpc-wc"),
+				SubnetIds:        []string{"subnet-wc-a"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			attachmentID := *out.TransitGatewayVpcAttachment.TransitGatewayAttachmentId
+			Expect(out.TransitGatewayVpcAttachment.State).To(Equal(types.TransitGatewayAttachmentStatePendingAcceptance))
+
+			Expect(server.AcceptTransitGatewayVpcAttachment(attachmentID)).To(Succeed())
+			Expect(server.TransitGatewayVpcAttachments()).To(ContainElement(
+				HaveField("State", types.TransitGatewayAttachmentStateAvailable),
+			))
+		})
+	})
+
+	Describe("transit gateway route tables", func() {
+		It("associates and propagates attachments", func() {
+			tgw := createTransitGateway(mcClient)
+			addVPC(mcAccount, "vpc-mc", map[string]string{"subnet-mc-a": "eu-north-1a"})
+
+			attachmentOut, err := mcClient.CreateTransitGatewayVpcAttachment(ctx, &ec2.CreateTransitGatewayVpcAttachmentInput{
+				TransitGatewayId: tgw.TransitGatewayId,
+				VpcId:            aws.String("vpc-mc"),
+				SubnetIds:        []string{"subnet-mc-a"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			attachmentID := attachmentOut.TransitGatewayVpcAttachment.TransitGatewayAttachmentId
+
+			routeTableOut, err := mcClient.CreateTransitGatewayRouteTable(ctx, &ec2.CreateTransitGatewayRouteTableInput{
+				TransitGatewayId: tgw.TransitGatewayId,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			routeTableID := routeTableOut.TransitGatewayRouteTable.TransitGatewayRouteTableId
+
+			_, err = mcClient.AssociateTransitGatewayRouteTable(ctx, &ec2.AssociateTransitGatewayRouteTableInput{
+				TransitGatewayAttachmentId: attachmentID,
+				TransitGatewayRouteTableId: routeTableID,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.RouteTableAssociation(*attachmentID)).To(Equal(*routeTableID))
+
+			_, err = mcClient.EnableTransitGatewayRouteTablePropagation(ctx, &ec2.EnableTransitGatewayRouteTablePropagationInput{
+				TransitGatewayAttachmentId: attachmentID,
+				TransitGatewayRouteTableId: routeTableID,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			propagationsOut, err := mcClient.GetTransitGatewayAttachmentPropagations(ctx, &ec2.GetTransitGatewayAttachmentPropagationsInput{
+				TransitGatewayAttachmentId: attachmentID,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(propagationsOut.TransitGatewayAttachmentPropagations).To(ConsistOf(
+				HaveField("TransitGatewayRouteTableId", routeTableID),
+			))
+
+			_, err = mcClient.DeleteTransitGatewayRouteTable(ctx, &ec2.DeleteTransitGatewayRouteTableInput{
+				TransitGatewayRouteTableId: routeTableID,
+			})
+			Expect(awsclient.HasErrorCode(err, awsclient.ErrIncorrectState)).To(BeTrue())
+		})
+	})
+
+	Describe("managed prefix lists", func() {
+		It("modifies the entries of the current version", func() {
+			out, err := mcClient.CreateManagedPrefixList(ctx, &ec2.CreateManagedPrefixListInput{
+				AddressFamily:  aws.String("IPv4"),
+				MaxEntries:     aws.Int32(2),
+				PrefixListName: aws.String("test"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			prefixList := out.PrefixList
+
+			_, err = mcClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+				PrefixListId:   prefixList.PrefixListId,
+				CurrentVersion: prefixList.Version,
+				AddEntries:     []types.AddPrefixListEntry{{Cidr: aws.String("10.0.0.0/16"), Description: aws.String("test")}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = mcClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+				PrefixListId:   prefixList.PrefixListId,
+				CurrentVersion: prefixList.Version,
+				AddEntries:     []types.AddPrefixListEntry{{Cidr: aws.String("10.1.0.0/16")}},
+			})
+			Expect(awsclient.HasErrorCode(err, awsclient.ErrPrefixListVersionMismatch)).To(BeTrue())
+
+			entriesOut, err := mcClient.GetManagedPrefixListEntries(ctx, &ec2.GetManagedPrefixListEntriesInput{
+				PrefixListId: prefixList.PrefixListId,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entriesOut.Entries).To(ConsistOf(HaveField("Cidr", aws.String("10.0.0.0/16"))))
+		})
+
+		It("updates the description of existing entries", func() {
+			prefixList := server.AddManagedPrefixList(mcAccount, "test", "IPv4", 1)
+
+			out, err := mcClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+				PrefixListId:   prefixList.PrefixListId,
+				CurrentVersion: prefixList.Version,
+				AddEntries:     []types.AddPrefixListEntry{{Cidr: aws.String("10.0.0.0/16"), Description: aws.String("old")}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = mcClient.ModifyManagedPrefixList(ctx, &ec2.ModifyManagedPrefixListInput{
+				PrefixListId:   prefixList.PrefixListId,
+				CurrentVersion: out.PrefixList.Version,
+				AddEntries:     []types.AddPrefixListEntry{{Cidr: aws.String("10.0.0.0/16"), Description: aws.String("new")}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(server.PrefixListEntries(*prefixList.PrefixListId)).To(ConsistOf(types.PrefixListEntry{
+				Cidr:        aws.String("10.0.0.0/16"),
+				Description: aws.String("new"),
+			}))
+		})
+
+		It("deletes prefix lists of the account", func() {
+			out, err := mcClient.CreateManagedPrefixList(ctx, &ec2.CreateManagedPrefixListInput{
+				AddressFamily:  aws.String("IPv4"),
+				MaxEntries:     aws.Int32(2),
+				PrefixListName: aws.String("test"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = wcClient.DeleteManagedPrefixList(ctx, &ec2.DeleteManagedPrefixListInput{
+				PrefixListId: out.PrefixList.PrefixListId,
+			})
+			Expect(err).To(HaveOccurred())
+
+			deleteOut, err := mcClient.DeleteManagedPrefixList(ctx, &ec2.DeleteManagedPrefixListInput{
+				PrefixListId: out.PrefixList.PrefixListId,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleteOut.PrefixList.State).To(Equal(types.PrefixListStateDeleteInProgress))
+			Expect(server.ManagedPrefixLists()).To(BeEmpty())
+		})
+
+		It("returns the resources referencing a prefix list", func() {
+			prefixList := server.AddManagedPrefixList(mcAccount, "test", "IPv4", 2)
+			Expect(server.AssociateManagedPrefixList(*prefixList.PrefixListId, "rtb-mc")).To(Succeed())
+
+			out, err := mcClient.GetManagedPrefixListAssociations(ctx, &ec2.GetManagedPrefixListAssociationsInput{
+				PrefixListId: prefixList.PrefixListId,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.PrefixListAssociations).To(ConsistOf(HaveField("ResourceId", aws.String("rtb-mc"))))
+
+			_, err = wcClient.GetManagedPrefixListAssociations(ctx, &ec2.GetManagedPrefixListAssociationsInput{
+				PrefixListId: prefixList.PrefixListId,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("subnets", func() {
+		It("filters subnets by tags", func() {
+			addVPC(mcAccount, "vpc-mc", map[string]string{"subnet-mc-a": "eu-north-1a"})
+			server.AddSubnet(mcAccount, types.Subnet{SubnetId: aws.String("subnet-mc-public"), VpcId: aws.String("vpc-mc")})
+
+			out, err := mcClient.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+				Filters: []types.Filter{
+					{Name: aws.String("vpc-id"), Values: []string{"vpc-mc"}},
+					{Name: aws.String("tag:subnet.giantswarm.io/role"), Values: []string{"private"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Subnets).To(ConsistOf(HaveField("SubnetId", aws.String("subnet-mc-a"))))
+		})
+	})
+
+	Describe("STS", func() {
+		It("issues credentials for the account of the role", func() {
+			client := sts.New(sts.Options{
+				Region:       region,
+				BaseEndpoint: aws.String(server.URL()),
+				Credentials:  credentials.NewStaticCredentialsProvider("AKIAFAKE", "secret", ""),
+			})
+
+			out, err := client.AssumeRole(ctx, &sts.AssumeRoleInput{
+				RoleArn:         aws.String(fmt.Sprintf("arn:aws:iam::%s:role/test", wcAccount)),
+				RoleSessionName: aws.String("test"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.Credentials.AccessKeyId).To(Equal(aws.String(fakeaws.AccessKey(wcAccount))))
+		})
+	})
+
+	Describe("RAM", func() {
+		It("creates and deletes resource shares", func() {
+			tgw := createTransitGateway(mcClient)
+			ramClient := awsclient.NewRAMClient(newRAMClient(server, mcAccount))
+			share := awsclient.ResourceShare{
+				Name:              "test",
+				ResourceArns:      []string{*tgw.TransitGatewayArn},
+				ExternalAccountID: wcAccount,
+			}
+
+			arn, err := ramClient.ApplyResourceShare(ctx, share)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ResourceShares()).To(ConsistOf(HaveField("ARN", arn)))
+
+			sameARN, err := ramClient.ApplyResourceShare(ctx, share)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sameARN).To(Equal(arn))
+
+			_, err = wcClient.DescribeTransitGateways(ctx, &ec2.DescribeTransitGatewaysInput{
+				TransitGatewayIds: []string{*tgw.TransitGatewayId},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ramClient.DeleteResourceShare(ctx, "test")).To(Succeed())
+			Expect(server.ResourceShares()).To(BeEmpty())
+		})
+
+		It("deletes the resource shares of a resource", func() {
+			tgw := createTransitGateway(mcClient)
+			prefixList := server.AddManagedPrefixList(mcAccount, "test", "IPv4", 2)
+			ramClient := awsclient.NewRAMClient(newRAMClient(server, mcAccount))
+			for name, resourceARN := range map[string]string{
+				"prefix-list":     *prefixList.PrefixListArn,
+				"transit-gateway": *tgw.TransitGatewayArn,
+			} {
+				_, err := ramClient.ApplyResourceShare(ctx, awsclient.ResourceShare{
+					Name:              name,
+					ResourceArns:      []string{resourceARN},
+					ExternalAccountID: wcAccount,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			deleted, err := ramClient.DeleteResourceSharesOf(ctx, *prefixList.PrefixListArn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(ConsistOf("prefix-list"))
+			Expect(server.ResourceShares()).To(ConsistOf(HaveField("Name", "transit-gateway")))
+		})
+	})
+
+	Describe("SNS", func() {
+		It("records published messages", func() {
+			client := sns.New(sns.Options{
+				Region:       region,
+				BaseEndpoint: aws.String(server.URL()),
+				Credentials:  credentials.NewStaticCredentialsProvider(fakeaws.AccessKey(mcAccount), "secret", ""),
+			})
+
+			_, err := client.Publish(ctx, &sns.PublishInput{
+				TopicArn: aws.String("arn:aws:sns:eu-north-1:111111111111:test"),
+				Message:  aws.String("hello"),
+				MessageAttributes: map[string]snstypes.MessageAttributeValue{
+					"Key": {DataType: aws.String("String"), StringValue: aws.String("value")},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.Messages()).To(ConsistOf(fakeaws.Message{
+				TopicArn:   "arn:aws:sns:eu-north-1:111111111111:test",
+				Message:    "hello",
+				Attributes: map[string]string{"Key": "value"},
+			}))
+		})
+
+		It("deduplicates messages of FIFO topics", func() {
+			client := sns.New(sns.Options{
+				Region:       region,
+				BaseEndpoint: aws.String(server.URL()),
+				Credentials:  credentials.NewStaticCredentialsProvider(fakeaws.AccessKey(mcAccount), "secret", ""),
+			})
+
+			for _, deduplicationID := range []string{"first", "first", "second"} {
+				_, err := client.Publish(ctx, &sns.PublishInput{
+					TopicArn:               aws.String("arn:aws:sns:eu-north-1:111111111111:test.fifo"),
+					Message:                aws.String(deduplicationID),
+					MessageGroupId:         aws.String("group"),
+					MessageDeduplicationId: aws.String(deduplicationID),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(server.Messages()).To(HaveLen(2))
+
+			_, err := client.Publish(ctx, &sns.PublishInput{
+				TopicArn:               aws.String("arn:aws:sns:eu-north-1:111111111111:test"),
+				Message:                aws.String("hello"),
+				MessageGroupId:         aws.String("group"),
+				MessageDeduplicationId: aws.String("third"),
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+func newRAMClient(server *fakeaws.Server, account string) *ram.RAM {
+	sess, err := session.NewSession(&awsv1.Config{
+		Endpoint:    awsv1.String(server.URL()),
+		Region:      awsv1.String(server.Region()),
+		Credentials: credentialsv1.NewStaticCredentials(fakeaws.AccessKey(account), "secret", ""),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return ram.New(sess)
+}
@@ -78,8 +78,9 @@ var _ = Describe("RAM client", func() {
 
 	Describe("ApplyResourceShare", func() {
 		It("creates the share resource", func() {
-			err := ramClient.ApplyResourceShare(ctx, share)
+			shareARN, err := ramClient.ApplyResourceShare(ctx, share)
 			Expect(err).NotTo(HaveOccurred())
+			Expect(shareARN).NotTo(BeEmpty())
 			waitForResourceShareAvailability()
 
 			Eventually(getSharedResources(rawRamClient, prefixList)).Should(HaveLen(1))
@@ -87,7 +88,7 @@ var _ = Describe("RAM client", func() {
 
 		When("the resource has already been shared", func() {
 			BeforeEach(func() {
-				err := ramClient.ApplyResourceShare(ctx, share)
+				_, err := ramClient.ApplyResourceShare(ctx, share)
 				Expect(err).NotTo(HaveOccurred())
 				waitForResourceShareAvailability()
 
@@ -95,7 +96,7 @@ var _ = Describe("RAM client", func() {
 			})
 
 			It("does not return an error", func() {
-				err := ramClient.ApplyResourceShare(ctx, share)
+				_, err := ramClient.ApplyResourceShare(ctx, share)
 				Expect(err).NotTo(HaveOccurred())
 
 				Consistently(getSharedResources(rawRamClient, prefixList)).Should(HaveLen(1))
@@ -110,7 +111,7 @@ var _ = Describe("RAM client", func() {
 			})
 
 			It("returns an error", func() {
-				err := ramClient.ApplyResourceShare(ctx, share)
+				_, err := ramClient.ApplyResourceShare(ctx, share)
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -123,7 +124,7 @@ var _ = Describe("RAM client", func() {
 			})
 
 			It("returns an error", func() {
-				err := ramClient.ApplyResourceShare(ctx, share)
+				_, err := ramClient.ApplyResourceShare(ctx, share)
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -134,7 +135,7 @@ var _ = Describe("RAM client", func() {
 			})
 
 			It("returns an error", func() {
-				err := ramClient.ApplyResourceShare(ctx, share)
+				_, err := ramClient.ApplyResourceShare(ctx, share)
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -142,7 +143,7 @@ var _ = Describe("RAM client", func() {
 
 	Describe("DeleteResourceShare", func() {
 		BeforeEach(func() {
-			err := ramClient.ApplyResourceShare(ctx, share)
+			_, err := ramClient.ApplyResourceShare(ctx, share)
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(getSharedResources(rawRamClient, prefixList)).Should(HaveLen(1))
 
@@ -171,7 +172,7 @@ var _ = Describe("RAM client", func() {
 
 			When("creating a resource share with the same name", func() {
 				It("creates the share resource", func() {
-					err := ramClient.ApplyResourceShare(ctx, share)
+					_, err := ramClient.ApplyResourceShare(ctx, share)
 					Expect(err).NotTo(HaveOccurred())
 
 					Eventually(getSharedResources(rawRamClient, prefixList)).Should(HaveLen(1))
@@ -180,13 +181,13 @@ var _ = Describe("RAM client", func() {
 
 				When("the resource has already been recreated", func() {
 					BeforeEach(func() {
-						err := ramClient.ApplyResourceShare(ctx, share)
+						_, err := ramClient.ApplyResourceShare(ctx, share)
 						Expect(err).NotTo(HaveOccurred())
 						waitForResourceShareAvailability()
 					})
 
 					It("does not return an error", func() {
-						err := ramClient.ApplyResourceShare(ctx, share)
+						_, err := ramClient.ApplyResourceShare(ctx, share)
 						Expect(err).NotTo(HaveOccurred())
 					})
 				})